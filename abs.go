@@ -7,49 +7,59 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"math"
-	"os"
 
 	"gioui.org/f32"
-	"gioui.org/font/gofont"
 	"gioui.org/op"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
 	"gioui.org/text"
-	"gioui.org/unit"
-	"gioui.org/widget/material"
 )
 
 // Foundational methods, and methods using Gio standard coordinates
 
 // textops places text
 func (c *Canvas) textops(x, y, size float32, alignment text.Alignment, s string, fillcolor color.NRGBA) {
-	offset := x
+	if c.Emoji {
+		s = Shortcodes(s)
+	}
+	// text is aligned within a box the width of the canvas, or starts at x on one line
+	offset, width := x, float32(unwrapped)
 	switch alignment {
 	case text.End:
-		offset = x - c.Width
+		offset, width = x-c.Width, c.Width
 	case text.Middle:
-		offset = x - c.Width/2
+		offset, width = x-c.Width/2, c.Width
 	}
 	stack := op.Offset(image.Point{X: int(offset), Y: int(y - size)}).Push(c.Context.Ops) // shift to use baseline
-	l := material.Label(material.NewTheme(gofont.Collection()), unit.Sp(size), s)
-	l.Color = fillcolor
-	l.Alignment = alignment
-	l.Layout(c.Context)
+	c.layouttext(s, size, width, alignment, fillcolor)
 	stack.Pop()
+	textop := "text"
+	left, w := x, textextent(s, size)
+	switch alignment {
+	case text.End:
+		textop = "textend"
+		left -= w
+	case text.Middle:
+		textop = "textmid"
+		left -= w / 2
+	}
+	c.semantic("text", s, left, y-size, w, size)
+	c.record(DrawCall{Op: textop, Size: size, Text: s, Color: fillcolor}, x, y)
 }
 
 // AbsTextWrap places and wraps text at (x, y), wrapped at width
 func (c *Canvas) AbsTextWrap(x, y, size, width float32, s string, fillcolor color.NRGBA) {
+	if c.Emoji {
+		s = Shortcodes(s)
+	}
 	stack := op.Offset(image.Point{X: int(x), Y: int(y - size)}).Push(c.Context.Ops) // shift to use baseline
-	l := material.Label(material.NewTheme(gofont.Collection()), unit.Sp(size), s)
-	l.Color = fillcolor
-	c.Context.Constraints.Max.X = int(width)
-	l.Layout(c.Context)
-	c.Context.Constraints.Max.X = int(c.Width) // restore width...
+	c.layouttext(s, size, width, text.Start, fillcolor)
 	stack.Pop()
+	c.semantic("text", s, x, y-size, width, size)
+	c.record(DrawCall{Op: "textwrap", W: width, Size: size, Text: s, Color: fillcolor}, x, y)
 }
 
-// AbsText places text at (x,y)
+// AbsText places text at (x,y), on one line
 func (c *Canvas) AbsText(x, y, size float32, s string, fillcolor color.NRGBA) {
 	c.textops(x, y, size, text.Start, s, fillcolor)
 }
@@ -72,7 +82,11 @@ func (c *Canvas) AbsRect(x, y, w, h float32, fillcolor color.NRGBA) {
 	px[1], py[1] = x+w, y
 	px[2], py[2] = x+w, y+h
 	px[3], py[3] = x, y+h
+	c.record(DrawCall{Op: "rect", W: w, H: h, Color: fillcolor}, x, y)
+	rec := c.Recorder
+	c.Recorder = nil
 	c.AbsPolygon(px, py, fillcolor)
+	c.Recorder = rec
 }
 
 // AbsCenterRect makes a filled rectangle centered at (x, y), with dimensions (w,h)
@@ -80,6 +94,16 @@ func (c *Canvas) AbsCenterRect(x, y, w, h float32, fillcolor color.NRGBA) {
 	c.AbsRect(x-(w/2), y-(h/2), w, h, fillcolor)
 }
 
+// AbsStrokedRect outlines a rectangle; left corner at (x, y), with dimensions (w, h),
+// stroked size wide
+func (c *Canvas) AbsStrokedRect(x, y, w, h, size float32, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokedrect", W: w, H: h, Size: size, Color: strokecolor}, x, y)
+	rec := c.Recorder
+	c.Recorder = nil
+	c.AbsPolygonOutline([]float32{x, x + w, x + w, x}, []float32{y, y, y + h, y + h}, size, strokecolor)
+	c.Recorder = rec
+}
+
 // AbsVLine makes a vertical line beginning at (x,y) with dimension (w, h)
 func (c *Canvas) AbsVLine(x, y, w, h float32, fillcolor color.NRGBA) {
 	c.AbsLine(x, y, x, y+h, w, fillcolor)
@@ -103,17 +127,13 @@ func (c *Canvas) AbsGrid(width, height, size, interval float32, fillcolor color.
 
 // AbsCenterImage places a named image centered at (x, y)
 // using the specified dimensions (w, h), and hen scaled
+// Images are drawn from the cache, decoded at a resolution limited by MaxImageSize
 func (c *Canvas) AbsCenterImage(name string, x, y float32, w, h int, scale float32) {
-	r, err := os.Open(name)
-	if err != nil {
-		return
-	}
-	defer r.Close()
-	im, _, err := image.Decode(r)
+	ci, err := loadimagefor(name, scale/100)
 	if err != nil {
 		return
 	}
-	c.AbsImg(im, x, y, w, h, scale)
+	c.absimage(ci.imop, ci.natural, x, y, w, h, scale)
 }
 
 // AbsImg places a image.Image centered at (x, y)
@@ -122,15 +142,24 @@ func (c *Canvas) AbsImg(im image.Image, x, y float32, w, h int, scale float32) {
 	if im == nil {
 		return
 	}
+	c.absimage(paint.NewImageOp(im), im.Bounds().Max, x, y, w, h, scale)
+}
+
+// absimage places an image operation centered at (x, y); natural is the size of the
+// original image, which may be larger than the (downscaled) image in imop
+func (c *Canvas) absimage(imop paint.ImageOp, natural image.Point, x, y float32, w, h int, scale float32) {
 	// compute scaled image dimensions
 	// if w and h are zero, use the natural dimensions
 	sc := scale / 100
 	imw := float32(w) * sc
 	imh := float32(h) * sc
 	if w == 0 && h == 0 {
-		b := im.Bounds()
-		imw = float32(b.Max.X) * sc
-		imh = float32(b.Max.Y) * sc
+		imw = float32(natural.X) * sc
+		imh = float32(natural.Y) * sc
+	}
+	ps := sc
+	if is := imop.Size(); is.X > 0 && is.X < natural.X {
+		ps = sc * float32(natural.X) / float32(is.X)
 	}
 	// center the image
 	x = x - (imw / 2)
@@ -140,10 +169,12 @@ func (c *Canvas) AbsImg(im image.Image, x, y float32, w, h int, scale float32) {
 	ix := int(x)
 	iy := int(y)
 	stack := op.Offset(image.Pt(ix, iy)).Push(ops)
-	op.Affine(f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(sc, sc))).Add(ops)
-	paint.NewImageOp(im).Add(ops)
+	op.Affine(f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(ps, ps))).Add(ops)
+	imop.Add(ops)
 	paint.PaintOp{}.Add(ops)
 	stack.Pop()
+	c.semantic("image", "", x, y, imw, imh)
+	c.record(DrawCall{Op: "image", W: imw, H: imh}, x, y)
 }
 
 // AbsPolygon makes a closed, filled polygon with vertices in x and y
@@ -151,6 +182,13 @@ func (c *Canvas) AbsPolygon(x, y []float32, fillcolor color.NRGBA) {
 	if len(x) != len(y) {
 		return
 	}
+	if c.Recorder != nil {
+		points := make([]float32, 0, len(x)*2)
+		for i := range x {
+			points = append(points, x[i], y[i])
+		}
+		c.record(DrawCall{Op: "polygon", Color: fillcolor}, points...)
+	}
 	path := new(clip.Path)
 	ops := c.Context.Ops
 
@@ -174,6 +212,20 @@ func (c *Canvas) AbsPolygon(x, y []float32, fillcolor color.NRGBA) {
 
 // AbsLine makes a line from (x0,y0) to (x1, y1) using absolute coordinates
 func (c *Canvas) AbsLine(x0, y0, x1, y1, size float32, fillcolor color.NRGBA) {
+	c.AbsStyledLine(x0, y0, x1, y1, size, StrokeStyle{}, fillcolor)
+}
+
+// AbsStyledLine makes a line from (x0,y0) to (x1, y1), as AbsLine, in the stroke style
+func (c *Canvas) AbsStyledLine(x0, y0, x1, y1, size float32, style StrokeStyle, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "line", Size: size, Color: fillcolor}, x0, y0, x1, y1)
+	if style.dashed() {
+		c.dashedline(x0, y0, x1, y1, size, style, fillcolor, style.DashOffset)
+		return
+	}
+	if style.styled() {
+		c.strokestyled([]float32{x0, x1}, []float32{y0, y1}, size, style, fillcolor)
+		return
+	}
 	path := new(clip.Path)
 	ops := c.Context.Ops
 	path.Begin(ops)
@@ -187,6 +239,7 @@ func (c *Canvas) AbsLine(x0, y0, x1, y1, size float32, fillcolor color.NRGBA) {
 // AbsQuadBezier makes a filled quadratic curve
 // starting at (x, y), control point at (cx, cy), end point (ex, ey)
 func (c *Canvas) AbsQuadBezier(x, y, cx, cy, ex, ey, size float32, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "quadcurve", Color: fillcolor}, x, y, cx, cy, ex, ey)
 	path := new(clip.Path)
 	ops := c.Context.Ops
 	// control and endpoints are relative to the starting point
@@ -206,6 +259,17 @@ func (c *Canvas) AbsQuadBezier(x, y, cx, cy, ex, ey, size float32, fillcolor col
 // AbsStrokedQuadBezier makes a stroked quadratic curve
 // starting at (x, y), control point at (cx, cy), end point (ex, ey)
 func (c *Canvas) AbsStrokedQuadBezier(x, y, cx, cy, ex, ey, size float32, strokecolor color.NRGBA) {
+	c.AbsStyledQuadBezier(x, y, cx, cy, ex, ey, size, StrokeStyle{}, strokecolor)
+}
+
+// AbsStyledQuadBezier makes a stroked quadratic curve, as AbsStrokedQuadBezier, in the stroke style
+func (c *Canvas) AbsStyledQuadBezier(x, y, cx, cy, ex, ey, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokedquadcurve", Size: size, Color: strokecolor}, x, y, cx, cy, ex, ey)
+	if style.dashed() || style.styled() {
+		px, py := flattenquad(x, y, cx, cy, ex, ey)
+		c.strokepoints(px, py, size, style, strokecolor)
+		return
+	}
 	path := new(clip.Path)
 	ops := c.Context.Ops
 	// control and endpoints are relative to the starting point
@@ -218,11 +282,11 @@ func (c *Canvas) AbsStrokedQuadBezier(x, y, cx, cy, ex, ey, size float32, stroke
 	stack := clip.Stroke{Path: path.End(), Width: size}.Op().Push(ops)
 	paint.Fill(ops, strokecolor)
 	stack.Pop()
-
 }
 
 // AbsCubicBezier makes a filled cubic bezier curve
 func (c *Canvas) AbsCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "cubecurve", Color: fillcolor}, x, y, cx1, cy1, cx2, cy2, ex, ey)
 	path := new(clip.Path)
 	ops := c.Context.Ops
 	// control and end points are relative to the starting point
@@ -243,6 +307,17 @@ func (c *Canvas) AbsCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32,
 
 // AbsStrokedCubicBezier makes a stroked cubic bezier curve
 func (c *Canvas) AbsStrokedCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32, strokecolor color.NRGBA) {
+	c.AbsStyledCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size, StrokeStyle{}, strokecolor)
+}
+
+// AbsStyledCubicBezier makes a stroked cubic bezier curve, as AbsStrokedCubicBezier, in the stroke style
+func (c *Canvas) AbsStyledCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokedcubecurve", Size: size, Color: strokecolor}, x, y, cx1, cy1, cx2, cy2, ex, ey)
+	if style.dashed() || style.styled() {
+		px, py := flattencubic(x, y, cx1, cy1, cx2, cy2, ex, ey)
+		c.strokepoints(px, py, size, style, strokecolor)
+		return
+	}
 	path := new(clip.Path)
 	ops := c.Context.Ops
 	// control and end points are relative to the starting point
@@ -261,16 +336,47 @@ func (c *Canvas) AbsStrokedCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, size fl
 
 // AbsCircle makes a circle centered at (x, y), radius r
 func (c *Canvas) AbsCircle(x, y, radius float32, fillcolor color.NRGBA) {
-	path := new(clip.Path)
-	ops := c.Context.Ops
-	const k = 0.551915024494 // http://spencermortensen.com/articles/bezier-circle/
+	c.record(DrawCall{Op: "circle", Size: radius, Color: fillcolor}, x, y)
+	c.fillellipse(x, y, radius, radius, fillcolor)
+}
+
+// AbsEllipse makes a ellipse centered at (x, y) radii (w, h)
+func (c *Canvas) AbsEllipse(x, y, w, h float32, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "ellipse", W: w, H: h, Color: fillcolor}, x, y)
+	c.fillellipse(x, y, w, h, fillcolor)
+}
 
+// AbsStrokedCircle outlines a circle centered at (x, y), radius r, stroked size wide
+func (c *Canvas) AbsStrokedCircle(x, y, radius, size float32, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokedcircle", Size: radius, W: size, Color: strokecolor}, x, y)
+	rec := c.Recorder
+	c.Recorder = nil
+	c.AbsEllipticalArc(x, y, radius, radius, 0, 0, 2*math.Pi, size, strokecolor)
+	c.Recorder = rec
+}
+
+// AbsStrokedEllipse outlines an ellipse centered at (x, y), radii (w, h), stroked size wide
+func (c *Canvas) AbsStrokedEllipse(x, y, w, h, size float32, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokedellipse", W: w, H: h, Size: size, Color: strokecolor}, x, y)
+	rec := c.Recorder
+	c.Recorder = nil
+	c.AbsEllipticalArc(x, y, w, h, 0, 0, 2*math.Pi, size, strokecolor)
+	c.Recorder = rec
+}
+
+// AbsArc makes circular arc centered at (x, y), through angles start and end;
+// the angles are measured in radians from the positive x axis, and increase clockwise
+// on the screen, as y increases downward (π/2 is straight down).
+// If end is less than start, the arc is swept the other way.
+// N.B: derived from the clipLoader function in widget/material/loader.go
+func (c *Canvas) AbsArc(x, y, radius float32, start, end float64, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "arc", Size: radius, A1: start, A2: end, Color: fillcolor}, x, y)
+	ops := c.Context.Ops
+	path := new(clip.Path)
 	path.Begin(ops)
-	path.Move(f32.Point{X: x + radius, Y: y})
-	path.Cube(f32.Point{X: 0, Y: radius * k}, f32.Point{X: -radius + radius*k, Y: radius}, f32.Point{X: -radius, Y: radius})    // SE
-	path.Cube(f32.Point{X: -radius * k, Y: 0}, f32.Point{X: -radius, Y: -radius + radius*k}, f32.Point{X: -radius, Y: -radius}) // SW
-	path.Cube(f32.Point{X: 0, Y: -radius * k}, f32.Point{X: radius - radius*k, Y: -radius}, f32.Point{X: radius, Y: -radius})   // NW
-	path.Cube(f32.Point{X: radius * k, Y: 0}, f32.Point{X: radius, Y: radius - radius*k}, f32.Point{X: radius, Y: radius})      // NE
+	path.MoveTo(f32.Pt(x, y)) // move to the center
+	path.LineTo(arcpoint(x, y, radius, start))
+	arcto(path, x, y, radius, start, end)
 	path.Close()
 	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
 	paint.ColorOp{Color: fillcolor}.Add(ops)
@@ -278,44 +384,111 @@ func (c *Canvas) AbsCircle(x, y, radius float32, fillcolor color.NRGBA) {
 	stack.Pop()
 }
 
-// AbsEllipse makes a ellipse centered at (x, y) radii (w, h)
-func (c *Canvas) AbsEllipse(x, y, w, h float32, fillcolor color.NRGBA) {
-	path := new(clip.Path)
+// AbsAnnularArc makes a band between circles of radii r1 and r2 centered at (x, y),
+// through angles start and end (radians, as AbsArc). A sweep of a full turn or more
+// makes a ring; an inner radius of zero, a wedge.
+func (c *Canvas) AbsAnnularArc(x, y, r1, r2 float32, start, end float64, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "annulararc", W: r1, Size: r2, A1: start, A2: end, Color: fillcolor}, x, y)
+	if end-start > 2*math.Pi {
+		end = start + 2*math.Pi
+	}
+	if start-end > 2*math.Pi {
+		end = start - 2*math.Pi
+	}
 	ops := c.Context.Ops
-	const k = 0.551915024494 // http://spencermortensen.com/articles/bezier-circle/
+	path := new(clip.Path)
 	path.Begin(ops)
-	path.Move(f32.Point{X: x + w, Y: y})
-	path.Cube(f32.Point{X: 0, Y: h * k}, f32.Point{X: -w + w*k, Y: h}, f32.Point{X: -w, Y: h})    // SE
-	path.Cube(f32.Point{X: -w * k, Y: 0}, f32.Point{X: -w, Y: -h + h*k}, f32.Point{X: -w, Y: -h}) // SW
-	path.Cube(f32.Point{X: 0, Y: -h * k}, f32.Point{X: w - w*k, Y: -h}, f32.Point{X: w, Y: -h})   // NW
-	path.Cube(f32.Point{X: w * k, Y: 0}, f32.Point{X: w, Y: h - h*k}, f32.Point{X: w, Y: h})      // NE
+	path.MoveTo(arcpoint(x, y, r2, start))
+	arcto(path, x, y, r2, start, end)
+	path.LineTo(arcpoint(x, y, r1, end))
+	arcto(path, x, y, r1, end, start)
+	path.Close()
 	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
 	paint.ColorOp{Color: fillcolor}.Add(ops)
 	paint.PaintOp{}.Add(ops)
 	stack.Pop()
 }
 
-// AbsArc makes circular arc centered at (x, y), through angles start and end;
-// the angles are measured in radians and increase counter-clockwise.
-// N.B: derived from the clipLoader function in widget/material/loader.go
-func (c *Canvas) AbsArc(x, y, radius float32, start, end float64, fillcolor color.NRGBA) {
+// AbsEllipticalArc strokes an arc of the ellipse centered at (x, y), radii (rx, ry), turned by
+// rotation, from angle start to end (radians, as AbsArc), size wide
+func (c *Canvas) AbsEllipticalArc(x, y, rx, ry float32, rotation, start, end float64, size float32, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "ellipticalarc", W: rx, H: ry, Size: size, A1: start, A2: end, Color: strokecolor}, x, y)
+	n := int(math.Ceil(math.Abs(end-start) * math.Max(float64(rx), float64(ry)) / 4))
+	if n < 8 {
+		n = 8
+	}
+	if n > 1000 {
+		n = 1000
+	}
+	sinr, cosr := math.Sincos(rotation)
+	px := make([]float32, n+1)
+	py := make([]float32, n+1)
+	for i := 0; i <= n; i++ {
+		sint, cost := math.Sincos(start + (end-start)*float64(i)/float64(n))
+		ex, ey := float64(rx)*cost, float64(ry)*sint
+		px[i] = x + float32(cosr*ex-sinr*ey)
+		py[i] = y + float32(sinr*ex+cosr*ey)
+	}
+	c.strokepoints(px, py, size, StrokeStyle{}, strokecolor)
+}
+
+// strokepoints strokes the path through the points in the style
+func (c *Canvas) strokepoints(x, y []float32, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	n := len(x)
+	if style.dashed() {
+		offset := style.DashOffset
+		for i := 1; i < n; i++ {
+			c.dashedline(x[i-1], y[i-1], x[i], y[i], size, style, strokecolor, offset)
+			offset += float32(math.Hypot(float64(x[i]-x[i-1]), float64(y[i]-y[i-1])))
+		}
+		return
+	}
+	if style.styled() {
+		c.strokestyled(x, y, size, style, strokecolor)
+		return
+	}
 	ops := c.Context.Ops
-	sine, cose := math.Sincos(start)
 	path := new(clip.Path)
 	path.Begin(ops)
-	path.Move(f32.Pt(x, y))                                 // move to the center
-	pen := f32.Pt(float32(cose), float32(sine)).Mul(radius) // starting point
-	path.Line(pen)
+	path.MoveTo(f32.Pt(x[0], y[0]))
+	for i := 1; i < n; i++ {
+		path.LineTo(f32.Pt(x[i], y[i]))
+	}
+	stack := clip.Stroke{Path: path.End(), Width: size}.Op().Push(ops)
+	paint.Fill(ops, strokecolor)
+	stack.Pop()
+}
 
+// arcpoint returns the point at angle a on the circle centered at (x, y), radius r,
+// in absolute coordinates: the angles of the canvas' arcs increase clockwise on the screen
+func arcpoint(x, y, r float32, a float64) f32.Point {
+	sin, cos := math.Sincos(a)
+	return f32.Pt(x+r*float32(cos), y+r*float32(sin))
+}
+
+// arcto continues a path, from the point at angle start, along a circular arc centered
+// at (x, y) to the angle end, in whichever direction that is
+func arcto(path *clip.Path, x, y, radius float32, start, end float64) {
+	if radius <= 0 {
+		return
+	}
 	// The clip path uses quadratic beziér curves to approximate
 	// a circle arc. Minimize the error by capping the length of
 	// each curve segment.
 	const maxArcLen = 20.0
 	arcPerRadian := float64(radius) * math.Pi
-	anglePerSegment := maxArcLen / arcPerRadian
-	for angle := start; angle < end; {
+	anglePerSegment := math.Min(maxArcLen/arcPerRadian, math.Pi/4)
+	if end < start {
+		anglePerSegment = -anglePerSegment
+	}
+	center := f32.Pt(x, y)
+	sine, cose := math.Sincos(start)
+	for angle := start; angle != end; {
 		angle += anglePerSegment
-		if angle > end {
+		if (anglePerSegment > 0 && angle > end) || (anglePerSegment < 0 && angle < end) {
 			angle = end
 		}
 		sins, coss := sine, cose
@@ -325,14 +498,8 @@ func (c *Canvas) AbsArc(x, y, radius float32, start, end float64, fillcolor colo
 		div := 1.0 / (coss*sine - cose*sins)
 		ctrlPt := f32.Point{X: float32((sine - sins) * div), Y: -float32((cose - coss) * div)}.Mul(radius)
 		endPt := f32.Pt(float32(cose), float32(sine)).Mul(radius)
-		path.Quad(ctrlPt.Sub(pen), endPt.Sub(pen))
-		pen = endPt
+		path.QuadTo(center.Add(ctrlPt), center.Add(endPt))
 	}
-	path.Close()
-	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
-	paint.ColorOp{Color: fillcolor}.Add(ops)
-	paint.PaintOp{}.Add(ops)
-	stack.Pop()
 }
 
 // AbsTranslate moves current location by (x,y)
@@ -0,0 +1,110 @@
+package giocanvas
+
+// Anchors: positioning items by a point other than their center
+
+// Anchor is the point of an item placed at a location, as fractions of the
+// item's width (X: 0 left, 1 right) and height (Y: 0 bottom, 1 top)
+type Anchor struct {
+	X, Y float32
+}
+
+// The nine standard anchor points
+var (
+	AnchorTopLeft     = Anchor{0, 1}
+	AnchorTop         = Anchor{0.5, 1}
+	AnchorTopRight    = Anchor{1, 1}
+	AnchorLeft        = Anchor{0, 0.5}
+	AnchorCenter      = Anchor{0.5, 0.5}
+	AnchorRight       = Anchor{1, 0.5}
+	AnchorBottomLeft  = Anchor{0, 0}
+	AnchorBottom      = Anchor{0.5, 0}
+	AnchorBottomRight = Anchor{1, 0}
+)
+
+// Origin returns the lower left corner of an item sized (w, h), with its anchor at (x, y)
+func (a Anchor) Origin(x, y, w, h float32) (float32, float32) {
+	return x - a.X*w, y - a.Y*h
+}
+
+// Point returns the location of the anchor of an item sized (w, h), with its lower left corner at (x, y)
+func (a Anchor) Point(x, y, w, h float32) (float32, float32) {
+	return x + a.X*w, y + a.Y*h
+}
+
+// Layout helpers: placing items within a box
+
+// Size is the width and height of an item, using percentage-based measures
+type Size struct {
+	W, H float32
+}
+
+// Box is a rectangle using percentage-based measures, with its lower left corner at (X, Y)
+type Box struct {
+	X, Y, W, H float32
+}
+
+// Page is the box covering the whole canvas
+var Page = Box{0, 0, 100, 100}
+
+// At returns the location of the anchor point of the box
+func (b Box) At(a Anchor) (float32, float32) {
+	return a.Point(b.X, b.Y, b.W, b.H)
+}
+
+// Center returns the center of the box
+func (b Box) Center() (float32, float32) {
+	return b.At(AnchorCenter)
+}
+
+// Inset returns the box shrunk by gap on every side
+func (b Box) Inset(gap float32) Box {
+	return Box{b.X + gap, b.Y + gap, b.W - 2*gap, b.H - 2*gap}
+}
+
+// Align places an item of size s within the box, aligned by the anchor:
+// AnchorTopRight places the item in the top right corner of the box.
+func (b Box) Align(a Anchor, s Size) Box {
+	return Box{b.X + a.X*(b.W-s.W), b.Y + a.Y*(b.H-s.H), s.W, s.H}
+}
+
+// Stack places items on top of each other, each aligned within the box by the anchor
+func (b Box) Stack(a Anchor, sizes ...Size) []Box {
+	boxes := make([]Box, len(sizes))
+	for i, s := range sizes {
+		boxes[i] = b.Align(a, s)
+	}
+	return boxes
+}
+
+// Row places items left to right, separated by gap. The row is aligned
+// within the box horizontally, and each item vertically, by the anchor.
+func (b Box) Row(gap float32, a Anchor, sizes ...Size) []Box {
+	total := gap * float32(len(sizes)-1)
+	for _, s := range sizes {
+		total += s.W
+	}
+	x := b.X + a.X*(b.W-total)
+	boxes := make([]Box, len(sizes))
+	for i, s := range sizes {
+		boxes[i] = Box{x, b.Y + a.Y*(b.H-s.H), s.W, s.H}
+		x += s.W + gap
+	}
+	return boxes
+}
+
+// Column places items top to bottom, separated by gap. The column is aligned
+// within the box vertically, and each item horizontally, by the anchor.
+func (b Box) Column(gap float32, a Anchor, sizes ...Size) []Box {
+	total := gap * float32(len(sizes)-1)
+	for _, s := range sizes {
+		total += s.H
+	}
+	y := b.Y + a.Y*(b.H-total) + total
+	boxes := make([]Box, len(sizes))
+	for i, s := range sizes {
+		y -= s.H
+		boxes[i] = Box{b.X + a.X*(b.W-s.W), y, s.W, s.H}
+		y -= gap
+	}
+	return boxes
+}
@@ -0,0 +1,34 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	b := Box{10, 10, 80, 20}
+	row := b.Row(2, AnchorCenter, Size{10, 4}, Size{20, 8}, Size{10, 4})
+	if row[0].X != 28 || row[1].X != 40 || row[2].X != 62 || row[1].Y != 16 {
+		t.Errorf("row: %v", row)
+	}
+	col := b.Column(1, AnchorTopLeft, Size{10, 4}, Size{10, 4})
+	if col[0].Y != 26 || col[1].Y != 21 || col[0].X != 10 {
+		t.Errorf("column: %v", col)
+	}
+	if a := b.Align(AnchorBottomRight, Size{10, 5}); a != (Box{80, 10, 10, 5}) {
+		t.Errorf("align: %v", a)
+	}
+}
+
+func TestAnchorRect(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	for _, a := range []Anchor{AnchorTopLeft, AnchorCenter, AnchorBottomRight} {
+		c.AnchorRect(50, 50, 20, 10, a, c.TextColor)
+	}
+	want := [][2]float32{{50, 50}, {40, 55}, {30, 60}}
+	for i, r := range log.Find("rect") {
+		if math.Abs(float64(r.Points[0]-want[i][0])) > 1e-3 || math.Abs(float64(r.Points[1]-want[i][1])) > 1e-3 {
+			t.Errorf("anchor %d: top left (%v, %v), want %v", i, r.Points[0], r.Points[1], want[i])
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package bench has standard scenes for measuring the performance of giocanvas drawing
+package bench
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math/rand"
+	"sort"
+	"time"
+
+	"gioui.org/io/system"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Scene draws a standard frame on a canvas
+type Scene struct {
+	Name string
+	Draw func(c *gc.Canvas)
+}
+
+// Scenes are the standard scenes: text-heavy, shape-heavy and image-heavy
+var Scenes = []Scene{
+	{"text", Text},
+	{"shapes", Shapes},
+	{"images", Images},
+}
+
+// Text draws a page of text: a title, paragraphs and a table of numbers
+func Text(c *gc.Canvas) {
+	c.CText(50, 92, 4, "Benchmark: text", c.TextColor)
+	for i := 0; i < 6; i++ {
+		c.TextWrap(5, 85-float32(i)*8, 1.5, 40, "Now is the time for all good men to come to the aid of the party.", c.TextColor)
+	}
+	for row := 0; row < 30; row++ {
+		y := 85 - float32(row)*2.5
+		for col := 0; col < 4; col++ {
+			x := 60 + float32(col)*10
+			c.EText(x, y, 1.5, fmt.Sprintf("%d.%02d", row*col, row+col), c.TextColor)
+		}
+	}
+}
+
+// Shapes draws many circles, rectangles, lines and curves
+func Shapes(c *gc.Canvas) {
+	r := rand.New(rand.NewSource(1))
+	rc := func() color.NRGBA {
+		return color.NRGBA{uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), 200}
+	}
+	for i := 0; i < 2000; i++ {
+		x, y := r.Float32()*100, r.Float32()*100
+		switch i % 5 {
+		case 0:
+			c.Circle(x, y, 0.5, rc())
+		case 1:
+			c.CenterRect(x, y, 2, 1, rc())
+		case 2:
+			c.Line(x, y, x+3, y+2, 0.2, rc())
+		case 3:
+			c.Ellipse(x, y, 1, 0.5, rc())
+		case 4:
+			c.QuadStrokedCurve(x, y, x+2, y+4, x+4, y, 0.2, rc())
+		}
+	}
+}
+
+// benchimage is the image drawn by the image scene
+var benchimage = func() image.Image {
+	im := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	for y := 0; y < 256; y++ {
+		for x := 0; x < 256; x++ {
+			im.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), uint8(x ^ y), 255})
+		}
+	}
+	return im
+}()
+
+// Images draws a grid of images
+func Images(c *gc.Canvas) {
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			c.Img(benchimage, 6+float32(col)*12.5, 6+float32(row)*12.5, 256, 256, 20)
+		}
+	}
+}
+
+// Stats are frame time statistics
+type Stats struct {
+	Frames                      int
+	Mean, Median, P95, P99, Max time.Duration
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d frames: mean %v median %v p95 %v p99 %v max %v", s.Frames, s.Mean, s.Median, s.P95, s.P99, s.Max)
+}
+
+// Run draws the scene for the given number of frames on a canvas of size (width, height),
+// reusing the canvas between frames, and returns the frame time statistics.
+// The time measured is the time to build the frame's operations.
+func Run(scene Scene, width, height float32, frames int) Stats {
+	if frames < 1 {
+		return Stats{}
+	}
+	c := gc.NewCanvas(width, height, system.FrameEvent{})
+	times := make([]time.Duration, frames)
+	for i := range times {
+		start := time.Now()
+		c.Reset(width, height, system.FrameEvent{})
+		scene.Draw(c)
+		times[i] = time.Since(start)
+	}
+	return stats(times)
+}
+
+// stats computes the statistics of a set of frame times
+func stats(times []time.Duration) Stats {
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	var total time.Duration
+	for _, t := range times {
+		total += t
+	}
+	n := len(times)
+	at := func(p float64) time.Duration {
+		return times[int(p*float64(n-1))]
+	}
+	return Stats{
+		Frames: n,
+		Mean:   total / time.Duration(n),
+		Median: at(0.5),
+		P95:    at(0.95),
+		P99:    at(0.99),
+		Max:    times[n-1],
+	}
+}
@@ -0,0 +1,34 @@
+package bench
+
+import (
+	"testing"
+
+	"gioui.org/io/system"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// BenchmarkScenes times each standard scene; compare runs with benchstat.
+func BenchmarkScenes(b *testing.B) {
+	for _, scene := range Scenes {
+		b.Run(scene.Name, func(b *testing.B) {
+			c := gc.NewCanvas(1000, 1000, system.FrameEvent{})
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				c.Reset(1000, 1000, system.FrameEvent{})
+				scene.Draw(c)
+			}
+			s := Run(scene, 1000, 1000, 20)
+			b.ReportMetric(float64(s.P95.Nanoseconds()), "p95-ns/frame")
+			b.ReportMetric(float64(s.P99.Nanoseconds()), "p99-ns/frame")
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, scene := range Scenes {
+		s := Run(scene, 500, 500, 5)
+		if s.Frames != 5 || s.Max < s.Median || s.Median <= 0 {
+			t.Errorf("%s: %v", scene.Name, s)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package giocanvas
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Blend modes: compositing one image onto another other than by painting over it.
+// Gio paints only over what is beneath, and has no blend modes, so blending is done
+// on the CPU, with pixels rendered offscreen: of canvases, by the capture package's Blend,
+// for exported images, or of groups of drawing calls on a canvas, by BeginBlendGroup.
+
+// BlendMode is how the colors of an image combine with those beneath it
+type BlendMode int
+
+// The blend modes. Multiply darkens, screen lightens, and overlay multiplies the dark
+// and screens the light of what is beneath; add sums the colors, so that overlapping
+// marks build up, as in density plots.
+const (
+	BlendNormal BlendMode = iota
+	BlendMultiply
+	BlendScreen
+	BlendOverlay
+	BlendAdd
+)
+
+// blendchannel combines a backdrop and source color channel (0-1, not premultiplied)
+func blendchannel(mode BlendMode, b, s float64) float64 {
+	switch mode {
+	case BlendMultiply:
+		return b * s
+	case BlendScreen:
+		return b + s - b*s
+	case BlendOverlay:
+		if b <= 0.5 {
+			return 2 * b * s
+		}
+		return 1 - 2*(1-b)*(1-s)
+	}
+	return s
+}
+
+// blendpixel composites the source color onto the backdrop (both premultiplied)
+func blendpixel(mode BlendMode, b, s color.RGBA) color.RGBA {
+	ab, as := float64(b.A)/255, float64(s.A)/255
+	if mode == BlendAdd {
+		return color.RGBA{
+			R: uint8(math.Min(255, float64(b.R)+float64(s.R))),
+			G: uint8(math.Min(255, float64(b.G)+float64(s.G))),
+			B: uint8(math.Min(255, float64(b.B)+float64(s.B))),
+			A: uint8(math.Min(255, float64(b.A)+float64(s.A))),
+		}
+	}
+	ao := as + ab*(1-as)
+	channel := func(bc, sc uint8) uint8 {
+		pb, ps := float64(bc)/255, float64(sc)/255 // premultiplied
+		var cb, cs float64
+		if ab > 0 {
+			cb = pb / ab
+		}
+		if as > 0 {
+			cs = ps / as
+		}
+		co := ps*(1-ab) + pb*(1-as) + as*ab*blendchannel(mode, cb, cs)
+		return uint8(math.Round(255 * math.Max(0, math.Min(ao, co))))
+	}
+	return color.RGBA{channel(b.R, s.R), channel(b.G, s.G), channel(b.B, s.B), uint8(math.Round(255 * ao))}
+}
+
+// Blend composites src onto dst, where they overlap, in the blend mode
+func Blend(dst *image.RGBA, src image.Image, mode BlendMode) {
+	r := dst.Bounds().Intersect(src.Bounds())
+	at := srcpixels(src)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		d := dst.Pix[dst.PixOffset(r.Min.X, y):]
+		for x := r.Min.X; x < r.Max.X; x, d = x+1, d[4:] {
+			s := at(x, y)
+			if s.A == 0 && mode != BlendAdd {
+				continue
+			}
+			b := blendpixel(mode, color.RGBA{d[0], d[1], d[2], d[3]}, s)
+			d[0], d[1], d[2], d[3] = b.R, b.G, b.B, b.A
+		}
+	}
+}
+
+// srcpixels returns a function reading the (premultiplied) colors of an image,
+// from the pixels themselves for *image.RGBA and *image.NRGBA
+func srcpixels(src image.Image) func(x, y int) color.RGBA {
+	switch s := src.(type) {
+	case *image.RGBA:
+		return func(x, y int) color.RGBA {
+			p := s.Pix[s.PixOffset(x, y):]
+			return color.RGBA{p[0], p[1], p[2], p[3]}
+		}
+	case *image.NRGBA:
+		return func(x, y int) color.RGBA {
+			p := s.Pix[s.PixOffset(x, y):]
+			a := uint32(p[3]) * 0x101
+			pm := func(v uint8) uint8 { return uint8(uint32(v) * 0x101 * a / 0xffff >> 8) }
+			return color.RGBA{pm(p[0]), pm(p[1]), pm(p[2]), p[3]}
+		}
+	}
+	return func(x, y int) color.RGBA {
+		return color.RGBAModel.Convert(src.At(x, y)).(color.RGBA)
+	}
+}
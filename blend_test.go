@@ -0,0 +1,53 @@
+package giocanvas
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBlend(t *testing.T) {
+	gray := color.RGBA{128, 128, 128, 255}
+	tests := []struct {
+		mode     BlendMode
+		dst, src color.RGBA
+		want     color.RGBA
+	}{
+		{BlendNormal, gray, color.RGBA{255, 0, 0, 255}, color.RGBA{255, 0, 0, 255}},
+		{BlendMultiply, color.RGBA{255, 255, 255, 255}, gray, gray},
+		{BlendMultiply, gray, color.RGBA{0, 0, 0, 255}, color.RGBA{0, 0, 0, 255}},
+		{BlendScreen, color.RGBA{0, 0, 0, 255}, gray, gray},
+		{BlendScreen, gray, color.RGBA{255, 255, 255, 255}, color.RGBA{255, 255, 255, 255}},
+		{BlendOverlay, color.RGBA{0, 0, 0, 255}, gray, color.RGBA{0, 0, 0, 255}},
+		{BlendAdd, gray, gray, color.RGBA{255, 255, 255, 255}},
+		{BlendAdd, color.RGBA{0, 0, 0, 0}, color.RGBA{50, 0, 0, 50}, color.RGBA{50, 0, 0, 50}},
+		// a clear source leaves the backdrop
+		{BlendMultiply, gray, color.RGBA{}, gray},
+	}
+	for _, test := range tests {
+		img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		img.SetRGBA(0, 0, test.dst)
+		src := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		src.SetRGBA(0, 0, test.src)
+		Blend(img, src, test.mode)
+		if got := img.RGBAAt(0, 0); got != test.want {
+			t.Errorf("mode %d, %v onto %v: got %v, want %v", test.mode, test.src, test.dst, got, test.want)
+		}
+	}
+}
+
+func TestBlendSources(t *testing.T) {
+	// sources of each kind read as their premultiplied colors
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	nrgba.SetNRGBA(1, 1, color.NRGBA{200, 100, 50, 128})
+	gray := image.NewGray(image.Rect(0, 0, 2, 2))
+	gray.SetGray(1, 1, color.Gray{90})
+	for _, src := range []image.Image{nrgba, gray} {
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		Blend(img, src, BlendAdd)
+		want := color.RGBAModel.Convert(src.At(1, 1)).(color.RGBA)
+		if got := img.RGBAAt(1, 1); got != want {
+			t.Errorf("%T: got %v, want %v", src, got, want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package capture
+
+import (
+	"image"
+
+	"github.com/ajstarks/giocanvas"
+)
+
+// Blend renders the canvases offscreen, and composites each layer onto the base, in
+// order, in the blend mode, on the CPU; layers should leave their background clear.
+// The result may be drawn on another canvas with Img.
+func Blend(base *giocanvas.Canvas, mode giocanvas.BlendMode, layers ...*giocanvas.Canvas) (*image.RGBA, error) {
+	img, err := Image(base)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range layers {
+		limg, err := Image(l)
+		if err != nil {
+			return nil, err
+		}
+		giocanvas.Blend(img, limg, mode)
+	}
+	return img, nil
+}
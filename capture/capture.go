@@ -0,0 +1,166 @@
+// Package capture renders giocanvas drawing offscreen, for export and analysis
+package capture
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"sync"
+
+	"gioui.org/gpu/headless"
+	"gioui.org/op"
+	"github.com/ajstarks/giocanvas"
+)
+
+// Image renders the canvas offscreen, returning its pixels
+func Image(c *giocanvas.Canvas) (*image.RGBA, error) {
+	return render(c.Context.Ops, int(c.Width), int(c.Height))
+}
+
+// render renders operations offscreen, w by h pixels
+func render(ops *op.Ops, w, h int) (*image.RGBA, error) {
+	win, err := headless.NewWindow(w, h)
+	if err != nil {
+		return nil, err
+	}
+	defer win.Release()
+	if err := win.Frame(ops); err != nil {
+		return nil, err
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	err = win.Screenshot(img)
+	return img, err
+}
+
+// Renderer renders operations offscreen, reusing its window from one call to the next
+// while the size is unchanged. As a canvas' Renderer, it renders opacity groups.
+// Release frees the window.
+type Renderer struct {
+	mu   sync.Mutex
+	win  *headless.Window
+	w, h int
+}
+
+// Render renders operations offscreen, w by h pixels, returning the pixels
+func (r *Renderer) Render(ops *op.Ops, w, h int) (*image.RGBA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.win == nil || r.w != w || r.h != h {
+		r.release()
+		win, err := headless.NewWindow(w, h)
+		if err != nil {
+			return nil, err
+		}
+		r.win, r.w, r.h = win, w, h
+	}
+	if err := r.win.Frame(ops); err != nil {
+		return nil, err
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	err := r.win.Screenshot(img)
+	return img, err
+}
+
+// Release frees the renderer's window
+func (r *Renderer) Release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+}
+
+func (r *Renderer) release() {
+	if r.win != nil {
+		r.win.Release()
+		r.win = nil
+	}
+}
+
+// PNG renders the canvas, writing it to w in PNG format
+func PNG(c *giocanvas.Canvas, w io.Writer) error {
+	img, err := Image(c)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// WritePNG renders the canvas to the named PNG file
+func WritePNG(c *giocanvas.Canvas, filename string) error {
+	img, err := Image(c)
+	if err != nil {
+		return err
+	}
+	return writepng(img, filename)
+}
+
+// Region renders the canvas, returning the pixels within the box (percentage-based)
+func Region(c *giocanvas.Canvas, b giocanvas.Box) (*image.RGBA, error) {
+	img, err := Image(c)
+	if err != nil {
+		return nil, err
+	}
+	r := image.Rect(
+		int(b.X*c.Width/100), int((100-b.Y-b.H)*c.Height/100),
+		int((b.X+b.W)*c.Width/100), int((100-b.Y)*c.Height/100),
+	).Intersect(img.Bounds())
+	return img.SubImage(r).(*image.RGBA), nil
+}
+
+// sampler renders the canvases of ColorAt, keeping its window from one sample to the next
+var sampler Renderer
+
+// ColorAt renders the canvas, returning the color of the pixel at (x, y), using percentage-based
+// coordinates. The canvas is rendered by its Renderer, if it has one, or by a renderer kept
+// for ColorAt, so that sampling colors, as an eyedropper does, does not make a window each time.
+func ColorAt(c *giocanvas.Canvas, x, y float32) (color.NRGBA, error) {
+	var r giocanvas.Renderer = &sampler
+	if c.Renderer != nil {
+		r = c.Renderer
+	}
+	img, err := r.Render(c.Context.Ops, int(c.Width), int(c.Height))
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	b := img.Bounds()
+	px, py := pixelat(x, y, b.Dx(), b.Dy())
+	return color.NRGBAModel.Convert(img.RGBAAt(b.Min.X+px, b.Min.Y+py)).(color.NRGBA), nil
+}
+
+// pixelat returns the pixel at (x, y), using percentage-based coordinates, of an image
+// w by h pixels: the edges of the canvas, at 0 and 100, are its first and last pixels
+func pixelat(x, y float32, w, h int) (int, int) {
+	clamp := func(v, n int) int {
+		if v >= n {
+			v = n - 1
+		}
+		if v < 0 {
+			v = 0
+		}
+		return v
+	}
+	return clamp(int(x*float32(w)/100), w), clamp(int((100-y)*float32(h)/100), h)
+}
+
+// WritePNGRegion renders the part of the canvas within the box to the named PNG file
+func WritePNGRegion(c *giocanvas.Canvas, b giocanvas.Box, filename string) error {
+	img, err := Region(c, b)
+	if err != nil {
+		return err
+	}
+	return writepng(img, filename)
+}
+
+// writepng writes an image to the named PNG file
+func writepng(img image.Image, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,21 @@
+package capture
+
+import "testing"
+
+func TestPixelAt(t *testing.T) {
+	tests := []struct {
+		x, y   float32
+		px, py int
+	}{
+		{0, 100, 0, 0},
+		{100, 0, 199, 99}, // the far edges are the last pixels, not past them
+		{50, 50, 100, 50},
+		{-10, 120, 0, 0},
+		{110, -5, 199, 99},
+	}
+	for _, tc := range tests {
+		if px, py := pixelat(tc.x, tc.y, 200, 100); px != tc.px || py != tc.py {
+			t.Errorf("pixelat(%v, %v) = %d, %d; want %d, %d", tc.x, tc.y, px, py, tc.px, tc.py)
+		}
+	}
+}
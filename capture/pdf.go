@@ -0,0 +1,97 @@
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+	"os"
+)
+
+// PDF writes the images to w as the pages of a PDF document, each filling
+// a page width by height points
+func PDF(w io.Writer, pages []image.Image, width, height float64) error {
+	bw := bufio.NewWriter(w)
+	var offsets []int
+	n := 0
+	write := func(format string, args ...interface{}) {
+		k, _ := fmt.Fprintf(bw, format, args...)
+		n += k
+	}
+	object := func() int {
+		offsets = append(offsets, n)
+		return len(offsets)
+	}
+
+	write("%%PDF-1.4\n")
+	object()
+	write("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	object()
+	write("2 0 obj\n<< /Type /Pages /Count %d /Kids [", len(pages))
+	for i := range pages {
+		write(" %d 0 R", 3+3*i)
+	}
+	write(" ] >>\nendobj\n")
+	for _, img := range pages {
+		pixels, err := deflate(img)
+		if err != nil {
+			return err
+		}
+		b := img.Bounds()
+		page := object()
+		write("%d 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /XObject << /Im %d 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			page, width, height, page+2, page+1)
+		content := fmt.Sprintf("q %g 0 0 %g 0 0 cm /Im Do Q\n", width, height)
+		write("%d 0 obj\n<< /Length %d >>\nstream\n%sendstream\nendobj\n", object(), len(content), content)
+		write("%d 0 obj\n<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n",
+			object(), b.Dx(), b.Dy(), len(pixels))
+		k, _ := bw.Write(pixels)
+		n += k
+		write("\nendstream\nendobj\n")
+	}
+	xref := n
+	write("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		write("%010d 00000 n \n", off)
+	}
+	write("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return bw.Flush()
+}
+
+// deflate returns the compressed RGB samples of an image
+func deflate(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	b := img.Bounds()
+	row := make([]byte, 3*b.Dx())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			i := 3 * (x - b.Min.X)
+			row[i], row[i+1], row[i+2] = byte(r>>8), byte(g>>8), byte(bl>>8)
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WritePDF writes the images to the named file as the pages of a PDF document,
+// each filling a page width by height points
+func WritePDF(pages []image.Image, filename string, width, height float64) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := PDF(f, pages, width, height); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
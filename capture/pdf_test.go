@@ -0,0 +1,57 @@
+package capture
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestPDF(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{0, 0, 255, 255})
+	var buf bytes.Buffer
+	if err := PDF(&buf, []image.Image{img, img}, 612, 792); err != nil {
+		t.Fatal(err)
+	}
+	doc := buf.Bytes()
+	for _, want := range []string{"%PDF-1.4\n", "/Count 2 /Kids [ 3 0 R 6 0 R ]", "/MediaBox [0 0 612 792]", "/Width 2 /Height 1", "%%EOF\n"} {
+		if !bytes.Contains(doc, []byte(want)) {
+			t.Errorf("no %q", want)
+		}
+	}
+
+	// the cross-reference table points at each object, and startxref at the table
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n0 9\n")) {
+		t.Fatalf("startxref %d points at %q", xref, doc[xref:xref+10])
+	}
+	for i, off := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1) {
+		n, _ := strconv.Atoi(string(off[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(doc[n:], []byte(want)) {
+			t.Errorf("object %d at %d: %q", i+1, n, doc[n:n+8])
+		}
+	}
+
+	// the image is the RGB samples, compressed
+	i := bytes.Index(doc, []byte("/FlateDecode"))
+	s := bytes.Index(doc[i:], []byte("stream\n")) + i + len("stream\n")
+	zr, err := zlib.NewReader(bytes.NewReader(doc[s:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pixels, err := io.ReadAll(zr)
+	if err != nil || !bytes.Equal(pixels, []byte{255, 0, 0, 0, 0, 255}) {
+		t.Errorf("pixels %v, %v", pixels, err)
+	}
+}
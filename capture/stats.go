@@ -0,0 +1,104 @@
+package capture
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/ajstarks/giocanvas"
+)
+
+// Stats summarizes the pixels of a rendering: a histogram of their luma (0-255),
+// their mean luma, and their average color
+type Stats struct {
+	Histogram [256]int
+	Luma      float64
+	Average   color.NRGBA
+	Count     int
+}
+
+// luma returns the luma (0-255) of an 8-bit color, using the Rec. 709 weights
+func luma(r, g, b uint8) float64 {
+	return 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+}
+
+// Analyze computes the statistics of an image
+func Analyze(img image.Image) Stats {
+	var s Stats
+	var sr, sg, sb, sa, sl float64
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			l := luma(c.R, c.G, c.B)
+			s.Histogram[int(l+0.5)]++
+			sr += float64(c.R)
+			sg += float64(c.G)
+			sb += float64(c.B)
+			sa += float64(c.A)
+			sl += l
+			s.Count++
+		}
+	}
+	if s.Count == 0 {
+		return s
+	}
+	n := float64(s.Count)
+	s.Luma = sl / n
+	s.Average = color.NRGBA{uint8(sr/n + 0.5), uint8(sg/n + 0.5), uint8(sb/n + 0.5), uint8(sa/n + 0.5)}
+	return s
+}
+
+// Percentile returns the luma below which the fraction p (0-1) of the pixels fall:
+// at 1, the greatest luma. With no pixels, it is 0.
+func (s Stats) Percentile(p float64) int {
+	if s.Count == 0 {
+		return 0
+	}
+	want := int(p * float64(s.Count))
+	if want >= s.Count {
+		want = s.Count - 1
+	}
+	if want < 0 {
+		want = 0
+	}
+	sum := 0
+	for i, n := range s.Histogram {
+		sum += n
+		if sum > want {
+			return i
+		}
+	}
+	return 255
+}
+
+// Measure renders the canvas, returning the statistics of the pixels within the box
+// (percentage-based; giocanvas.Page for the whole canvas)
+func Measure(c *giocanvas.Canvas, b giocanvas.Box) (Stats, error) {
+	img, err := Region(c, b)
+	if err != nil {
+		return Stats{}, err
+	}
+	return Analyze(img), nil
+}
+
+// ContrastingText places text (as giocanvas.Canvas.Text does) in black or white,
+// whichever is more legible over what is already drawn beneath it. Over busy
+// backgrounds, with a wide range of luma, the text is outlined in the other color.
+func ContrastingText(c *giocanvas.Canvas, x, y, size float32, s string) error {
+	w := c.TextWidth(s, size)
+	h := size * c.Width / c.Height
+	st, err := Measure(c, giocanvas.Box{X: x, Y: y - h*0.3, W: w, H: h * 1.3})
+	if err != nil {
+		return err
+	}
+	bg := st.Average
+	bg.A = 255
+	fg := giocanvas.ContrastingColor(bg)
+	if st.Percentile(0.9)-st.Percentile(0.1) > 128 {
+		outline := color.NRGBA{255 - fg.R, 255 - fg.G, 255 - fg.B, 255}
+		c.OutlinedText(x, y, size, s, fg, outline)
+		return nil
+	}
+	c.Text(x, y, size, s, fg)
+	return nil
+}
@@ -0,0 +1,74 @@
+package capture
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	// gray makes an image of a row of gray pixels
+	gray := func(v ...uint8) image.Image {
+		img := image.NewGray(image.Rect(0, 0, len(v), 1))
+		copy(img.Pix, v)
+		return img
+	}
+	tests := []struct {
+		name    string
+		img     image.Image
+		count   int
+		luma    float64
+		average color.NRGBA
+	}{
+		{"empty", gray(), 0, 0, color.NRGBA{}},
+		{"one", gray(100), 1, 100, color.NRGBA{100, 100, 100, 255}},
+		{"two", gray(0, 200), 2, 100, color.NRGBA{100, 100, 100, 255}},
+		{"clear", image.NewNRGBA(image.Rect(0, 0, 2, 2)), 4, 0, color.NRGBA{}},
+	}
+	for _, test := range tests {
+		s := Analyze(test.img)
+		if s.Count != test.count || s.Luma-test.luma > 1e-9 || test.luma-s.Luma > 1e-9 || s.Average != test.average {
+			t.Errorf("%s: count %d, luma %v, average %v", test.name, s.Count, s.Luma, s.Average)
+		}
+		sum := 0
+		for _, n := range s.Histogram {
+			sum += n
+		}
+		if sum != test.count {
+			t.Errorf("%s: histogram of %d pixels", test.name, sum)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	// stats makes the statistics of pixels of the lumas
+	stats := func(lumas ...int) Stats {
+		var s Stats
+		for _, l := range lumas {
+			s.Histogram[l]++
+			s.Count++
+		}
+		return s
+	}
+	tests := []struct {
+		name string
+		s    Stats
+		p    float64
+		want int
+	}{
+		{"empty", stats(), 0.5, 0},
+		{"empty, all", stats(), 1, 0},
+		{"one, none", stats(40), 0, 40},
+		{"one, all", stats(40), 1, 40},
+		{"lowest", stats(10, 20, 30, 40), 0, 10},
+		{"median", stats(10, 20, 30, 40), 0.5, 30},
+		{"90th", stats(10, 20, 30, 40), 0.9, 40},
+		{"all", stats(10, 20, 30, 40), 1, 40},
+		{"below", stats(10, 20), -1, 10},
+	}
+	for _, test := range tests {
+		if got := test.s.Percentile(test.p); got != test.want {
+			t.Errorf("%s: Percentile(%v) = %d, want %d", test.name, test.p, got, test.want)
+		}
+	}
+}
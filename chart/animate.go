@@ -0,0 +1,62 @@
+package chart
+
+import (
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Interpolate returns the chart part way (t, 0-1) from one dataset to another:
+// bars grow, lines morph and pie slices sweep to their new values.
+// Data are matched by position; the labels and notes are those of to,
+// and points missing from from start at zero.
+func Interpolate(from, to ChartBox, t float64) ChartBox {
+	c := to
+	c.Data = make([]NameValue, len(to.Data))
+	lerp := func(a, b float64) float64 { return a + (b-a)*t }
+	for i, d := range to.Data {
+		v := 0.0
+		if i < len(from.Data) {
+			v = from.Data[i].value
+		}
+		d.value = lerp(v, d.value)
+		c.Data[i] = d
+	}
+	if len(from.Data) > 0 {
+		c.Minvalue = lerp(from.Minvalue, to.Minvalue)
+		c.Maxvalue = lerp(from.Maxvalue, to.Maxvalue)
+	}
+	return c
+}
+
+// Empty returns a copy of the chart with every value zero, to animate from
+func Empty(c ChartBox) ChartBox {
+	e := c
+	e.Data = make([]NameValue, len(c.Data))
+	for i, d := range c.Data {
+		d.value = 0
+		e.Data[i] = d
+	}
+	return e
+}
+
+// Transition animates a chart from one dataset to another
+type Transition struct {
+	From, To ChartBox
+	Tween    *gc.Tween
+}
+
+// NewTransition starts a transition lasting d, with the easing function (Linear if nil)
+func NewTransition(from, to ChartBox, d time.Duration, ease gc.Easing) *Transition {
+	return &Transition{From: from, To: to, Tween: gc.NewTween(d, ease)}
+}
+
+// At returns the chart at time now
+func (tr *Transition) At(now time.Time) ChartBox {
+	return Interpolate(tr.From, tr.To, tr.Tween.At(now))
+}
+
+// Done reports whether the transition has finished; until then, keep drawing frames
+func (tr *Transition) Done(now time.Time) bool {
+	return tr.Tween.Done(now)
+}
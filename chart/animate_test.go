@@ -0,0 +1,15 @@
+package chart
+
+import "testing"
+
+func TestInterpolate(t *testing.T) {
+	from := ChartBox{Data: []NameValue{{label: "a", value: 10}}, Maxvalue: 10}
+	to := ChartBox{Data: []NameValue{{label: "a", value: 20}, {label: "b", value: 40}}, Maxvalue: 40}
+	mid := Interpolate(from, to, 0.5)
+	if len(mid.Data) != 2 || mid.Data[0].value != 15 || mid.Data[1].value != 20 || mid.Maxvalue != 25 {
+		t.Errorf("halfway: %+v", mid)
+	}
+	if e := Empty(to); e.Data[1].value != 0 || e.Data[1].label != "b" {
+		t.Errorf("empty: %+v", e)
+	}
+}
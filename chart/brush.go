@@ -0,0 +1,147 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Brushing: selecting a region of a chart with the pointer, and zooming to it
+
+// doubleclick is the longest time between the presses of a double click
+const doubleclick = 300 * time.Millisecond
+
+// Brush zooms a chart to regions selected by pressing and dragging across it; zooms
+// may be nested. A double click, or a press of the secondary button, resets the zoom.
+type Brush struct {
+	Chart ChartBox    // the whole chart
+	Color color.NRGBA // of the selection
+
+	zoomed      bool
+	first, last int     // the data points in view
+	min, max    float64 // the values in view
+	selecting   bool
+	x0, y0      float32
+	x1, y1      float32
+	lastpress   time.Duration
+}
+
+// NewBrush makes a brush for the chart, showing the selection in color
+func NewBrush(chart ChartBox, selcolor color.NRGBA) *Brush {
+	return &Brush{Chart: chart, Color: selcolor}
+}
+
+// View returns the chart as zoomed
+func (b *Brush) View() ChartBox {
+	if !b.zoomed {
+		return b.Chart
+	}
+	view := b.Chart
+	view.Data = b.Chart.Data[b.first : b.last+1]
+	view.Minvalue, view.Maxvalue = b.min, b.max
+	view.Zerobased = false
+	return view
+}
+
+// Zoomed reports whether the chart is zoomed
+func (b *Brush) Zoomed() bool {
+	return b.zoomed
+}
+
+// Reset shows the whole chart
+func (b *Brush) Reset() {
+	b.zoomed = false
+	b.selecting = false
+}
+
+// Input selects with pointer presses, drags and releases on the chart, zooming to the
+// selection when it is released, and reports whether the view or the selection changed
+func (b *Brush) Input(p gc.PointerEvent) bool {
+	c := b.Chart
+	inside := float64(p.X) >= c.Left && float64(p.X) <= c.Right && float64(p.Y) >= c.Bottom && float64(p.Y) <= c.Top
+	switch p.Type {
+	case pointer.Press:
+		if !inside {
+			return false
+		}
+		double := b.lastpress != 0 && p.Time-b.lastpress < doubleclick
+		b.lastpress = p.Time
+		if double || p.Buttons == pointer.ButtonSecondary {
+			b.Reset()
+			return true
+		}
+		b.selecting = true
+		b.x0, b.y0, b.x1, b.y1 = p.X, p.Y, p.X, p.Y
+	case pointer.Drag:
+		if !b.selecting {
+			return false
+		}
+		b.x1, b.y1 = p.X, p.Y
+	case pointer.Release:
+		if !b.selecting {
+			return false
+		}
+		b.selecting = false
+		b.x1, b.y1 = p.X, p.Y
+		b.zoom()
+	case pointer.Cancel:
+		b.selecting = false
+	default:
+		return false
+	}
+	return true
+}
+
+// zoom zooms the view to the selection, if it spans at least two data points and
+// some height
+func (b *Brush) zoom() {
+	view := b.View()
+	n := len(view.Data)
+	if n < 2 {
+		return
+	}
+	clamp := func(v, low, high float64) float64 { return math.Max(low, math.Min(high, v)) }
+	x0 := clamp(float64(min32(b.x0, b.x1)), view.Left, view.Right)
+	x1 := clamp(float64(max32(b.x0, b.x1)), view.Left, view.Right)
+	y0 := clamp(float64(min32(b.y0, b.y1)), view.Bottom, view.Top)
+	y1 := clamp(float64(max32(b.y0, b.y1)), view.Bottom, view.Top)
+	first := int(math.Ceil(gc.MapRange(x0, view.Left, view.Right, 0, float64(n-1))))
+	last := int(math.Floor(gc.MapRange(x1, view.Left, view.Right, 0, float64(n-1))))
+	if last-first < 1 || y1-y0 < 1 {
+		return
+	}
+	offset := 0
+	if b.zoomed {
+		offset = b.first
+	}
+	b.first, b.last = offset+first, offset+last
+	b.min, b.max = view.valueat(y0), view.valueat(y1)
+	b.zoomed = true
+}
+
+// Draw shows the selection in progress
+func (b *Brush) Draw(canvas *gc.Canvas) {
+	if !b.selecting {
+		return
+	}
+	x, y := min32(b.x0, b.x1), min32(b.y0, b.y1)
+	w, h := max32(b.x0, b.x1)-x, max32(b.y0, b.y1)-y
+	canvas.CenterRect(x+w/2, y+h/2, w, h, b.Color)
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,39 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestBrush(t *testing.T) {
+	data := make([]NameValue, 11)
+	for i := range data {
+		data[i].value = float64(i * 10)
+	}
+	b := NewBrush(ChartBox{Data: data, Left: 0, Right: 100, Bottom: 0, Top: 100, Maxvalue: 100, Zerobased: true}, labelcolor)
+	drag := func(x0, y0, x1, y1 float32, at time.Duration) {
+		b.Input(gc.PointerEvent{Type: pointer.Press, X: x0, Y: y0, Buttons: pointer.ButtonPrimary, Time: at})
+		b.Input(gc.PointerEvent{Type: pointer.Drag, X: x1, Y: y1, Time: at + time.Second})
+		b.Input(gc.PointerEvent{Type: pointer.Release, X: x1, Y: y1, Time: at + time.Second})
+	}
+	drag(15, 20, 65, 60, time.Second)
+	v := b.View()
+	if !b.Zoomed() || len(v.Data) != 5 || v.Data[0].value != 20 || !near(float32(v.Minvalue), 20) || !near(float32(v.Maxvalue), 60) {
+		t.Fatalf("zoom: %d points from %v, values %v-%v", len(v.Data), v.Data[0].value, v.Minvalue, v.Maxvalue)
+	}
+	// zooming again selects within the view
+	drag(50, 0, 100, 100, 3*time.Second)
+	if v = b.View(); len(v.Data) != 3 || v.Data[0].value != 40 {
+		t.Errorf("nested zoom: %v", v.Data)
+	}
+	// a double click resets
+	b.Input(gc.PointerEvent{Type: pointer.Press, X: 50, Y: 50, Time: 10 * time.Second})
+	b.Input(gc.PointerEvent{Type: pointer.Release, X: 50, Y: 50, Time: 10 * time.Second})
+	b.Input(gc.PointerEvent{Type: pointer.Press, X: 50, Y: 50, Time: 10*time.Second + 100*time.Millisecond})
+	if b.Zoomed() || len(b.View().Data) != 11 {
+		t.Errorf("reset: %v", b.View().Data)
+	}
+}
@@ -28,6 +28,10 @@ type ChartBox struct {
 	Top, Bottom, Left, Right float64
 	Minvalue, Maxvalue       float64
 	Zerobased                bool
+	Locale                   gc.Locale
+	MaxPoints                int // decimate Line and Scatter data with more points than this (0: no limit)
+	Scale                    Scale
+	LinearWidth              float64 // extent of the linear region about zero of a SymLogScale (0: 1)
 }
 
 const (
@@ -106,10 +110,9 @@ func zerobase(usez bool, n float64) float64 {
 // Bar makes a (column) bar chart
 func (c *ChartBox) Bar(canvas *gc.Canvas, size float64) {
 	dlen := float64(len(c.Data) - 1)
-	ymin := zerobase(c.Zerobased, c.Minvalue)
 	for i, d := range c.Data {
 		x := float32(gc.MapRange(float64(i), 0, dlen, c.Left, c.Right))
-		y := float32(gc.MapRange(d.value, ymin, c.Maxvalue, c.Bottom, c.Top))
+		y := float32(c.yvalue(d.value))
 		canvas.Line(float32(x), float32(c.Bottom), x, y, float32(size), c.Color)
 	}
 }
@@ -118,35 +121,50 @@ func (c *ChartBox) Bar(canvas *gc.Canvas, size float64) {
 func (c *ChartBox) HBar(canvas *gc.Canvas, size, linespacing, textsize float64) {
 	y := float32(c.Top)
 	cl := float32(c.Left)
-	xmin := zerobase(c.Zerobased, c.Minvalue)
 	for _, d := range c.Data {
 		canvas.EText(cl-2, y-float32(size/2), float32(textsize), d.label, labelcolor)
-		x2 := gc.MapRange(d.value, xmin, c.Maxvalue, c.Left, c.Right)
+		x2 := c.scalepos(d.value, c.Left, c.Right)
 		canvas.Line(cl, y, float32(x2), y, float32(size), c.Color)
 		y -= float32(linespacing)
 	}
 }
 
-// Line makes a line chart
-func (c *ChartBox) Line(canvas *gc.Canvas, size float64) {
+// points returns the data as chart coordinates
+func (c *ChartBox) points() ([]float32, []float32) {
 	n := len(c.Data)
 	fn := float64(n - 1)
-	ymin := zerobase(c.Zerobased, c.Minvalue)
-	for i := 0; i < n-1; i++ {
-		v1 := c.Data[i].value
-		v2 := c.Data[i+1].value
-		x1 := float32(gc.MapRange(float64(i), 0, fn, c.Left, c.Right))
-		y1 := float32(gc.MapRange(v1, ymin, c.Maxvalue, c.Bottom, c.Top))
-		x2 := float32(gc.MapRange(float64(i+1), 0, fn, c.Left, c.Right))
-		y2 := float32(gc.MapRange(v2, ymin, c.Maxvalue, c.Bottom, c.Top))
-		canvas.Line(x1, y1, x2, y2, float32(size), c.Color)
+	x := make([]float32, n)
+	y := make([]float32, n)
+	for i, d := range c.Data {
+		x[i] = float32(gc.MapRange(float64(i), 0, fn, c.Left, c.Right))
+		y[i] = float32(c.yvalue(d.value))
+	}
+	return x, y
+}
+
+// Line makes a line chart
+func (c *ChartBox) Line(canvas *gc.Canvas, size float64) {
+	x, y := c.points()
+	if c.MaxPoints > 0 {
+		x, y = gc.Decimate(x, y, c.MaxPoints/4)
+	}
+	for i := 0; i < len(x)-1; i++ {
+		canvas.Line(x[i], y[i], x[i+1], y[i+1], float32(size), c.Color)
+	}
+}
+
+// SmoothLine makes a line chart with a smooth curve through the points
+func (c *ChartBox) SmoothLine(canvas *gc.Canvas, size float64) {
+	x, y := c.points()
+	if c.MaxPoints > 0 {
+		x, y = gc.Decimate(x, y, c.MaxPoints/4)
 	}
+	canvas.Spline(x, y, float32(size), c.Color)
 }
 
 // Area makes a area chart with specified opacity
 func (c *ChartBox) Area(canvas *gc.Canvas, opacity float64) {
 	n := len(c.Data)
-	ymin := zerobase(c.Zerobased, c.Minvalue)
 	width := c.Right
 	height := c.Top
 	x := c.Left
@@ -160,7 +178,7 @@ func (c *ChartBox) Area(canvas *gc.Canvas, opacity float64) {
 
 	for i, d := range c.Data {
 		xp := float32(gc.MapRange(float64(i), 0, float64(n-1), float64(x), float64(width)))
-		yp := float32(gc.MapRange(d.value, ymin, c.Maxvalue, float64(y), float64(height)))
+		yp := float32(c.scalepos(d.value, float64(y), float64(height)))
 		ax[i+1] = xp
 		ay[i+1] = yp
 	}
@@ -179,8 +197,23 @@ func datasum(data []NameValue) float64 {
 
 // Pie makes a pie chart
 func (c *ChartBox) Pie(canvas *gc.Canvas, r float64) {
+	c.pie(canvas, r, 0)
+}
+
+// Donut makes a donut chart: a pie chart of radius r with its middle left open,
+// the ring thickness wide
+func (c *ChartBox) Donut(canvas *gc.Canvas, r, thickness float64) {
+	c.pie(canvas, r, math.Max(0, r-thickness))
+}
+
+// pie makes a pie chart of radius r, open within the radius inner (if not zero),
+// the label of each slice joined to its middle
+func (c *ChartBox) pie(canvas *gc.Canvas, r, inner float64) {
 	px, py, pr := float32(c.Left+r), float32(c.Top-r), float32(r)
 	sum := datasum(c.Data)
+	if sum == 0 {
+		return
+	}
 	a1 := 0.0
 	labelr := pr + 10
 	ts := pr / 10
@@ -189,11 +222,17 @@ func (c *ChartBox) Pie(canvas *gc.Canvas, r float64) {
 		pct := (d.value / sum)
 		a2 := (fullcircle * pct) + a1
 		mid := fullcircle - (a1 + (a2-a1)/2)
-		canvas.Arc(px, py, pr, a1, a2, fillcolor)
+		mx, my := px, py
+		if inner > 0 {
+			canvas.AnnularArc(px, py, float32(inner), pr, a1, a2, fillcolor)
+			mx, my = canvas.Polar(px, py, pr, float32(mid))
+		} else {
+			canvas.Arc(px, py, pr, a1, a2, fillcolor)
+		}
 		tx, ty := canvas.Polar(px, py, labelr, float32(mid))
 		lx, ly := canvas.Polar(px, py, labelr-ts, float32(mid))
-		canvas.CText(tx, ty, ts, fmt.Sprintf("%s (%.2f%%)", d.label, pct*100), fillcolor)
-		canvas.Line(px, py, lx, ly, 0.1, fillcolor)
+		canvas.CText(tx, ty, ts, d.label+" ("+c.Locale.Percent(pct*100, 2)+")", fillcolor)
+		canvas.Line(mx, my, lx, ly, 0.1, fillcolor)
 		a1 = a2
 	}
 }
@@ -254,12 +293,13 @@ func (c *ChartBox) Label(canvas *gc.Canvas, size float64, n int) {
 
 // Scatter makes a scatter chart
 func (c *ChartBox) Scatter(canvas *gc.Canvas, size float64) {
-	dlen := float64(len(c.Data) - 1)
-	ymin := zerobase(c.Zerobased, c.Minvalue)
-	for i, d := range c.Data {
-		x := float32(gc.MapRange(float64(i), 0, dlen, c.Left, c.Right))
-		y := float32(gc.MapRange(d.value, ymin, c.Maxvalue, c.Bottom, c.Top))
-		canvas.Circle(x, y, float32(size), c.Color)
+	x, y := c.points()
+	if c.MaxPoints > 0 {
+		n := int(math.Sqrt(float64(c.MaxPoints)))
+		x, y, _ = gc.BinPoints(x, y, n, n)
+	}
+	for i := range x {
+		canvas.Circle(x[i], y[i], float32(size), c.Color)
 	}
 }
 
@@ -276,13 +316,12 @@ func Grid(canvas *gc.Canvas, left, bottom, width, height, size float64, color co
 // YAxis makes the Y axis with optional grid lines
 func (c *ChartBox) YAxis(canvas *gc.Canvas, size, min, max, step float64, format string, gridlines bool) {
 	w := c.Right - c.Left
-	ymin := zerobase(c.Zerobased, c.Minvalue)
 	for v := min; v <= max; v += step {
-		y := float32(gc.MapRange(v, ymin, c.Maxvalue, c.Bottom, c.Top))
+		y := float32(c.yvalue(v))
 		if gridlines {
 			canvas.Line(float32(c.Left), y, float32(c.Left+w), y, 0.05, color.NRGBA{128, 128, 128, 255})
 		}
-		canvas.EText(float32(c.Left-2), (y - float32(size/3)), float32(size), fmt.Sprintf(format, v), c.Color)
+		canvas.EText(float32(c.Left-2), (y - float32(size/3)), float32(size), c.Locale.Format(format, v), c.Color)
 	}
 }
 
@@ -0,0 +1,34 @@
+package chart
+
+import (
+	"testing"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestDonut(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	data := ChartBox{
+		Data: []NameValue{{label: "a", value: 1}, {label: "b", value: 3}},
+		Left: 10,
+		Top:  90,
+	}
+	data.Donut(c, 20, 5)
+	bands := log.Find("annulararc")
+	if len(bands) != 2 {
+		t.Fatalf("got %d bands, want 2", len(bands))
+	}
+	// the slices run clockwise from 0, as those of Pie, 15 to 20 from the center
+	if bands[0].A1 != 0 || !near(float32(bands[0].A2), fullcircle/4) || bands[1].A2 <= bands[1].A1 {
+		t.Errorf("angles %v-%v, %v-%v", bands[0].A1, bands[0].A2, bands[1].A1, bands[1].A2)
+	}
+	if !near(bands[0].W, 15) || !near(bands[0].Size, 20) {
+		t.Errorf("radii %v, %v; want 15, 20", bands[0].W, bands[0].Size)
+	}
+
+	c, log = gc.NewRecordingCanvas(1000, 1000)
+	data.Pie(c, 20)
+	if len(log.Find("arc")) != 2 || len(log.Find("annulararc")) != 0 {
+		t.Errorf("pie drew %v", log.Calls)
+	}
+}
@@ -0,0 +1,205 @@
+package chart
+
+import (
+	"image/color"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Contours: isolines and filled bands of a 2D scalar field, by marching squares
+
+// Field is a grid of values, Values[row][col] (row 0 at the bottom), shown in the
+// area between Top, Bottom, Left and Right
+type Field struct {
+	Values                   [][]float64
+	Top, Bottom, Left, Right float64
+	Locale                   gc.Locale
+}
+
+// Range returns the least and greatest values of the field
+func (f Field) Range() (float64, float64) {
+	min, max := largest, smallest
+	for _, row := range f.Values {
+		for _, v := range row {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+	}
+	return min, max
+}
+
+// Levels returns n levels evenly spaced between min and max, exclusive
+func Levels(min, max float64, n int) []float64 {
+	levels := make([]float64, n)
+	for i := range levels {
+		levels[i] = min + (max-min)*float64(i+1)/float64(n+1)
+	}
+	return levels
+}
+
+// size returns the number of rows and columns of the field (the shortest row limits the columns)
+func (f Field) size() (int, int) {
+	rows := len(f.Values)
+	if rows == 0 {
+		return 0, 0
+	}
+	cols := len(f.Values[0])
+	for _, row := range f.Values {
+		if len(row) < cols {
+			cols = len(row)
+		}
+	}
+	return rows, cols
+}
+
+// point returns the canvas location of a (fractional) place on the grid
+func (f Field) point(col, row float64, rows, cols int) (float32, float32) {
+	x := gc.MapRange(col, 0, float64(cols-1), f.Left, f.Right)
+	y := gc.MapRange(row, 0, float64(rows-1), f.Bottom, f.Top)
+	return float32(x), float32(y)
+}
+
+// corner is a corner of a grid cell, and its value
+type corner struct {
+	col, row, v float64
+}
+
+// cell returns the corners of the cell with lower left at (col, row),
+// counter-clockwise from there
+func (f Field) cell(col, row int) [4]corner {
+	c, r := float64(col), float64(row)
+	return [4]corner{
+		{c, r, f.Values[row][col]},
+		{c + 1, r, f.Values[row][col+1]},
+		{c + 1, r + 1, f.Values[row+1][col+1]},
+		{c, r + 1, f.Values[row+1][col]},
+	}
+}
+
+// crossing returns where the level crosses the edge between corners a and b
+func crossing(a, b corner, level float64) corner {
+	t := (level - a.v) / (b.v - a.v)
+	return corner{a.col + t*(b.col-a.col), a.row + t*(b.row-a.row), level}
+}
+
+// isolines returns the segments (pairs of grid places) along which the field has the level.
+// Saddle cells are resolved by the mean of their corners.
+func (f Field) isolines(level float64) [][2]corner {
+	rows, cols := f.size()
+	var segments [][2]corner
+	for row := 0; row < rows-1; row++ {
+		for col := 0; col < cols-1; col++ {
+			k := f.cell(col, row)
+			// the crossings on the bottom, right, top and left edges
+			var cross [4]*corner
+			n := 0
+			for e := 0; e < 4; e++ {
+				a, b := k[e], k[(e+1)%4]
+				if (a.v >= level) != (b.v >= level) {
+					p := crossing(a, b, level)
+					cross[e] = &p
+					n++
+				}
+			}
+			switch n {
+			case 2:
+				var pair []corner
+				for _, p := range cross {
+					if p != nil {
+						pair = append(pair, *p)
+					}
+				}
+				segments = append(segments, [2]corner{pair[0], pair[1]})
+			case 4:
+				mean := (k[0].v + k[1].v + k[2].v + k[3].v) / 4
+				if (mean >= level) == (k[0].v >= level) {
+					segments = append(segments, [2]corner{*cross[0], *cross[1]}, [2]corner{*cross[2], *cross[3]})
+				} else {
+					segments = append(segments, [2]corner{*cross[3], *cross[0]}, [2]corner{*cross[1], *cross[2]})
+				}
+			}
+		}
+	}
+	return segments
+}
+
+// clipcell returns the part of a cell polygon where the values are at least the
+// level (above), or at most it (below)
+func clipcell(poly []corner, level float64, above bool) []corner {
+	in := func(p corner) bool {
+		if above {
+			return p.v >= level
+		}
+		return p.v <= level
+	}
+	var out []corner
+	for i, p := range poly {
+		q := poly[(i+1)%len(poly)]
+		if in(p) {
+			out = append(out, p)
+		}
+		if in(p) != in(q) {
+			out = append(out, crossing(p, q, level))
+		}
+	}
+	return out
+}
+
+// Contour draws the isolines of the field at the levels, size wide
+func (f Field) Contour(canvas *gc.Canvas, levels []float64, size float64, linecolor color.NRGBA) {
+	rows, cols := f.size()
+	for _, level := range levels {
+		for _, s := range f.isolines(level) {
+			x0, y0 := f.point(s[0].col, s[0].row, rows, cols)
+			x1, y1 := f.point(s[1].col, s[1].row, rows, cols)
+			canvas.Line(x0, y0, x1, y1, float32(size), linecolor)
+		}
+	}
+}
+
+// ContourLabels labels each isoline at the levels with its value (formatted by the
+// locale), at the middle of its longest segment
+func (f Field) ContourLabels(canvas *gc.Canvas, levels []float64, size float64, format string, labelcolor color.NRGBA) {
+	rows, cols := f.size()
+	for _, level := range levels {
+		var best float32
+		var bx, by float32
+		for _, s := range f.isolines(level) {
+			x0, y0 := f.point(s[0].col, s[0].row, rows, cols)
+			x1, y1 := f.point(s[1].col, s[1].row, rows, cols)
+			if d := (x1-x0)*(x1-x0) + (y1-y0)*(y1-y0); d > best {
+				best, bx, by = d, (x0+x1)/2, (y0+y1)/2
+			}
+		}
+		if best > 0 {
+			canvas.CText(bx, by-float32(size/3), float32(size), f.Locale.Format(format, level), labelcolor)
+		}
+	}
+}
+
+// Bands fills the regions of the field between successive levels: the region from
+// levels[i] to levels[i+1] with colors[i]
+func (f Field) Bands(canvas *gc.Canvas, levels []float64, colors []color.NRGBA) {
+	rows, cols := f.size()
+	for row := 0; row < rows-1; row++ {
+		for col := 0; col < cols-1; col++ {
+			k := f.cell(col, row)
+			for i := 0; i < len(levels)-1 && i < len(colors); i++ {
+				poly := clipcell(clipcell(k[:], levels[i], true), levels[i+1], false)
+				if len(poly) < 3 {
+					continue
+				}
+				x := make([]float32, len(poly))
+				y := make([]float32, len(poly))
+				for j, p := range poly {
+					x[j], y[j] = f.point(p.col, p.row, rows, cols)
+				}
+				canvas.Polygon(x, y, colors[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package chart
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsolines(t *testing.T) {
+	// a peak in the middle
+	f := Field{Values: [][]float64{
+		{0, 0, 0},
+		{0, 4, 0},
+		{0, 0, 0},
+	}}
+	segments := f.isolines(2)
+	if len(segments) != 4 {
+		t.Fatalf("got %d segments, want 4: %v", len(segments), segments)
+	}
+	for _, s := range segments {
+		for _, p := range s {
+			// each crossing is halfway from the edge to the peak
+			d := math.Abs(p.col-1) + math.Abs(p.row-1)
+			if math.Abs(d-0.5) > 1e-9 {
+				t.Errorf("crossing at (%v, %v)", p.col, p.row)
+			}
+		}
+	}
+	if n := len(f.isolines(5)); n != 0 {
+		t.Errorf("above the peak: got %d segments", n)
+	}
+}
+
+func TestClipCell(t *testing.T) {
+	k := []corner{{0, 0, 0}, {1, 0, 2}, {1, 1, 2}, {0, 1, 0}}
+	band := clipcell(clipcell(k, 0.5, true), 1.5, false)
+	// the band from 0.5 to 1.5 is the middle half of the cell
+	var area float64
+	for i, p := range band {
+		q := band[(i+1)%len(band)]
+		area += p.col*q.row - q.col*p.row
+	}
+	if math.Abs(area/2-0.5) > 1e-9 {
+		t.Errorf("band area %v, want 0.5: %v", area/2, band)
+	}
+}
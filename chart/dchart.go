@@ -0,0 +1,216 @@
+package chart
+
+import (
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Options describe a chart as dchart's command line does: the kinds of chart to
+// draw, the chart area, sizes and colors. Sizes and positions use percentage-based measures.
+type Options struct {
+	Top, Bottom, Left, Right                                                              float64
+	BarWidth, LineWidth, LineSpacing, DotSize, TextSize, PieSize, TitleY, FrameOp, AreaOp float64
+	BgColor, DataColor, LabelColor, ChartTitle, YFormat, YRange                           string
+	XLabel, MaxPoints                                                                     int
+	Zero, Line, Smooth, Bar, HBar, Scatter, Area, Pie, Lego, ShowTitle, ShowGrid          bool
+}
+
+// DefaultOptions returns the options used when none are specified
+func DefaultOptions() Options {
+	return Options{
+		Top: 80, Bottom: 40, Left: 10, Right: 90,
+		BarWidth: 0.5, LineWidth: 0.25, LineSpacing: 2, DotSize: 0.5, TextSize: 1.5,
+		PieSize: 20, TitleY: 5, AreaOp: 50,
+		BgColor: "white", DataColor: "steelblue", LabelColor: "rgb(100,100,100)", YFormat: "%v",
+		XLabel: 1, Zero: true, ShowTitle: true,
+	}
+}
+
+// Flags defines the options as flags in fs, using dchart's names; the current
+// values are the defaults. Flags of dchart that are not supported are accepted, and ignored.
+func (o *Options) Flags(fs *flag.FlagSet) {
+	fs.Float64Var(&o.Top, "top", o.Top, "top of the chart")
+	fs.Float64Var(&o.Bottom, "bottom", o.Bottom, "bottom of the chart")
+	fs.Float64Var(&o.Left, "left", o.Left, "left of the chart")
+	fs.Float64Var(&o.Right, "right", o.Right, "right of the chart")
+	fs.Float64Var(&o.BarWidth, "barwidth", o.BarWidth, "bar width")
+	fs.Float64Var(&o.LineWidth, "linewidth", o.LineWidth, "line width")
+	fs.Float64Var(&o.LineSpacing, "ls", o.LineSpacing, "horizontal bar spacing")
+	fs.Float64Var(&o.DotSize, "dotsize", o.DotSize, "dot size")
+	fs.Float64Var(&o.TextSize, "textsize", o.TextSize, "text size")
+	fs.Float64Var(&o.PieSize, "psize", o.PieSize, "pie chart radius")
+	fs.Float64Var(&o.PieSize, "piesize", o.PieSize, "pie chart radius")
+	fs.Float64Var(&o.TitleY, "ty", o.TitleY, "title position relative to the top")
+	fs.Float64Var(&o.FrameOp, "frame", o.FrameOp, "frame opacity")
+	fs.Float64Var(&o.AreaOp, "areaop", o.AreaOp, "area opacity")
+	fs.StringVar(&o.BgColor, "bgcolor", o.BgColor, "background color")
+	fs.StringVar(&o.DataColor, "color", o.DataColor, "data color")
+	fs.StringVar(&o.LabelColor, "labelcolor", o.LabelColor, "label color")
+	fs.StringVar(&o.ChartTitle, "chartitle", o.ChartTitle, "chart title")
+	fs.StringVar(&o.YFormat, "yfmt", o.YFormat, "y axis format")
+	fs.StringVar(&o.YRange, "yrange", o.YRange, "y axis range (min,max,step)")
+	fs.IntVar(&o.XLabel, "xlabel", o.XLabel, "x axis label interval")
+	fs.IntVar(&o.MaxPoints, "maxpoints", o.MaxPoints, "decimate line and scatter charts with more points than this")
+	fs.BoolVar(&o.Zero, "zero", o.Zero, "zero minimum")
+	fs.BoolVar(&o.Line, "line", o.Line, "line chart")
+	fs.BoolVar(&o.Smooth, "smooth", o.Smooth, "draw line charts as smooth curves")
+	fs.BoolVar(&o.Bar, "bar", o.Bar, "bar chart")
+	fs.BoolVar(&o.HBar, "hbar", o.HBar, "horizontal bar chart")
+	fs.BoolVar(&o.Scatter, "scatter", o.Scatter, "scatter chart")
+	fs.BoolVar(&o.Scatter, "dot", o.Scatter, "scatter chart")
+	fs.BoolVar(&o.Area, "area", o.Area, "area chart")
+	fs.BoolVar(&o.Area, "vol", o.Area, "area chart")
+	fs.BoolVar(&o.Pie, "pie", o.Pie, "pie chart")
+	fs.BoolVar(&o.Lego, "lego", o.Lego, "lego chart")
+	fs.BoolVar(&o.ShowTitle, "title", o.ShowTitle, "show the title")
+	fs.BoolVar(&o.ShowGrid, "grid", o.ShowGrid, "show y axis grid")
+
+	// accepted for compatibility
+	for _, name := range []string{"val", "csv", "xlast", "xstagger", "wbar", "pgrid", "donut", "radial", "solidpie", "note", "dmin", "connect", "fulldeck", "standalone"} {
+		fs.Bool(name, false, "ignored (dchart compatibility)")
+	}
+	for _, name := range []string{"datafmt", "valpos", "csvcol", "noteloc", "hline", "vcolor", "framecolor", "layout"} {
+		fs.String(name, "", "ignored (dchart compatibility)")
+	}
+	for _, name := range []string{"pwidth", "xlabrotate", "spokes"} {
+		fs.Float64(name, 0, "ignored (dchart compatibility)")
+	}
+}
+
+// ParseArgs reads dchart command line arguments (without the command name),
+// returning the options and the remaining arguments, the data files
+func ParseArgs(args []string) (Options, []string, error) {
+	o := DefaultOptions()
+	fs := flag.NewFlagSet("dchart", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	o.Flags(fs)
+	err := fs.Parse(args)
+	return o, fs.Args(), err
+}
+
+// ParseCommand reads a dchart command line, such as `dchart -bar -left 20 data.d`,
+// in which arguments may be quoted
+func ParseCommand(line string) (Options, []string, error) {
+	args := splitargs(line)
+	if len(args) > 0 && strings.HasSuffix(args[0], "dchart") {
+		args = args[1:]
+	}
+	return ParseArgs(args)
+}
+
+// splitargs splits a command line into words, keeping quoted strings together
+func splitargs(line string) []string {
+	var args []string
+	var word strings.Builder
+	var quote rune
+	inword := false
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inword = r, true
+		case r == ' ' || r == '\t':
+			if inword {
+				args = append(args, word.String())
+				word.Reset()
+				inword = false
+			}
+		default:
+			word.WriteRune(r)
+			inword = true
+		}
+	}
+	if inword {
+		args = append(args, word.String())
+	}
+	return args
+}
+
+// YRange parses a y axis range: "min", "min,max" or "min,max,step",
+// using the data's minimum and maximum for those left out
+func YRange(yrange string, dmin, dmax float64) (float64, float64, float64) {
+	stof := func(s string) float64 {
+		v, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return v
+	}
+	min, max := dmin, dmax
+	step := (max - min) / 5
+	v := strings.Split(yrange, ",")
+	switch len(v) {
+	case 1:
+		min = stof(v[0])
+	case 2:
+		min, max = stof(v[0]), stof(v[1])
+	case 3:
+		min, max, step = stof(v[0]), stof(v[1]), stof(v[2])
+	}
+	return min, max, step
+}
+
+// Draw draws the data as the options describe, on a canvas filled with the background color
+func (o Options) Draw(canvas *gc.Canvas, data ChartBox) {
+	canvas.Background(gc.ColorLookup(o.BgColor))
+	data.Zerobased = o.Zero
+	data.Top, data.Bottom = o.Top, o.Bottom
+	data.Left, data.Right = o.Left, o.Right
+	data.MaxPoints = o.MaxPoints
+
+	// the data
+	data.Color = gc.ColorLookup(o.DataColor)
+	if o.FrameOp > 0 {
+		data.Frame(canvas, o.FrameOp)
+	}
+	if o.Line && o.Smooth {
+		data.SmoothLine(canvas, o.LineWidth)
+	} else if o.Line {
+		data.Line(canvas, o.LineWidth)
+	}
+	if o.Bar {
+		data.Bar(canvas, o.BarWidth)
+	}
+	if o.Scatter {
+		data.Scatter(canvas, o.DotSize)
+	}
+	if o.HBar {
+		data.HBar(canvas, o.BarWidth, o.LineSpacing, o.TextSize)
+	}
+	if o.Area {
+		data.Area(canvas, o.AreaOp)
+	}
+	if o.Pie {
+		data.Pie(canvas, o.PieSize)
+		data.Left -= o.PieSize // adjust for title
+	}
+	if o.Lego {
+		data.Lego(canvas, o.DotSize)
+	}
+
+	// labels and axes
+	data.Color = gc.ColorLookup(o.LabelColor)
+	if o.Line || o.Bar || o.Scatter || o.Area {
+		n := o.XLabel
+		if n < 1 {
+			n = 1
+		}
+		data.Label(canvas, o.TextSize, n)
+		if len(o.YRange) > 0 {
+			min, max, step := YRange(o.YRange, data.Minvalue, data.Maxvalue)
+			data.YAxis(canvas, o.TextSize, min, max, step, o.YFormat, o.ShowGrid)
+		}
+	}
+
+	// the title
+	if len(o.ChartTitle) > 0 {
+		data.Title = o.ChartTitle
+	}
+	if o.ShowTitle && len(data.Title) > 0 {
+		data.CTitle(canvas, o.TextSize*2, o.TitleY)
+	}
+}
@@ -0,0 +1,19 @@
+package chart
+
+import "testing"
+
+func TestParseCommand(t *testing.T) {
+	o, files, err := ParseCommand(`dchart -dot -left 20 -chartitle "Sales by Month" -val data.d`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.Scatter || o.Left != 20 || o.ChartTitle != "Sales by Month" || o.Top != 80 {
+		t.Errorf("options = %+v", o)
+	}
+	if len(files) != 1 || files[0] != "data.d" {
+		t.Errorf("files = %q", files)
+	}
+	if _, _, err := ParseArgs([]string{"-nosuchflag"}); err == nil {
+		t.Error("unknown flag accepted")
+	}
+}
@@ -0,0 +1,77 @@
+package chart
+
+import (
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Uncertainty: error bars on data points, and shaded bands (such as confidence
+// intervals) around line series
+
+// xy returns the chart coordinates of value v at data point i (which may be fractional)
+func (c *ChartBox) xy(i, v float64) (float32, float32) {
+	x := gc.MapRange(i, 0, float64(len(c.Data)-1), c.Left, c.Right)
+	y := c.yvalue(v)
+	return float32(x), float32(y)
+}
+
+// ErrorBars draws error bars on the data: from each value less yerr[i] to it plus yerr[i]
+// (in data units), and across from xerr[i] data points before it to xerr[i] after; either
+// may be nil. Bars are size wide, and capped with lines capwidth long.
+func (c *ChartBox) ErrorBars(canvas *gc.Canvas, yerr, xerr []float64, size, capwidth float64) {
+	sw, cw := float32(size), float32(capwidth/2)
+	ch := cw * canvas.Width / canvas.Height
+	for i, d := range c.Data {
+		fi := float64(i)
+		if i < len(yerr) && yerr[i] != 0 {
+			x, y0 := c.xy(fi, d.value-yerr[i])
+			_, y1 := c.xy(fi, d.value+yerr[i])
+			canvas.Line(x, y0, x, y1, sw, c.Color)
+			canvas.Line(x-cw, y0, x+cw, y0, sw, c.Color)
+			canvas.Line(x-cw, y1, x+cw, y1, sw, c.Color)
+		}
+		if i < len(xerr) && xerr[i] != 0 {
+			x0, y := c.xy(fi-xerr[i], d.value)
+			x1, _ := c.xy(fi+xerr[i], d.value)
+			canvas.Line(x0, y, x1, y, sw, c.Color)
+			canvas.Line(x0, y-ch, x0, y+ch, sw, c.Color)
+			canvas.Line(x1, y-ch, x1, y+ch, sw, c.Color)
+		}
+	}
+}
+
+// Band shades the region between the low and high values at each data point (in data
+// units), such as a confidence interval around a line, at the opacity (0-100)
+func (c *ChartBox) Band(canvas *gc.Canvas, low, high []float64, opacity float64) {
+	n := len(c.Data)
+	if len(low) < n || len(high) < n || n < 2 {
+		return
+	}
+	x := make([]float32, 0, 2*n)
+	y := make([]float32, 0, 2*n)
+	for i := 0; i < n; i++ {
+		px, py := c.xy(float64(i), high[i])
+		x, y = append(x, px), append(y, py)
+	}
+	for i := n - 1; i >= 0; i-- {
+		px, py := c.xy(float64(i), low[i])
+		x, y = append(x, px), append(y, py)
+	}
+	bandcolor := c.Color
+	bandcolor.A = uint8((opacity / 100) * 255)
+	canvas.Polygon(x, y, bandcolor)
+}
+
+// Spread returns the values of the data less, and plus, the errors (in data units),
+// as the low and high edges of a Band
+func (c *ChartBox) Spread(errs []float64) ([]float64, []float64) {
+	low := make([]float64, len(c.Data))
+	high := make([]float64, len(c.Data))
+	for i, d := range c.Data {
+		var e float64
+		if i < len(errs) {
+			e = errs[i]
+		}
+		low[i], high[i] = d.value-e, d.value+e
+	}
+	return low, high
+}
@@ -0,0 +1,40 @@
+package chart
+
+import (
+	"testing"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestErrorBars(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	data := ChartBox{
+		Data:      []NameValue{{value: 10}, {value: 20}, {value: 30}},
+		Left:      10,
+		Right:     90,
+		Bottom:    10,
+		Top:       90,
+		Maxvalue:  40,
+		Zerobased: true,
+	}
+	data.ErrorBars(c, []float64{5, 0, 5}, []float64{0, 0.5, 0}, 0.1, 1)
+	// two capped vertical bars, one capped horizontal bar
+	if n := len(log.Find("line")); n != 9 {
+		t.Errorf("got %d lines, want 9", n)
+	}
+	bar := log.Find("line")[0]
+	if bar.Points[0] != 10 || !near(bar.Points[1], 20) || !near(bar.Points[3], 40) {
+		t.Errorf("first bar: %v", bar.Points)
+	}
+
+	low, high := data.Spread([]float64{5, 5, 5})
+	data.Band(c, low, high, 30)
+	p := log.Find("polygon")
+	if len(p) != 1 || len(p[0].Points) != 12 || !near(p[0].Points[1], 40) || !near(p[0].Points[11], 20) {
+		t.Errorf("band: %+v", p)
+	}
+}
+
+func near(a, b float32) bool {
+	return a-b < 1e-3 && b-a < 1e-3
+}
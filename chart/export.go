@@ -0,0 +1,34 @@
+package chart
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes the chart's data to w as comma separated values, with a heading:
+// the label and value of each point, and its note if any point has one
+func (c *ChartBox) WriteCSV(w io.Writer) error {
+	notes := false
+	for _, d := range c.Data {
+		if d.note != "" {
+			notes = true
+			break
+		}
+	}
+	cw := csv.NewWriter(w)
+	heading := []string{"label", "value"}
+	if notes {
+		heading = append(heading, "note")
+	}
+	cw.Write(heading)
+	for _, d := range c.Data {
+		row := []string{d.label, strconv.FormatFloat(d.value, 'g', -1, 64)}
+		if notes {
+			row = append(row, d.note)
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
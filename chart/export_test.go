@@ -0,0 +1,18 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	c := ChartBox{Data: []NameValue{{label: "a", value: 1.5}, {label: "b, c", value: -2}}}
+	var buf bytes.Buffer
+	if err := c.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "label,value\na,1.5\n\"b, c\",-2\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -3,9 +3,10 @@ package main
 
 import (
 	"flag"
+	"image/color"
 	"io"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 
 	"gioui.org/app"
@@ -13,55 +14,19 @@ import (
 	"gioui.org/io/system"
 	"gioui.org/unit"
 	"github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/capture"
 	"github.com/ajstarks/giocanvas/chart"
 )
 
-type chartOptions struct {
-	top, bottom, left, right                                                          float64
-	barwidth, linewidth, linespacing, dotsize, textsize, piesize, ty, frameOp, areaOp float64
-	bgcolor, dcolor, labelcolor, chartitle, yaxfmt, yrange                            string
-	xlabel                                                                            int
-	zb, line, bar, hbar, scatter, area, pie, lego, showtitle, showgrid                bool
-}
-
 func main() {
 
-	// Command line options
-	var opts chartOptions
+	// Command line options: those of dchart, and the window size
+	opts := chart.DefaultOptions()
 	var width, height int
 
 	flag.IntVar(&width, "w", 1000, "canvas width")
 	flag.IntVar(&height, "h", 1000, "canvas height")
-	flag.IntVar(&opts.xlabel, "xlabel", 1, "x-xaxis label")
-	flag.Float64Var(&opts.barwidth, "barwidth", 0.5, "bar width")
-	flag.Float64Var(&opts.linewidth, "linewidth", 0.25, "bar width")
-	flag.Float64Var(&opts.linespacing, "ls", opts.barwidth*4, "bar width")
-	flag.Float64Var(&opts.dotsize, "dotsize", 0.5, "bar width")
-	flag.Float64Var(&opts.textsize, "textsize", 1.5, "bar width")
-	flag.Float64Var(&opts.top, "top", 80, "bar width")
-	flag.Float64Var(&opts.bottom, "bottom", 40, "bar width")
-	flag.Float64Var(&opts.left, "left", 10, "bar width")
-	flag.Float64Var(&opts.right, "right", 90, "bar width")
-	flag.Float64Var(&opts.ty, "ty", 5, "title position relative to the top")
-	flag.Float64Var(&opts.frameOp, "frame", 0, "frame opacity")
-	flag.Float64Var(&opts.areaOp, "areaop", 50, "area opacity")
-	flag.Float64Var(&opts.piesize, "piesize", 20, "pie chart radius")
-	flag.StringVar(&opts.yrange, "yrange", "", "y axis range (min,max,step")
-	flag.StringVar(&opts.chartitle, "chartitle", "", "chart title")
-	flag.StringVar(&opts.yaxfmt, "yfmt", "%v", "yaxis format")
-	flag.StringVar(&opts.dcolor, "color", "steelblue", "color")
-	flag.StringVar(&opts.bgcolor, "bgcolor", "white", "background color")
-	flag.StringVar(&opts.labelcolor, "labelcolor", "rgb(100,100,100)", "label color")
-	flag.BoolVar(&opts.showtitle, "title", true, "show the title")
-	flag.BoolVar(&opts.showgrid, "grid", false, "show y axis grid")
-	flag.BoolVar(&opts.zb, "zero", true, "zero minumum")
-	flag.BoolVar(&opts.lego, "lego", false, "lego chart")
-	flag.BoolVar(&opts.area, "area", false, "area chart")
-	flag.BoolVar(&opts.bar, "bar", false, "bar chart")
-	flag.BoolVar(&opts.line, "line", false, "line chart")
-	flag.BoolVar(&opts.hbar, "hbar", false, "horizontal bar")
-	flag.BoolVar(&opts.scatter, "scatter", false, "scatter chart")
-	flag.BoolVar(&opts.pie, "pie", false, "show a pie chart")
+	opts.Flags(flag.CommandLine)
 	flag.Parse()
 
 	var input io.Reader
@@ -86,8 +51,12 @@ func main() {
 		perr("unable to read ", infile)
 		os.Exit(2)
 	}
-	// make the chart
-	go gchart("charts", width, height, data, opts)
+	// make the chart; exports are named for the input file
+	base := "chart"
+	if infile != "stdin" {
+		base = strings.TrimSuffix(filepath.Base(infile), filepath.Ext(infile))
+	}
+	go gchart(base, width, height, data, opts)
 	app.Main()
 }
 
@@ -95,112 +64,76 @@ func perr(msg, file string) {
 	io.WriteString(os.Stderr, msg+file+"\n")
 }
 
-// string to floating point
-func stof(s string) float64 {
-	v, err := strconv.ParseFloat(s, 64)
-	if err != nil {
-		return 0
+// view returns the chart and options as zoomed by the brush
+func view(brush *chart.Brush, opts chart.Options) (chart.ChartBox, chart.Options) {
+	if brush.Zoomed() {
+		opts.Zero = false
 	}
-	return v
+	return brush.View(), opts
 }
 
-// yr parses the the yrange (max,min,step) string
-func yr(yrange string, dmin, dmax float64) (float64, float64, float64) {
-	var min, max, step float64
-	min = dmin
-	max = dmax
-	step = max - min/5
-	v := strings.Split(yrange, ",")
-	switch len(v) {
-	case 1:
-		min = stof(v[0])
-	case 2:
-		min = stof(v[0])
-		max = stof(v[1])
-	case 3:
-		min = stof(v[0])
-		max = stof(v[1])
-		step = stof(v[2])
+// export writes the data in view to base.csv, and the chart as shown to base.png
+func export(base string, width, height float32, brush *chart.Brush, opts chart.Options, png bool) {
+	data, opts := view(brush, opts)
+	if png {
+		canvas := giocanvas.NewCanvas(width, height, system.FrameEvent{})
+		opts.Draw(canvas, data)
+		if err := capture.WritePNG(canvas, base+".png"); err != nil {
+			perr("unable to write ", base+".png")
+		}
+		return
+	}
+	f, err := os.Create(base + ".csv")
+	if err == nil {
+		err = data.WriteCSV(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		perr("unable to write ", base+".csv")
 	}
-	return min, max, step
 }
 
-func gchart(s string, w, h int, data chart.ChartBox, opts chartOptions) {
+// gchart shows the chart; drag across it to zoom, double click to reset,
+// and press C or P to export the view as CSV or PNG
+func gchart(base string, w, h int, data chart.ChartBox, opts chart.Options) {
 	defer os.Exit(0)
 	width := float32(w)
 	height := float32(h)
-	appsize := app.Size(unit.Px(width), unit.Px(height))
+	appsize := app.Size(unit.Dp(width), unit.Dp(height))
 	apptitle := app.Title("Chart: " + data.Title)
 	win := app.NewWindow(apptitle, appsize)
 
-	// Define the colors
-	datacolor := giocanvas.ColorLookup(opts.dcolor)
-	labelcolor := giocanvas.ColorLookup(opts.labelcolor)
-	bgcolor := giocanvas.ColorLookup(opts.bgcolor)
-
-	// Set the chart attributes
-	data.Zerobased = opts.zb
-	data.Top, data.Bottom = opts.top, opts.bottom
-	data.Left, data.Right = opts.left, opts.right
+	data.Top, data.Bottom, data.Left, data.Right = opts.Top, opts.Bottom, opts.Left, opts.Right
+	data.Zerobased = opts.Zero
+	brush := chart.NewBrush(data, color.NRGBA{70, 130, 180, 60})
+	pressed := new(bool)
 
 	for e := range win.Events() {
 		switch e := e.(type) {
 		case system.FrameEvent:
 			canvas := giocanvas.NewCanvas(width, height, e)
-			canvas.Background(bgcolor)
-
-			// Draw the data
-			data.Color = datacolor
-			if opts.frameOp > 0 {
-				data.Frame(canvas, opts.frameOp)
-			}
-			if opts.line {
-				data.Line(canvas, opts.linewidth)
-			}
-			if opts.bar {
-				data.Bar(canvas, opts.barwidth)
+			for _, p := range canvas.PointerEvents(e.Queue, pressed) {
+				brush.Input(p)
 			}
-			if opts.scatter {
-				data.Scatter(canvas, opts.dotsize)
-			}
-			if opts.hbar {
-				data.HBar(canvas, opts.barwidth, opts.linespacing, opts.textsize)
-			}
-			if opts.area {
-				data.Area(canvas, opts.areaOp)
-			}
-			if opts.pie {
-				data.Pie(canvas, opts.piesize)
-				data.Left -= opts.piesize // adjust for title
-			}
-			if opts.lego {
-				data.Lego(canvas, opts.dotsize)
-			}
-
-			// Draw labels, axes if specified
-			data.Color = labelcolor
-			if opts.line || opts.bar || opts.scatter || opts.area {
-				data.Label(canvas, opts.textsize, opts.xlabel)
-				if len(opts.yrange) > 0 {
-					yaxmin, yaxmax, yaxstep := yr(opts.yrange, data.Minvalue, data.Maxvalue)
-					data.YAxis(canvas, opts.textsize, yaxmin, yaxmax, yaxstep, opts.yaxfmt, opts.showgrid)
-				}
-			}
-
-			// Draw the chart titles
-			if len(opts.chartitle) > 0 {
-				data.Title = opts.chartitle
-			}
-			if opts.showtitle && len(data.Title) > 0 {
-				data.CTitle(canvas, opts.textsize*2, opts.ty)
-			}
-
+			data, opts := view(brush, opts)
+			opts.Draw(canvas, data)
+			brush.Draw(canvas)
+			canvas.PointerInput(pressed)
 			e.Frame(canvas.Context.Ops)
 
 		case key.Event:
+			if e.State != key.Press {
+				continue
+			}
 			switch e.Name {
 			case "Q", key.NameEscape:
 				os.Exit(0)
+			case "C":
+				export(base, width, height, brush, opts, false)
+			case "P":
+				export(base, width, height, brush, opts, true)
 			}
 		}
 	}
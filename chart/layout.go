@@ -0,0 +1,78 @@
+package chart
+
+import (
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Layouts: a secondary y axis with its own scale, and small multiples --
+// grids of charts sharing their axes
+
+// Overlay returns a copy of data placed in the same area as c, keeping its own
+// value range, for plotting against a secondary y axis
+func (c *ChartBox) Overlay(data ChartBox) ChartBox {
+	data.Top, data.Bottom, data.Left, data.Right = c.Top, c.Bottom, c.Left, c.Right
+	return data
+}
+
+// YAxisRight makes a y axis on the right side of the chart, labeled from min to max every step
+func (c *ChartBox) YAxisRight(canvas *gc.Canvas, size, min, max, step float64, format string) {
+	for v := min; v <= max; v += step {
+		y := float32(c.yvalue(v))
+		canvas.Text(float32(c.Right+2), (y - float32(size/3)), float32(size), c.Locale.Format(format, v), c.Color)
+	}
+}
+
+// Panel is the area of one chart in a grid of small multiples. Shared axes are drawn
+// only on the panels at the left (AxisY) and bottom (AxisX) of the grid.
+type Panel struct {
+	Top, Bottom, Left, Right float64
+	Row, Col                 int
+	AxisX, AxisY             bool
+}
+
+// Facets divides the area between left, bottom, right and top into a grid of rows and
+// cols panels, gap apart, in reading order (row 0 at the top)
+func Facets(left, bottom, right, top float64, rows, cols int, gap float64) []Panel {
+	if rows < 1 || cols < 1 {
+		return nil
+	}
+	w := (right - left - gap*float64(cols-1)) / float64(cols)
+	h := (top - bottom - gap*float64(rows-1)) / float64(rows)
+	panels := make([]Panel, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			x := left + float64(c)*(w+gap)
+			y := top - float64(r)*(h+gap)
+			panels = append(panels, Panel{
+				Top: y, Bottom: y - h, Left: x, Right: x + w,
+				Row: r, Col: c,
+				AxisX: r == rows-1, AxisY: c == 0,
+			})
+		}
+	}
+	return panels
+}
+
+// Place returns a copy of the chart positioned in the panel
+func (c *ChartBox) Place(p Panel) ChartBox {
+	placed := *c
+	placed.Top, placed.Bottom, placed.Left, placed.Right = p.Top, p.Bottom, p.Left, p.Right
+	return placed
+}
+
+// ShareRange sets the value range of every chart to the range of them all,
+// so that they share a y scale
+func ShareRange(charts []ChartBox) {
+	min, max := largest, smallest
+	for _, c := range charts {
+		if c.Minvalue < min {
+			min = c.Minvalue
+		}
+		if c.Maxvalue > max {
+			max = c.Maxvalue
+		}
+	}
+	for i := range charts {
+		charts[i].Minvalue, charts[i].Maxvalue = min, max
+	}
+}
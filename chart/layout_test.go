@@ -0,0 +1,28 @@
+package chart
+
+import "testing"
+
+func TestFacets(t *testing.T) {
+	panels := Facets(10, 10, 90, 90, 2, 3, 4)
+	if len(panels) != 6 {
+		t.Fatalf("got %d panels, want 6", len(panels))
+	}
+	first, last := panels[0], panels[5]
+	if first.Left != 10 || first.Top != 90 || first.Right != 34 || first.Bottom != 52 {
+		t.Errorf("first panel: %+v", first)
+	}
+	if last.Right != 90 || last.Bottom != 10 || last.Left != 66 {
+		t.Errorf("last panel: %+v", last)
+	}
+	if !first.AxisY || first.AxisX || !last.AxisX || last.AxisY {
+		t.Errorf("axes: %+v, %+v", first, last)
+	}
+
+	charts := []ChartBox{{Minvalue: 5, Maxvalue: 10}, {Minvalue: -2, Maxvalue: 8}}
+	ShareRange(charts)
+	for _, c := range charts {
+		if c.Minvalue != -2 || c.Maxvalue != 10 {
+			t.Errorf("shared range: %v-%v", c.Minvalue, c.Maxvalue)
+		}
+	}
+}
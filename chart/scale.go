@@ -0,0 +1,199 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Scales: logarithmic and symmetric logarithmic value axes, for data of wide range
+
+// Scale is how values map onto a chart's value axis
+type Scale int
+
+// The scales. LogScale is base 10, placing values at or below zero at the bottom, and
+// ignores Zerobased; a Minvalue at or below zero is taken as the least positive value of
+// the data (or a tenth of Maxvalue, if there is none). SymLogScale is logarithmic away from zero, and nearly linear within
+// LinearWidth of it, for data of either sign.
+const (
+	LinearScale Scale = iota
+	LogScale
+	SymLogScale
+)
+
+// transform returns a value as placed by the chart's scale
+func (c *ChartBox) transform(v float64) float64 {
+	switch c.Scale {
+	case LogScale:
+		if v <= 0 {
+			return math.Inf(-1)
+		}
+		return math.Log10(v)
+	case SymLogScale:
+		w := c.LinearWidth
+		if w <= 0 {
+			w = 1
+		}
+		if v < 0 {
+			return -math.Log10(1 - v/w)
+		}
+		return math.Log10(1 + v/w)
+	}
+	return v
+}
+
+// untransform returns the value placed at t by the chart's scale, the inverse of transform
+func (c *ChartBox) untransform(t float64) float64 {
+	switch c.Scale {
+	case LogScale:
+		return math.Pow(10, t)
+	case SymLogScale:
+		w := c.LinearWidth
+		if w <= 0 {
+			w = 1
+		}
+		if t < 0 {
+			return -w * (math.Pow(10, -t) - 1)
+		}
+		return w * (math.Pow(10, t) - 1)
+	}
+	return t
+}
+
+// logrange returns the least and greatest values of a LogScale, both above zero,
+// and the greatest above the least
+func (c *ChartBox) logrange() (float64, float64) {
+	min, max := c.Minvalue, c.Maxvalue
+	if min <= 0 {
+		min = math.Inf(1)
+		for _, d := range c.Data {
+			if d.value > 0 && d.value < min {
+				min = d.value
+			}
+		}
+		if math.IsInf(min, 1) {
+			min = max / 10
+		}
+	}
+	if min <= 0 {
+		min = 1
+	}
+	if max <= min {
+		max = min * 10
+	}
+	return min, max
+}
+
+// valuerange returns the least and greatest values of the value axis
+func (c *ChartBox) valuerange() (float64, float64) {
+	if c.Scale == LogScale {
+		return c.logrange()
+	}
+	return zerobase(c.Zerobased, c.Minvalue), c.Maxvalue
+}
+
+// scalepos maps a value onto the range from low to high, by the chart's scale
+func (c *ChartBox) scalepos(v, low, high float64) float64 {
+	min, max := c.valuerange()
+	t := c.transform(v)
+	if math.IsInf(t, -1) {
+		return low
+	}
+	return gc.MapRange(t, c.transform(min), c.transform(max), low, high)
+}
+
+// yvalue returns the vertical chart coordinate of a value
+func (c *ChartBox) yvalue(v float64) float64 {
+	return c.scalepos(v, c.Bottom, c.Top)
+}
+
+// valueat returns the value at the vertical chart coordinate y, the inverse of yvalue
+func (c *ChartBox) valueat(y float64) float64 {
+	min, max := c.valuerange()
+	return c.untransform(gc.MapRange(y, c.Bottom, c.Top, c.transform(min), c.transform(max)))
+}
+
+// LogTicks returns the major (powers of ten) and minor (their multiples from
+// 2 to 9) tick values between min and max, inclusive, for a LogScale
+func LogTicks(min, max float64) ([]float64, []float64) {
+	var major, minor []float64
+	if min <= 0 || max < min {
+		return nil, nil
+	}
+	for e := math.Floor(math.Log10(min)); e <= math.Ceil(math.Log10(max)); e++ {
+		p := math.Pow(10, e)
+		for m := 1.0; m < 10; m++ {
+			v := m * p
+			if v < min*(1-1e-9) || v > max*(1+1e-9) {
+				continue
+			}
+			if m == 1 {
+				major = append(major, v)
+			} else {
+				minor = append(minor, v)
+			}
+		}
+	}
+	return major, minor
+}
+
+// SymLogTicks returns the major and minor tick values between min and max for a
+// SymLogScale: zero, and the log ticks of each sign beyond the linear width
+func SymLogTicks(min, max, width float64) ([]float64, []float64) {
+	if width <= 0 {
+		width = 1
+	}
+	var major, minor []float64
+	if min <= 0 && max >= 0 {
+		major = append(major, 0)
+	}
+	if min < -width {
+		ma, mi := LogTicks(width, -min)
+		neg := make([]float64, 0, len(ma)+len(major))
+		for i := len(ma) - 1; i >= 0; i-- {
+			neg = append(neg, -ma[i])
+		}
+		major = append(neg, major...)
+		for _, v := range mi {
+			minor = append(minor, -v)
+		}
+	}
+	if max > width {
+		ma, mi := LogTicks(width, max)
+		major = append(major, ma...)
+		minor = append(minor, mi...)
+	}
+	return major, minor
+}
+
+// ScaleAxis makes the y axis for the chart's scale: labeled major ticks, unlabeled
+// minor ticks, and optional grid lines at the major ticks. A LinearScale is labeled
+// as YAxis with ten steps.
+func (c *ChartBox) ScaleAxis(canvas *gc.Canvas, size float64, format string, gridlines bool) {
+	var major, minor []float64
+	switch c.Scale {
+	case LogScale:
+		major, minor = LogTicks(c.logrange())
+	case SymLogScale:
+		major, minor = SymLogTicks(zerobase(c.Zerobased, c.Minvalue), c.Maxvalue, c.LinearWidth)
+	default:
+		min := zerobase(c.Zerobased, c.Minvalue)
+		c.YAxis(canvas, size, min, c.Maxvalue, (c.Maxvalue-min)/10, format, gridlines)
+		return
+	}
+	left, w := float32(c.Left), float32(c.Right-c.Left)
+	tick := float32(size / 2)
+	for _, v := range major {
+		y := float32(c.yvalue(v))
+		if gridlines {
+			canvas.Line(left, y, left+w, y, 0.05, color.NRGBA{128, 128, 128, 255})
+		}
+		canvas.Line(left-tick, y, left, y, 0.1, c.Color)
+		canvas.EText(left-tick-1, y-float32(size/3), float32(size), c.Locale.Format(format, v), c.Color)
+	}
+	for _, v := range minor {
+		y := float32(c.yvalue(v))
+		canvas.Line(left-tick/2, y, left, y, 0.05, c.Color)
+	}
+}
@@ -0,0 +1,47 @@
+package chart
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScales(t *testing.T) {
+	major, minor := LogTicks(1, 1000)
+	if len(major) != 4 || major[0] != 1 || major[3] != 1000 || len(minor) != 24 {
+		t.Errorf("log ticks: %v %v", major, minor)
+	}
+	major, _ = SymLogTicks(-100, 100, 1)
+	if len(major) != 7 || major[0] != -100 || major[3] != 0 || major[6] != 100 {
+		t.Errorf("symlog ticks: %v", major)
+	}
+
+	c := ChartBox{Bottom: 0, Top: 100, Minvalue: 1, Maxvalue: 100, Scale: LogScale}
+	if y := c.yvalue(10); !near(float32(y), 50) {
+		t.Errorf("log: 10 at %v, want 50", y)
+	}
+	if y := c.yvalue(0); y != 0 {
+		t.Errorf("log: 0 at %v, want the bottom", y)
+	}
+	// a minimum at or below zero is the least positive value, or a tenth of the maximum
+	c = ChartBox{Bottom: 0, Top: 100, Maxvalue: 100, Scale: LogScale,
+		Data: []NameValue{{value: 0}, {value: 10}, {value: 100}}}
+	for _, min := range []float64{0, -5} {
+		c.Minvalue = min
+		if y := c.yvalue(10); y != 0 || math.IsNaN(c.valueat(50)) {
+			t.Errorf("log from %v: 10 at %v, want the bottom", min, y)
+		}
+	}
+	c.Data = nil
+	if y := c.yvalue(10); y != 0 || !near(float32(c.valueat(50)), float32(math.Sqrt(1000))) {
+		t.Errorf("log without data: 10 at %v, want the bottom", y)
+	}
+	c.Maxvalue = 0
+	if y := c.yvalue(1); math.IsNaN(y) || math.IsInf(y, 0) {
+		t.Errorf("log of no range: 1 at %v", y)
+	}
+
+	c = ChartBox{Bottom: 0, Top: 100, Minvalue: -99, Maxvalue: 99, Scale: SymLogScale}
+	if y0, y1, y2 := c.yvalue(0), c.yvalue(9), c.yvalue(-9); !near(float32(y0), 50) || !near(float32(y1), 75) || !near(float32(y2), 25) {
+		t.Errorf("symlog: %v %v %v", y0, y1, y2)
+	}
+}
@@ -0,0 +1,130 @@
+package chart
+
+import (
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Time axes: ticks at round intervals, from seconds to years, labeled to suit the interval
+
+// time units of tick intervals
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// timestep is a tick interval: n units, and the layout of its labels
+type timestep struct {
+	unit, n int
+	approx  time.Duration
+	layout  string
+}
+
+const day = 24 * time.Hour
+
+// timesteps are the tick intervals, shortest first
+var timesteps = []timestep{
+	{unitSecond, 1, time.Second, "15:04:05"},
+	{unitSecond, 5, 5 * time.Second, "15:04:05"},
+	{unitSecond, 15, 15 * time.Second, "15:04:05"},
+	{unitSecond, 30, 30 * time.Second, "15:04:05"},
+	{unitMinute, 1, time.Minute, "15:04"},
+	{unitMinute, 5, 5 * time.Minute, "15:04"},
+	{unitMinute, 15, 15 * time.Minute, "15:04"},
+	{unitMinute, 30, 30 * time.Minute, "15:04"},
+	{unitHour, 1, time.Hour, "15:04"},
+	{unitHour, 3, 3 * time.Hour, "15:04"},
+	{unitHour, 6, 6 * time.Hour, "Jan 2 15:04"},
+	{unitHour, 12, 12 * time.Hour, "Jan 2 15:04"},
+	{unitDay, 1, day, "Jan 2"},
+	{unitDay, 2, 2 * day, "Jan 2"},
+	{unitWeek, 1, 7 * day, "Jan 2"},
+	{unitMonth, 1, 30 * day, "Jan 2006"},
+	{unitMonth, 3, 91 * day, "Jan 2006"},
+	{unitMonth, 6, 182 * day, "Jan 2006"},
+	{unitYear, 1, 365 * day, "2006"},
+}
+
+// floor returns the start of the interval containing t, in t's location
+func (s timestep) floor(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	loc := t.Location()
+	switch s.unit {
+	case unitSecond:
+		return time.Date(y, mo, d, h, mi, sec-sec%s.n, 0, loc)
+	case unitMinute:
+		return time.Date(y, mo, d, h, mi-mi%s.n, 0, 0, loc)
+	case unitHour:
+		return time.Date(y, mo, d, h-h%s.n, 0, 0, 0, loc)
+	case unitDay:
+		return time.Date(y, mo, d, 0, 0, 0, 0, loc)
+	case unitWeek: // weeks begin on Monday
+		return time.Date(y, mo, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	case unitMonth:
+		return time.Date(y, mo-(mo-1)%time.Month(s.n), 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(y-y%s.n, 1, 1, 0, 0, 0, 0, loc)
+}
+
+// next returns the time an interval after t; days and longer keep to the calendar
+// (midnight stays midnight across daylight saving changes)
+func (s timestep) next(t time.Time) time.Time {
+	switch s.unit {
+	case unitDay:
+		return t.AddDate(0, 0, s.n)
+	case unitWeek:
+		return t.AddDate(0, 0, 7*s.n)
+	case unitMonth:
+		return t.AddDate(0, s.n, 0)
+	case unitYear:
+		return t.AddDate(s.n, 0, 0)
+	}
+	return t.Add(s.approx)
+}
+
+// TimeTicks returns the times of ticks from start to end, in the location loc (nil for
+// local time), at the round interval making closest to n ticks, and the time layout
+// suited to labelling them
+func TimeTicks(start, end time.Time, n int, loc *time.Location) ([]time.Time, string) {
+	if !end.After(start) || n < 1 {
+		return nil, ""
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	span := end.Sub(start)
+	step := timesteps[len(timesteps)-1]
+	for _, s := range timesteps {
+		if span/s.approx <= time.Duration(n) {
+			step = s
+			break
+		}
+	}
+	var ticks []time.Time
+	for t := step.floor(start.In(loc)); !t.After(end); t = step.next(t) {
+		if !t.Before(start) {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks, step.layout
+}
+
+// TimeAxis labels the x axis of a chart whose data points are evenly spaced in time from
+// start to end, with about n ticks, showing times in the location loc (nil for local time)
+func (c *ChartBox) TimeAxis(canvas *gc.Canvas, size float64, start, end time.Time, n int, loc *time.Location) {
+	ticks, layout := TimeTicks(start, end, n, loc)
+	span := float64(end.Sub(start))
+	tick := float32(size / 2)
+	for _, t := range ticks {
+		x := float32(gc.MapRange(float64(t.Sub(start)), 0, span, c.Left, c.Right))
+		canvas.Line(x, float32(c.Bottom), x, float32(c.Bottom)-tick, 0.1, c.Color)
+		canvas.CText(x, float32(c.Bottom-(size*2)), float32(size), t.Format(layout), c.Color)
+	}
+}
@@ -0,0 +1,27 @@
+package chart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeTicks(t *testing.T) {
+	zone := time.FixedZone("EST", -5*3600)
+	start := time.Date(2023, 3, 1, 0, 10, 0, 0, zone)
+	ticks, layout := TimeTicks(start, start.Add(6*time.Hour), 6, zone)
+	if len(ticks) != 6 || layout != "15:04" || ticks[0].Format(layout) != "01:00" {
+		t.Errorf("hourly: %v %q", ticks, layout)
+	}
+
+	// the same span, shown in UTC
+	ticks, _ = TimeTicks(start, start.Add(6*time.Hour), 6, time.UTC)
+	if len(ticks) != 6 || ticks[0].Format("15:04") != "06:00" {
+		t.Errorf("hourly, UTC: %v", ticks)
+	}
+
+	start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ticks, layout = TimeTicks(start, start.AddDate(1, 0, -1), 4, time.UTC)
+	if len(ticks) != 4 || layout != "Jan 2006" || ticks[1].Month() != time.April {
+		t.Errorf("quarterly: %v %q", ticks, layout)
+	}
+}
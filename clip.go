@@ -0,0 +1,65 @@
+package giocanvas
+
+import (
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+)
+
+// Clipping: confining drawing to a region until the clip is ended
+
+// AbsClipRect confines drawing to the rectangle with upper left corner at (x, y), sized (w, h)
+func (c *Canvas) AbsClipRect(x, y, w, h float32) clip.Stack {
+	return clip.Outline{Path: c.polypath([]float32{x, x + w, x + w, x}, []float32{y, y, y + h, y + h})}.Op().Push(c.Context.Ops)
+}
+
+// AbsClipEllipse confines drawing to the ellipse centered at (x, y), radii (w, h)
+func (c *Canvas) AbsClipEllipse(x, y, w, h float32) clip.Stack {
+	const k = 0.551915024494 // as in ellipsepath
+	path := new(clip.Path)
+	path.Begin(c.Context.Ops)
+	path.MoveTo(f32.Pt(x+w, y))
+	path.CubeTo(f32.Pt(x+w, y+h*k), f32.Pt(x+w*k, y+h), f32.Pt(x, y+h))
+	path.CubeTo(f32.Pt(x-w*k, y+h), f32.Pt(x-w, y+h*k), f32.Pt(x-w, y))
+	path.CubeTo(f32.Pt(x-w, y-h*k), f32.Pt(x-w*k, y-h), f32.Pt(x, y-h))
+	path.CubeTo(f32.Pt(x+w*k, y-h), f32.Pt(x+w, y-h*k), f32.Pt(x+w, y))
+	path.Close()
+	return clip.Outline{Path: path.End()}.Op().Push(c.Context.Ops)
+}
+
+// AbsClipCircle confines drawing to the circle centered at (x, y), radius r
+func (c *Canvas) AbsClipCircle(x, y, r float32) clip.Stack {
+	return c.AbsClipEllipse(x, y, r, r)
+}
+
+// ClipRect confines drawing to the rectangle centered at (x, y), sized (w, h),
+// using percentage-based measures
+func (c *Canvas) ClipRect(x, y, w, h float32) clip.Stack {
+	x, y = dimen(x, y, c.Width, c.Height)
+	w = pct(w, c.Width)
+	h = pct(h, c.Height)
+	return c.AbsClipRect(x-w/2, y-h/2, w, h)
+}
+
+// ClipCircle confines drawing to the circle centered at (x, y), radius r (a percentage
+// of the width), using percentage-based measures
+func (c *Canvas) ClipCircle(x, y, r float32) clip.Stack {
+	x, y = dimen(x, y, c.Width, c.Height)
+	return c.AbsClipCircle(x, y, pct(r, c.Width))
+}
+
+// ClipEllipse confines drawing to the ellipse centered at (x, y), radii (w, h),
+// using percentage-based measures
+func (c *Canvas) ClipEllipse(x, y, w, h float32) clip.Stack {
+	x, y = dimen(x, y, c.Width, c.Height)
+	return c.AbsClipEllipse(x, y, pct(w, c.Width), pct(h, c.Height))
+}
+
+// ClipPath confines drawing to the inside of the path (every subpath closed)
+func (c *Canvas) ClipPath(p *Path) clip.Stack {
+	return clip.Outline{Path: p.build(c, true)}.Op().Push(c.Context.Ops)
+}
+
+// EndClip ends a clip, restoring the previous clipping region
+func EndClip(stack clip.Stack) {
+	stack.Pop()
+}
@@ -0,0 +1,167 @@
+package giocanvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Color vision deficiency simulation and palette checking
+
+// Deficiency is a type of color vision deficiency
+type Deficiency int
+
+// Color vision deficiencies
+const (
+	Protanopia Deficiency = iota
+	Deuteranopia
+	Tritanopia
+)
+
+// Deficiencies lists all the simulated deficiencies
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+
+func (d Deficiency) String() string {
+	switch d {
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	}
+	return "unknown"
+}
+
+// cvdmatrix holds the simulation matrices (linear RGB, full severity), from
+// Machado, Oliveira, Fernandes: "A Physiologically-based Model for Simulation of Color Vision Deficiency" (2009)
+var cvdmatrix = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// srgb2linear converts an sRGB component (0-255) to linear light (0-1)
+func srgb2linear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// linear2srgb converts linear light (0-1) to an sRGB component (0-255)
+func linear2srgb(c float64) uint8 {
+	if c <= 0 {
+		return 0
+	}
+	if c >= 1 {
+		return 255
+	}
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(c * 255))
+}
+
+// SimulateColorBlind returns the color as seen with the specified deficiency
+func SimulateColorBlind(c color.NRGBA, d Deficiency) color.NRGBA {
+	m, ok := cvdmatrix[d]
+	if !ok {
+		return c
+	}
+	r, g, b := srgb2linear(c.R), srgb2linear(c.G), srgb2linear(c.B)
+	return color.NRGBA{
+		R: linear2srgb(m[0][0]*r + m[0][1]*g + m[0][2]*b),
+		G: linear2srgb(m[1][0]*r + m[1][1]*g + m[1][2]*b),
+		B: linear2srgb(m[2][0]*r + m[2][1]*g + m[2][2]*b),
+		A: c.A,
+	}
+}
+
+// SimulateImage returns a copy of an image (for example a captured frame)
+// as seen with the specified deficiency
+func SimulateImage(img image.Image, d Deficiency) *image.NRGBA {
+	b := img.Bounds()
+	out := image.NewNRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			out.SetNRGBA(x, y, SimulateColorBlind(c, d))
+		}
+	}
+	return out
+}
+
+// lab converts a color to CIE L*a*b* (D65 white)
+func lab(c color.NRGBA) (float64, float64, float64) {
+	r, g, b := srgb2linear(c.R), srgb2linear(c.G), srgb2linear(c.B)
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := (0.2126*r + 0.7152*g + 0.0722*b) / 1.0
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+	f := func(t float64) float64 {
+		if t > 216.0/24389.0 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27.0*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// ColorDistance returns the perceptual difference (CIE76 ΔE) between two colors;
+// differences below about 10 are hard to tell apart in charts
+func ColorDistance(a, b color.NRGBA) float64 {
+	l1, a1, b1 := lab(a)
+	l2, a2, b2 := lab(b)
+	return math.Sqrt((l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2))
+}
+
+// PaletteWarning describes adjacent palette colors that become hard to distinguish
+type PaletteWarning struct {
+	Deficiency Deficiency
+	Index      int // the colors at Index and Index+1 are too similar
+	Distance   float64
+}
+
+func (w PaletteWarning) String() string {
+	return fmt.Sprintf("%v: colors %d and %d are hard to distinguish (ΔE %.1f)", w.Deficiency, w.Index, w.Index+1, w.Distance)
+}
+
+// CheckPalette simulates each deficiency on the palette, warning when adjacent
+// colors that are distinct with normal vision become closer than threshold (ΔE).
+// A threshold of zero uses 10.
+func CheckPalette(palette []color.NRGBA, threshold float64) []PaletteWarning {
+	if threshold <= 0 {
+		threshold = 10
+	}
+	var warnings []PaletteWarning
+	for _, d := range Deficiencies {
+		for i := 0; i < len(palette)-1; i++ {
+			if ColorDistance(palette[i], palette[i+1]) < threshold {
+				continue
+			}
+			a := SimulateColorBlind(palette[i], d)
+			b := SimulateColorBlind(palette[i+1], d)
+			if dist := ColorDistance(a, b); dist < threshold {
+				warnings = append(warnings, PaletteWarning{Deficiency: d, Index: i, Distance: dist})
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,23 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestCheckPalette(t *testing.T) {
+	redgreen := []color.NRGBA{ColorLookup("#cc6666"), ColorLookup("#669966")}
+	w := CheckPalette(redgreen, 0)
+	found := false
+	for _, pw := range w {
+		if pw.Deficiency == Deuteranopia {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("red/green not flagged for deuteranopia: %v", w)
+	}
+	if w := CheckPalette([]color.NRGBA{ColorLookup("black"), ColorLookup("white")}, 0); len(w) != 0 {
+		t.Errorf("black/white flagged: %v", w)
+	}
+}
@@ -315,3 +315,34 @@ func hsv2rgb(h, s, v float64) (uint8, uint8, uint8) {
 	b += m
 	return uint8(r * 255), uint8(g * 255), uint8(b * 255)
 }
+
+// HSV returns the color with hue (0-360), saturation and value (0-100), and alpha
+func HSV(h, s, v float64, alpha uint8) color.NRGBA {
+	r, g, b := hsv2rgb(h, s, v)
+	return color.NRGBA{r, g, b, alpha}
+}
+
+// ToHSV returns the hue (0-360), saturation and value (0-100) of a color
+func ToHSV(c color.NRGBA) (h, s, v float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	d := max - min
+	switch {
+	case d == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/d, 6)
+	case max == g:
+		h = 60 * ((b-r)/d + 2)
+	default:
+		h = 60 * ((r-g)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	if max > 0 {
+		s = 100 * d / max
+	}
+	return h, s, 100 * max
+}
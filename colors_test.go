@@ -0,0 +1,42 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestHSV(t *testing.T) {
+	for _, c := range []color.NRGBA{{255, 0, 0, 255}, {0, 128, 255, 255}, {30, 200, 90, 128}, {128, 128, 128, 255}} {
+		h, s, v := ToHSV(c)
+		got := HSV(h, s, v, c.A)
+		for i, pair := range [][2]uint8{{got.R, c.R}, {got.G, c.G}, {got.B, c.B}} {
+			if math.Abs(float64(pair[0])-float64(pair[1])) > 1 {
+				t.Errorf("%v: component %d round trip = %d", c, i, pair[0])
+			}
+		}
+	}
+	if h, s, v := ToHSV(color.NRGBA{0, 0, 255, 255}); h != 240 || s != 100 || v != 100 {
+		t.Errorf("blue = hsv(%g, %g, %g)", h, s, v)
+	}
+}
+
+func TestContrastingColor(t *testing.T) {
+	black, white := color.NRGBA{0, 0, 0, 255}, color.NRGBA{255, 255, 255, 255}
+	tests := []struct {
+		bg, want color.NRGBA
+	}{
+		{white, black},
+		{black, white},
+		{color.NRGBA{255, 255, 0, 255}, black},
+		{color.NRGBA{0, 0, 128, 255}, white},
+	}
+	for _, tc := range tests {
+		if got := ContrastingColor(tc.bg); got != tc.want {
+			t.Errorf("ContrastingColor(%v) = %v, want %v", tc.bg, got, tc.want)
+		}
+	}
+	if r := ContrastRatio(black, white); math.Abs(r-21) > 0.01 {
+		t.Errorf("ContrastRatio(black, white) = %g", r)
+	}
+}
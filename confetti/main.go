@@ -23,13 +23,14 @@ func rn8(n int) uint8 {
 }
 
 func confetti(w *app.Window, width, height float32, nshapes, maxsize int) error {
+	canvas := giocanvas.NewCanvas(width, height, system.FrameEvent{})
 	for {
 		e := <-w.Events()
 		switch e := e.(type) {
 		case system.DestroyEvent:
 			return e.Err
 		case system.FrameEvent:
-			canvas := giocanvas.NewCanvas(width, height, system.FrameEvent{})
+			canvas.Reset(width, height, system.FrameEvent{})
 			canvas.CenterRect(50, 50, 100, 100, color.NRGBA{0, 0, 0, 255})
 			for i := 0; i < nshapes; i++ {
 				color := color.NRGBA{rn8(255), rn8(255), rn8(255), rn8(255)}
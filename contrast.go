@@ -0,0 +1,45 @@
+package giocanvas
+
+import "image/color"
+
+// Contrast: choosing legible text colors over backgrounds
+
+// RelativeLuminance returns the relative luminance (0-1) of a color, as defined by WCAG
+func RelativeLuminance(c color.NRGBA) float64 {
+	return 0.2126*srgb2linear(c.R) + 0.7152*srgb2linear(c.G) + 0.0722*srgb2linear(c.B)
+}
+
+// ContrastRatio returns the WCAG contrast ratio (1-21) of two colors
+func ContrastRatio(a, b color.NRGBA) float64 {
+	la, lb := RelativeLuminance(a), RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ContrastingColor returns black or white, whichever contrasts more with the background
+func ContrastingColor(bg color.NRGBA) color.NRGBA {
+	black, white := color.NRGBA{0, 0, 0, 255}, color.NRGBA{255, 255, 255, 255}
+	if ContrastRatio(bg, black) >= ContrastRatio(bg, white) {
+		return black
+	}
+	return white
+}
+
+// ContrastingText places text (as Text does) in black or white, whichever is more
+// legible over the background color bg
+func (c *Canvas) ContrastingText(x, y, size float32, s string, bg color.NRGBA) {
+	c.Text(x, y, size, s, ContrastingColor(bg))
+}
+
+// OutlinedText places text (as Text does) with an outline, so that it stays
+// legible over busy backgrounds like photos
+func (c *Canvas) OutlinedText(x, y, size float32, s string, fillcolor, outline color.NRGBA) {
+	d := size / 16
+	dy := d * c.Width / c.Height
+	for _, o := range [8][2]float32{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}} {
+		c.Text(x+o[0]*d, y+o[1]*dy, size, s, outline)
+	}
+	c.Text(x, y, size, s, fillcolor)
+}
@@ -13,6 +13,16 @@ func MapRange(value, low1, high1, low2, high2 float64) float64 {
 	return low2 + (high2-low2)*(value-low1)/(high1-low1)
 }
 
+// AbsCoord converts percentage-based coordinates (x, y) to absolute canvas coordinates
+func (c *Canvas) AbsCoord(x, y float32) (float32, float32) {
+	return dimen(x, y, c.Width, c.Height)
+}
+
+// PctCoord converts absolute canvas coordinates (x, y) to percentage-based coordinates
+func (c *Canvas) PctCoord(x, y float32) (float32, float32) {
+	return 100 * (x / c.Width), 100 - (100 * (y / c.Height))
+}
+
 // PolarDegrees returns the Cartesian coordinates (x, y) from polar coordinates
 // with compensation for canvas aspect ratio
 // center at (cx, cy), radius r, and angle theta (degrees)
@@ -0,0 +1,203 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Dashes: dashed and dotted lines, curves, arcs and polygon outlines, drawn by the Styled
+// functions with a StrokeStyle that has Dashes, a pattern of on and off lengths. With
+// round caps, a dash of zero length makes a dot. Curves are flattened into short lines,
+// and the pattern continues from one to the next, so that dashes are spaced evenly along them.
+
+// dashes returns the spans (start and end distances) of a line, length long, that the
+// pattern of alternating on and off lengths draws, when begun offset into the pattern.
+// A pattern of odd length repeats, on becoming off, as in SVG.
+func dashes(length float32, pattern []float32, offset float32) [][2]float32 {
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float32{}, pattern...), pattern...)
+	}
+	var period float32
+	for _, p := range pattern {
+		if p < 0 {
+			return [][2]float32{{0, length}}
+		}
+		period += p
+	}
+	if period <= 0 {
+		return [][2]float32{{0, length}}
+	}
+	offset = float32(math.Mod(float64(offset), float64(period)))
+	if offset < 0 {
+		offset += period
+	}
+	i := 0
+	for offset > 0 && offset >= pattern[i] {
+		offset -= pattern[i]
+		i = (i + 1) % len(pattern)
+	}
+	var spans [][2]float32
+	for pos := -offset; pos < length; i = (i + 1) % len(pattern) {
+		end := pos + pattern[i]
+		if i%2 == 0 {
+			spans = append(spans, [2]float32{max32(pos, 0), min32(end, length)})
+		}
+		pos = end
+	}
+	return spans
+}
+
+// dashedline strokes the dashes of a line from (x0, y0) to (x1, y1) in the style,
+// begun offset into its pattern
+func (c *Canvas) dashedline(x0, y0, x1, y1, size float32, style StrokeStyle, strokecolor color.NRGBA, offset float32) {
+	dx, dy := x1-x0, y1-y0
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 {
+		return
+	}
+	ux, uy := dx/length, dy/length
+	spans := dashes(length, style.Dashes, offset)
+	if style.styled() {
+		for _, s := range spans {
+			if s[1] > s[0] {
+				c.strokestyled([]float32{x0 + ux*s[0], x0 + ux*s[1]}, []float32{y0 + uy*s[0], y0 + uy*s[1]}, size, style, strokecolor)
+			}
+		}
+		return
+	}
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	var dots [][2]float32
+	for _, s := range spans {
+		if s[1] > s[0] {
+			path.MoveTo(f32.Pt(x0+ux*s[0], y0+uy*s[0]))
+			path.LineTo(f32.Pt(x0+ux*s[1], y0+uy*s[1]))
+		} else {
+			dots = append(dots, s)
+		}
+	}
+	stack := clip.Stroke{Path: path.End(), Width: size}.Op().Push(ops)
+	paint.Fill(ops, strokecolor)
+	stack.Pop()
+	for _, s := range dots {
+		c.fillellipse(x0+ux*s[0], y0+uy*s[0], size/2, size/2, strokecolor)
+	}
+}
+
+// AbsPolygonOutline strokes the outline of a closed polygon with vertices in x and y
+func (c *Canvas) AbsPolygonOutline(x, y []float32, size float32, strokecolor color.NRGBA) {
+	c.AbsStyledPolygonOutline(x, y, size, StrokeStyle{}, strokecolor)
+}
+
+// AbsStyledPolygonOutline strokes the outline of a closed polygon, as AbsPolygonOutline,
+// in the stroke style; dashes continue around the corners
+func (c *Canvas) AbsStyledPolygonOutline(x, y []float32, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	if c.Recorder != nil {
+		points := make([]float32, 0, len(x)*2)
+		for i := range x {
+			points = append(points, x[i], y[i])
+		}
+		c.record(DrawCall{Op: "polygonoutline", Size: size, Color: strokecolor}, points...)
+	}
+	n := len(x)
+	if style.dashed() {
+		offset := style.DashOffset
+		for i := 0; i < n; i++ {
+			j := (i + 1) % n
+			c.dashedline(x[i], y[i], x[j], y[j], size, style, strokecolor, offset)
+			offset += float32(math.Hypot(float64(x[j]-x[i]), float64(y[j]-y[i])))
+		}
+		return
+	}
+	if style.styled() {
+		// from the middle of the first side around to it again, so that every corner is joined
+		mx, my := (x[0]+x[1])/2, (y[0]+y[1])/2
+		px, py := append([]float32{mx}, x[1:]...), append([]float32{my}, y[1:]...)
+		px, py = append(px, x[0], mx), append(py, y[0], my)
+		c.strokestyled(px, py, size, style, strokecolor)
+		return
+	}
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	path.MoveTo(f32.Pt(x[0], y[0]))
+	for i := 1; i < n; i++ {
+		path.LineTo(f32.Pt(x[i], y[i]))
+	}
+	path.Close()
+	stack := clip.Stroke{Path: path.End(), Width: size}.Op().Push(ops)
+	paint.Fill(ops, strokecolor)
+	stack.Pop()
+}
+
+// PolygonOutline strokes the outline of a closed polygon, as AbsPolygonOutline,
+// using percentage-based measures
+func (c *Canvas) PolygonOutline(x, y []float32, size float32, strokecolor color.NRGBA) {
+	c.StyledPolygonOutline(x, y, size, StrokeStyle{}, strokecolor)
+}
+
+// StyledPolygonOutline strokes the outline of a closed polygon in the stroke style, as
+// AbsStyledPolygonOutline, using percentage-based measures
+func (c *Canvas) StyledPolygonOutline(x, y []float32, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	if len(x) != len(y) {
+		return
+	}
+	nx := make([]float32, len(x))
+	ny := make([]float32, len(y))
+	for i := range x {
+		nx[i], ny[i] = dimen(x[i], y[i], c.Width, c.Height)
+	}
+	c.AbsStyledPolygonOutline(nx, ny, pct(size, c.Width), pctstyle(style, c.Width), strokecolor)
+}
+
+// flatsegments returns the number of lines to flatten a curve into, from the length of its
+// control polygon (through the points, x and y pairs)
+func flatsegments(points ...float32) int {
+	var length float64
+	for i := 2; i+1 < len(points); i += 2 {
+		length += math.Hypot(float64(points[i]-points[i-2]), float64(points[i+1]-points[i-1]))
+	}
+	n := int(length / 4)
+	if n < 8 {
+		return 8
+	}
+	if n > 1000 {
+		return 1000
+	}
+	return n
+}
+
+// flattenquad returns the points along a quadratic bezier curve
+func flattenquad(x, y, cx, cy, ex, ey float32) ([]float32, []float32) {
+	n := flatsegments(x, y, cx, cy, ex, ey)
+	px, py := make([]float32, n+1), make([]float32, n+1)
+	for i := 0; i <= n; i++ {
+		t := float32(i) / float32(n)
+		a, b, c := (1-t)*(1-t), 2*(1-t)*t, t*t
+		px[i] = a*x + b*cx + c*ex
+		py[i] = a*y + b*cy + c*ey
+	}
+	return px, py
+}
+
+// flattencubic returns the points along a cubic bezier curve
+func flattencubic(x, y, cx1, cy1, cx2, cy2, ex, ey float32) ([]float32, []float32) {
+	n := flatsegments(x, y, cx1, cy1, cx2, cy2, ex, ey)
+	px, py := make([]float32, n+1), make([]float32, n+1)
+	for i := 0; i <= n; i++ {
+		t := float32(i) / float32(n)
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		px[i] = a*x + b*cx1 + c*cx2 + d*ex
+		py[i] = a*y + b*cy1 + c*cy2 + d*ey
+	}
+	return px, py
+}
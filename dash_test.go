@@ -0,0 +1,89 @@
+package giocanvas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDashes(t *testing.T) {
+	tests := []struct {
+		length  float32
+		pattern []float32
+		offset  float32
+		want    [][2]float32
+	}{
+		{10, []float32{2, 2}, 0, [][2]float32{{0, 2}, {4, 6}, {8, 10}}},
+		{10, []float32{3, 2}, 1, [][2]float32{{0, 2}, {4, 7}, {9, 10}}},
+		{10, []float32{3, 2}, 3, [][2]float32{{2, 5}, {7, 10}}},
+		{6, []float32{0, 2}, 0, [][2]float32{{0, 0}, {2, 2}, {4, 4}}},
+		{10, []float32{2}, 0, [][2]float32{{0, 2}, {4, 6}, {8, 10}}},
+		{5, []float32{0, 0}, 0, [][2]float32{{0, 5}}},
+		{5, []float32{2, 1}, -1, [][2]float32{{1, 3}, {4, 5}}},
+	}
+	for _, test := range tests {
+		got := dashes(test.length, test.pattern, test.offset)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("dashes(%v, %v, %v) = %v, want %v", test.length, test.pattern, test.offset, got, test.want)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	px, py := flattencubic(0, 0, 0, 100, 100, 100, 100, 0)
+	n := len(px) - 1
+	if px[0] != 0 || py[0] != 0 || px[n] != 100 || py[n] != 0 {
+		t.Errorf("cubic ends at (%v, %v), (%v, %v)", px[0], py[0], px[n], py[n])
+	}
+	if top := maxof(py); top < 74.9 || top > 75 {
+		t.Errorf("cubic peaks at y = %v, want 75", top)
+	}
+	_, py = flattenquad(0, 0, 50, 100, 100, 0)
+	if top := maxof(py); top < 49.9 || top > 50 {
+		t.Errorf("quad peaks at y = %v, want 50", top)
+	}
+}
+
+func maxof(v []float32) float32 {
+	m := v[0]
+	for _, x := range v {
+		m = max32(m, x)
+	}
+	return m
+}
+
+func TestPctStyle(t *testing.T) {
+	s := StrokeStyle{Cap: ButtCap, Dashes: []float32{1, 2}, DashOffset: 0.5}
+	abs := pctstyle(s, 200)
+	if !reflect.DeepEqual(abs.Dashes, []float32{2, 4}) || abs.DashOffset != 1 || abs.Cap != ButtCap {
+		t.Errorf("pctstyle: %+v", abs)
+	}
+	if s.Dashes[0] != 1 {
+		t.Error("pctstyle changed the style's dashes")
+	}
+	if solid := (StrokeStyle{}); solid.dashed() || solid.styled() {
+		t.Error("zero style is dashed or styled")
+	}
+}
+
+func TestStyledHVLines(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	dashed := StrokeStyle{Cap: ButtCap, Dashes: []float32{2, 1}}
+	c.StyledHLine(10, 20, 50, 0.5, dashed, c.TextColor)
+	c.StyledVLine(10, 20, 30, 0.5, dashed, c.TextColor)
+	want := [][]float32{{10, 20, 60, 20}, {10, 20, 10, 50}}
+	lines := log.Find("line")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(want))
+	}
+	for i, l := range lines {
+		for j, v := range want[i] {
+			if d := l.Points[j] - v; d > 1e-3 || d < -1e-3 {
+				t.Errorf("line %d: %v, want %v", i, l.Points, want[i])
+				break
+			}
+		}
+		if d := l.Size - 0.5; d > 1e-4 || d < -1e-4 {
+			t.Errorf("line %d: size %v", i, l.Size)
+		}
+	}
+}
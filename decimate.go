@@ -0,0 +1,125 @@
+package giocanvas
+
+import "math"
+
+// Level of detail: reducing dense data to the points that can be seen
+
+// Decimate reduces a series (x increasing) to at most four points per column,
+// dividing the x range into the given number of columns, and keeping the first,
+// last, lowest and highest points of each column, in order. The shape of the
+// series drawn as a line is unchanged at that resolution.
+// The points are returned unchanged if there are no more than 4*columns of them.
+func Decimate(x, y []float32, columns int) ([]float32, []float32) {
+	n := len(x)
+	if len(y) < n {
+		n = len(y)
+	}
+	if columns < 1 || n <= 4*columns {
+		return x[:n], y[:n]
+	}
+	xmin, xmax := x[0], x[n-1]
+	if xmin > xmax {
+		xmin, xmax = xmax, xmin
+	}
+	width := (xmax - xmin) / float32(columns)
+	if width <= 0 {
+		width = 1
+	}
+	dx := make([]float32, 0, 4*columns)
+	dy := make([]float32, 0, 4*columns)
+	keep := func(i int) {
+		if l := len(dx); l > 0 && dx[l-1] == x[i] && dy[l-1] == y[i] {
+			return
+		}
+		dx = append(dx, x[i])
+		dy = append(dy, y[i])
+	}
+	start := 0
+	for start < n {
+		col := int((x[start] - xmin) / width)
+		first, lo, hi, last := start, start, start, start
+		i := start + 1
+		for ; i < n && int((x[i]-xmin)/width) == col; i++ {
+			if y[i] < y[lo] {
+				lo = i
+			}
+			if y[i] > y[hi] {
+				hi = i
+			}
+			last = i
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		keep(first)
+		keep(lo)
+		keep(hi)
+		keep(last)
+		start = i
+	}
+	return dx, dy
+}
+
+// BinPoints reduces a set of points to at most one per cell of a grid with
+// the given number of columns and rows, covering the extent of the points.
+// The first point in each cell is kept, along with the count of points in the cell.
+func BinPoints(x, y []float32, columns, rows int) ([]float32, []float32, []int) {
+	n := len(x)
+	if len(y) < n {
+		n = len(y)
+	}
+	if columns < 1 || rows < 1 || n <= columns*rows {
+		counts := make([]int, n)
+		for i := range counts {
+			counts[i] = 1
+		}
+		return x[:n], y[:n], counts
+	}
+	xmin, xmax := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+	ymin, ymax := xmin, xmax
+	for i := 0; i < n; i++ {
+		xmin, xmax = min32(xmin, x[i]), max32(xmax, x[i])
+		ymin, ymax = min32(ymin, y[i]), max32(ymax, y[i])
+	}
+	cw := (xmax - xmin) / float32(columns)
+	ch := (ymax - ymin) / float32(rows)
+	cell := func(v, lo, size float32, limit int) int {
+		if size <= 0 {
+			return 0
+		}
+		c := int((v - lo) / size)
+		if c >= limit {
+			c = limit - 1
+		}
+		return c
+	}
+	index := make(map[int]int)
+	var bx, by []float32
+	var counts []int
+	for i := 0; i < n; i++ {
+		k := cell(y[i], ymin, ch, rows)*columns + cell(x[i], xmin, cw, columns)
+		if j, ok := index[k]; ok {
+			counts[j]++
+			continue
+		}
+		index[k] = len(bx)
+		bx = append(bx, x[i])
+		by = append(by, y[i])
+		counts = append(counts, 1)
+	}
+	return bx, by, counts
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
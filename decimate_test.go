@@ -0,0 +1,38 @@
+package giocanvas
+
+import "testing"
+
+func TestDecimate(t *testing.T) {
+	n := 100000
+	x := make([]float32, n)
+	y := make([]float32, n)
+	for i := range x {
+		x[i] = float32(i)
+		y[i] = float32(i % 100)
+	}
+	y[n/2] = 1000
+	dx, dy := Decimate(x, y, 100)
+	if len(dx) > 400 || len(dx) != len(dy) {
+		t.Fatalf("got %d points, want at most 400", len(dx))
+	}
+	peak := false
+	for i := range dx {
+		if dy[i] == 1000 {
+			peak = true
+		}
+		if i > 0 && dx[i] < dx[i-1] {
+			t.Fatalf("points out of order at %d", i)
+		}
+	}
+	if !peak {
+		t.Error("peak was dropped")
+	}
+	bx, _, counts := BinPoints(x, y, 10, 10)
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if len(bx) > 100 || total != n {
+		t.Errorf("binned %d points into %d cells", total, len(bx))
+	}
+}
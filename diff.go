@@ -0,0 +1,160 @@
+package giocanvas
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/text"
+)
+
+// Diffs: unified diffs shown as in code review, with the lines removed and added
+// on red and green, and the line numbers of both versions
+
+// diffline is a line of a unified diff: kind is '+' (added), '-' (removed), ' ' (context)
+// or '@' (hunk header); old and new are its line numbers in each version (0 for none)
+type diffline struct {
+	kind     byte
+	old, new int
+	text     string
+}
+
+// difflines parses a unified diff. File headers ("---", "+++" and the like) are
+// skipped before the first hunk of each file, as told by the line counts of the hunk
+// headers; lines before any hunk header are numbered from 1.
+func difflines(diff string) []diffline {
+	var lines []diffline
+	oldline, newline := 1, 1
+	oldleft, newleft := 0, 0 // lines of the hunk still to come
+	for _, s := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		inhunk := oldleft > 0 || newleft > 0
+		switch {
+		case !inhunk && (strings.HasPrefix(s, "---") || strings.HasPrefix(s, "+++") ||
+			strings.HasPrefix(s, "diff ") || strings.HasPrefix(s, "index ")), strings.HasPrefix(s, `\`):
+			continue
+		case !inhunk && strings.HasPrefix(s, "@@"):
+			oldline, oldleft, newline, newleft = hunkrange(s)
+			lines = append(lines, diffline{kind: '@', text: s})
+		case strings.HasPrefix(s, "+"):
+			lines = append(lines, diffline{kind: '+', new: newline, text: s[1:]})
+			newline++
+			newleft--
+		case strings.HasPrefix(s, "-"):
+			lines = append(lines, diffline{kind: '-', old: oldline, text: s[1:]})
+			oldline++
+			oldleft--
+		default:
+			lines = append(lines, diffline{kind: ' ', old: oldline, new: newline, text: strings.TrimPrefix(s, " ")})
+			oldline++
+			newline++
+			oldleft--
+			newleft--
+		}
+	}
+	return lines
+}
+
+// hunkrange returns the first line and count of lines of each version, from a hunk
+// header such as "@@ -10,4 +10,5 @@"; an omitted count is 1
+func hunkrange(s string) (old, oldn, new, newn int) {
+	f := strings.Fields(s)
+	if len(f) < 3 {
+		return 0, 0, 0, 0
+	}
+	old, oldn = linerange(strings.TrimPrefix(f[1], "-"))
+	new, newn = linerange(strings.TrimPrefix(f[2], "+"))
+	return old, oldn, new, newn
+}
+
+// linerange parses "start,count" or "start"
+func linerange(s string) (start, count int) {
+	count = 1
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		count, _ = strconv.Atoi(s[i+1:])
+		s = s[:i]
+	}
+	start, _ = strconv.Atoi(s)
+	return start, count
+}
+
+// The colors of diffs: backgrounds of added, removed and hunk header lines,
+// and the color of line numbers and headers
+var (
+	diffadded   = color.NRGBA{220, 255, 228, 255}
+	diffremoved = color.NRGBA{255, 224, 224, 255}
+	diffhunk    = color.NRGBA{236, 242, 255, 255}
+	diffgutter  = color.NRGBA{128, 128, 128, 255}
+)
+
+// AbsDiff shows a unified diff in monospaced text, within the width, with the baseline
+// of the first line at (x, y), returning the baseline of the line after the last.
+// Lines too long for the width are cut off.
+func (c *Canvas) AbsDiff(x, y, size, width float32, diff string, textcolor color.NRGBA) float32 {
+	c.record(DrawCall{Op: "diff", W: width, Size: size, Text: diff, Color: textcolor}, x, y)
+	rec, sem, style := c.Recorder, c.Semantic, c.textstyle
+	c.Recorder, c.Semantic = nil, false
+	defer func() { c.Recorder, c.Semantic, c.textstyle = rec, sem, style }()
+
+	lines := difflines(diff)
+	last := 0
+	for _, l := range lines {
+		if l.old > last {
+			last = l.old
+		}
+		if l.new > last {
+			last = l.new
+		}
+	}
+	c.textstyle = font.Font{Variant: "Mono"}
+	pad := size * 0.5
+	numw := float32(c.measuretext(strings.Repeat("0", len(strconv.Itoa(last))), size).X) + pad
+	markw := float32(c.measuretext("+ ", size).X)
+	lh := size * 1.5
+	top := y - size*1.1
+
+	clip := c.AbsClipRect(x, top, width, lh*float32(len(lines)))
+	for i, l := range lines {
+		base := y + lh*float32(i)
+		var bg color.NRGBA
+		switch l.kind {
+		case '+':
+			bg = diffadded
+		case '-':
+			bg = diffremoved
+		case '@':
+			bg = diffhunk
+		}
+		if bg.A > 0 {
+			bg.A = textcolor.A
+			c.AbsRect(x, base-size*1.1, width, lh, bg)
+		}
+		gutter := diffgutter
+		gutter.A = textcolor.A
+		if l.kind == '@' {
+			c.textops(x+pad, base, size, text.Start, l.text, gutter)
+			continue
+		}
+		if l.old > 0 {
+			c.textops(x+numw, base, size, text.End, strconv.Itoa(l.old), gutter)
+		}
+		if l.new > 0 {
+			c.textops(x+2*numw, base, size, text.End, strconv.Itoa(l.new), gutter)
+		}
+		c.textops(x+2*numw+pad, base, size, text.Start, string(l.kind), textcolor)
+		c.textops(x+2*numw+pad+markw, base, size, text.Start, strings.ReplaceAll(l.text, "\t", "    "), textcolor)
+	}
+	EndClip(clip)
+	end := y + lh*float32(len(lines))
+	c.Semantic = sem
+	c.semantic("text", diff, x, top, width, end-top)
+	return end
+}
+
+// Diff shows a unified diff using percentage-based measures, as AbsDiff,
+// returning the baseline of the line after the last
+func (c *Canvas) Diff(x, y, size, width float32, diff string, textcolor color.NRGBA) float32 {
+	x, y = dimen(x, y, c.Width, c.Height)
+	end := c.AbsDiff(x, y, pct(size, c.Width), pct(width, c.Width), diff, textcolor)
+	return 100 - 100*end/c.Height
+}
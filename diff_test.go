@@ -0,0 +1,73 @@
+package giocanvas
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	diff := `--- a/config.yaml
++++ b/config.yaml
+@@ -10,4 +10,4 @@ server:
+ port: 8080
+-timeout: 30s
++timeout: 90s
+ 	debug: false
+\ No newline at end of file
+`
+	want := []diffline{
+		{kind: '@', text: "@@ -10,4 +10,4 @@ server:"},
+		{kind: ' ', old: 10, new: 10, text: "port: 8080"},
+		{kind: '-', old: 11, text: "timeout: 30s"},
+		{kind: '+', new: 11, text: "timeout: 90s"},
+		{kind: ' ', old: 12, new: 12, text: "\tdebug: false"},
+	}
+	got := difflines(diff)
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// lines of hunks that look like file headers, and a second file
+	diff2 := `--- a/notes.txt
++++ b/notes.txt
+@@ -1,3 +1,2 @@
+--- comment
++++x
+ kept
+-gone
+--- a/other.txt
++++ b/other.txt
+@@ -5 +5 @@
+-old
++new
+`
+	want2 := []diffline{
+		{kind: '@', text: "@@ -1,3 +1,2 @@"},
+		{kind: '-', old: 1, text: "-- comment"},
+		{kind: '+', new: 1, text: "++x"},
+		{kind: ' ', old: 2, new: 2, text: "kept"},
+		{kind: '-', old: 3, text: "gone"},
+		{kind: '@', text: "@@ -5 +5 @@"},
+		{kind: '-', old: 5, text: "old"},
+		{kind: '+', new: 5, text: "new"},
+	}
+	if got := difflines(diff2); len(got) != len(want2) {
+		t.Errorf("got %+v, want %+v", got, want2)
+	} else {
+		for i := range want2 {
+			if got[i] != want2[i] {
+				t.Errorf("second diff, line %d: got %+v, want %+v", i, got[i], want2[i])
+			}
+		}
+	}
+
+	c, log := NewRecordingCanvas(400, 400)
+	if end := c.AbsDiff(10, 20, 10, 300, diff, c.TextColor); end != 20+5*15 {
+		t.Errorf("next baseline %v, want %v", end, 20+5*15)
+	}
+	if len(log.Calls) != 1 || len(log.Find("diff")) != 1 {
+		t.Errorf("recorded %v, want one diff call", log.Calls)
+	}
+}
@@ -0,0 +1,98 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+)
+
+// Elliptical arcs, as SVG paths draw them: from the current point to an end point,
+// around an ellipse of radii rx and ry turned by a rotation, with flags choosing
+// which of the four possible arcs is drawn
+
+// ellipticalarc returns the cubic bezier curves (cx1, cy1, cx2, cy2, x, y) of the arc from
+// (x0, y0) to (x1, y1), in absolute coordinates, following SVG: phi turns the ellipse
+// clockwise (radians), large chooses the arc of more than 180 degrees, and sweep the arc
+// drawn clockwise. Radii too small to reach the end are enlarged; a zero radius makes a line.
+func ellipticalarc(x0, y0, rx, ry float32, phi float64, large, sweep bool, x1, y1 float32) [][6]float32 {
+	if x0 == x1 && y0 == y1 {
+		return nil
+	}
+	if rx == 0 || ry == 0 {
+		return [][6]float32{{x0, y0, x1, y1, x1, y1}}
+	}
+	// center parameterization (SVG implementation notes, F.6.5)
+	sin, cos := math.Sincos(phi)
+	a, b := math.Abs(float64(rx)), math.Abs(float64(ry))
+	dx, dy := float64(x0-x1)/2, float64(y0-y1)/2
+	px, py := cos*dx+sin*dy, -sin*dx+cos*dy
+	if l := px*px/(a*a) + py*py/(b*b); l > 1 {
+		a, b = a*math.Sqrt(l), b*math.Sqrt(l)
+	}
+	num := a*a*b*b - a*a*py*py - b*b*px*px
+	den := a*a*py*py + b*b*px*px
+	k := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		k = -k
+	}
+	cpx, cpy := k*a*py/b, -k*b*px/a
+	cx := cos*cpx - sin*cpy + float64(x0+x1)/2
+	cy := sin*cpx + cos*cpy + float64(y0+y1)/2
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (px-cpx)/a, (py-cpy)/b)
+	delta := angle((px-cpx)/a, (py-cpy)/b, (-px-cpx)/a, (-py-cpy)/b)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+	// quarters or less, each a cubic curve
+	n := int(math.Ceil(math.Abs(delta)/(math.Pi/2) - 1e-9))
+	step := delta / float64(n)
+	t := 4.0 / 3 * math.Tan(step/4)
+	point := func(th float64) (float64, float64, float64, float64) {
+		s, c := math.Sincos(th)
+		ex, ey := a*c, b*s  // on the ellipse
+		tx, ty := -a*s, b*c // its tangent
+		return cx + cos*ex - sin*ey, cy + sin*ex + cos*ey, cos*tx - sin*ty, sin*tx + cos*ty
+	}
+	curves := make([][6]float32, n)
+	sx, sy, stx, sty := point(theta)
+	for i := range curves {
+		ex, ey, etx, ety := point(theta + step*float64(i+1))
+		curves[i] = [6]float32{float32(sx + t*stx), float32(sy + t*sty), float32(ex - t*etx), float32(ey - t*ety), float32(ex), float32(ey)}
+		sx, sy, stx, sty = ex, ey, etx, ety
+	}
+	curves[n-1][4], curves[n-1][5] = x1, y1
+	return curves
+}
+
+// AbsEllipticalArcTo strokes the elliptical arc from (x0, y0) to (x1, y1), size wide, as
+// SVG paths draw it: the ellipse of radii (rx, ry) is turned by rotation (radians, as
+// AbsEllipticalArc), large chooses the arc of more than 180 degrees, and sweep the arc
+// drawn clockwise
+func (c *Canvas) AbsEllipticalArcTo(x0, y0, rx, ry float32, rotation float64, large, sweep bool, x1, y1, size float32, strokecolor color.NRGBA) {
+	curves := ellipticalarc(x0, y0, rx, ry, rotation, large, sweep, x1, y1)
+	if curves == nil {
+		return
+	}
+	c.record(DrawCall{Op: "ellipticalarcto", W: rx, H: ry, Size: size, A1: rotation, Color: strokecolor}, x0, y0, x1, y1)
+	px, py := []float32{x0}, []float32{y0}
+	sx, sy := x0, y0
+	for _, b := range curves {
+		fx, fy := flattencubic(sx, sy, b[0], b[1], b[2], b[3], b[4], b[5])
+		px, py = append(px, fx[1:]...), append(py, fy[1:]...)
+		sx, sy = b[4], b[5]
+	}
+	c.strokepoints(px, py, size, StrokeStyle{}, strokecolor)
+}
+
+// EllipticalArcTo strokes the elliptical arc from (x0, y0) to (x1, y1), as AbsEllipticalArcTo,
+// using percentage-based measures. The flags keep their meaning on screen, so SVG artwork
+// is reproduced by converting its coordinates alone.
+func (c *Canvas) EllipticalArcTo(x0, y0, rx, ry float32, rotation float64, large, sweep bool, x1, y1, size float32, strokecolor color.NRGBA) {
+	x0, y0 = dimen(x0, y0, c.Width, c.Height)
+	x1, y1 = dimen(x1, y1, c.Width, c.Height)
+	c.AbsEllipticalArcTo(x0, y0, pct(rx, c.Width), pct(ry, c.Height), rotation, large, sweep, x1, y1, pct(size, c.Width), strokecolor)
+}
@@ -0,0 +1,60 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+
+	"gioui.org/f32"
+)
+
+func TestEllipticalArcTo(t *testing.T) {
+	// the four arcs between two points of a circle of radius 10 centered at either (10, 0) or (0, 10)
+	for _, tc := range []struct {
+		large, sweep bool
+		center       f32.Point
+		quarters     int
+	}{
+		{false, true, f32.Pt(0, 10), 1},
+		{false, false, f32.Pt(10, 0), 1},
+		{true, true, f32.Pt(10, 0), 3},
+		{true, false, f32.Pt(0, 10), 3},
+	} {
+		curves := ellipticalarc(0, 0, 10, 10, 0, tc.large, tc.sweep, 10, 10)
+		if len(curves) != tc.quarters {
+			t.Errorf("large %v sweep %v: %d curves, want %d", tc.large, tc.sweep, len(curves), tc.quarters)
+			continue
+		}
+		start := f32.Pt(0, 0)
+		for i, b := range curves {
+			// the middle and end of each curve are on the circle
+			mid := start.Add(f32.Pt(b[0], b[1]).Mul(3)).Add(f32.Pt(b[2], b[3]).Mul(3)).Add(f32.Pt(b[4], b[5])).Mul(0.125)
+			end := f32.Pt(b[4], b[5])
+			for _, p := range []f32.Point{mid, end} {
+				if d := p.Sub(tc.center); math.Abs(math.Hypot(float64(d.X), float64(d.Y))-10) > 0.01 {
+					t.Errorf("large %v sweep %v: curve %d passes %v, off the circle about %v", tc.large, tc.sweep, i, p, tc.center)
+				}
+			}
+			start = end
+		}
+		if last := curves[len(curves)-1]; last[4] != 10 || last[5] != 10 {
+			t.Errorf("large %v sweep %v: ends at %v, want (10, 10)", tc.large, tc.sweep, last[4:])
+		}
+	}
+	// radii too small are enlarged: a half circle between the points
+	if curves := ellipticalarc(0, 0, 1, 1, 0, false, true, 20, 0); len(curves) != 2 || math.Abs(float64(curves[0][5]+10)) > 1e-3 {
+		t.Errorf("enlarged arc %v, want two quarters through (10, -10)", curves)
+	}
+
+	c, log := NewRecordingCanvas(200, 200)
+	var p Path
+	p.MoveTo(10, 10)
+	p.EllipticalArcTo(20, 10, math.Pi/6, true, false, 50, 50)
+	p.Stroke(c, 0.5, c.TextColor)
+	c.EllipticalArcTo(10, 10, 20, 10, math.Pi/6, true, false, 50, 50, 0.5, c.TextColor)
+	if sub := p.flatten(c); len(sub) != 1 || len(sub[0][0]) < 10 {
+		t.Errorf("flattened arc %v", sub)
+	}
+	if len(log.Find("ellipticalarcto")) != 1 {
+		t.Errorf("recorded %v, want an elliptical arc", log.Calls)
+	}
+}
@@ -0,0 +1,92 @@
+package giocanvas
+
+import (
+	"strings"
+	"sync"
+)
+
+// Emoji: :name: shortcodes in text, replaced by the emoji they stand for.
+// The Go fonts have no emoji; register a font that does (such as Noto Emoji)
+// with RegisterFont, and its glyphs are used for them.
+
+// emoji maps shortcodes to emoji
+var emoji = struct {
+	sync.RWMutex
+	codes map[string]string
+}{codes: map[string]string{
+	"+1":               "\U0001F44D",
+	"-1":               "\U0001F44E",
+	"bulb":             "\U0001F4A1",
+	"calendar":         "\U0001F4C5",
+	"chart":            "\U0001F4C8",
+	"check":            "✅",
+	"clap":             "\U0001F44F",
+	"coffee":           "☕",
+	"cry":              "\U0001F622",
+	"fire":             "\U0001F525",
+	"grin":             "\U0001F601",
+	"heart":            "❤️",
+	"joy":              "\U0001F602",
+	"laughing":         "\U0001F606",
+	"lock":             "\U0001F512",
+	"memo":             "\U0001F4DD",
+	"question":         "❓",
+	"rocket":           "\U0001F680",
+	"smile":            "\U0001F604",
+	"smiley":           "\U0001F603",
+	"star":             "⭐",
+	"sunglasses":       "\U0001F60E",
+	"tada":             "\U0001F389",
+	"thinking":         "\U0001F914",
+	"thumbsdown":       "\U0001F44E",
+	"thumbsup":         "\U0001F44D",
+	"warning":          "⚠️",
+	"wave":             "\U0001F44B",
+	"wink":             "\U0001F609",
+	"x":                "❌",
+	"zap":              "⚡",
+	"white_check_mark": "✅",
+}}
+
+// RegisterEmoji adds shortcodes (names without colons) and the text they stand for,
+// replacing any of the same name
+func RegisterEmoji(codes map[string]string) {
+	emoji.Lock()
+	for name, s := range codes {
+		emoji.codes[name] = s
+	}
+	emoji.Unlock()
+}
+
+// Shortcodes returns s with its :name: shortcodes replaced by their emoji;
+// unknown shortcodes are left as they are
+func Shortcodes(s string) string {
+	if strings.IndexByte(s, ':') < 0 {
+		return s
+	}
+	emoji.RLock()
+	defer emoji.RUnlock()
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, ':')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(s[i+1:], ':')
+		if j < 0 {
+			break
+		}
+		name := s[i+1 : i+1+j]
+		if e, ok := emoji.codes[name]; ok && !strings.ContainsAny(name, " \t\n") {
+			b.WriteString(s[:i])
+			b.WriteString(e)
+			s = s[i+j+2:]
+			continue
+		}
+		// not a shortcode: keep the first colon, and look again from the second
+		b.WriteString(s[:i+1])
+		s = s[i+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package giocanvas
+
+import "testing"
+
+func TestShortcodes(t *testing.T) {
+	RegisterEmoji(map[string]string{"gopher": "G"})
+	tests := []struct{ in, out string }{
+		{"plain", "plain"},
+		{"hi :smile:", "hi \U0001F604"},
+		{":gopher::gopher:", "GG"},
+		{"time 10:30:00", "time 10:30:00"},
+		{"a :nope: :tada:", "a :nope: \U0001F389"},
+		{"ratio 1:2 :+1:", "ratio 1:2 \U0001F44D"},
+		{"unclosed :smile", "unclosed :smile"},
+	}
+	for _, test := range tests {
+		if got := Shortcodes(test.in); got != test.out {
+			t.Errorf("%q: got %q, want %q", test.in, got, test.out)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package giocanvas
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/op/clip"
+)
+
+// Input events, using percentage-based coordinates
+
+// PointerEvent is a pointer event with its position in percentage-based coordinates.
+// Pressure ranges from 0 (no contact) to 1 (full pressure), TiltX and TiltY are the
+// stylus angles (degrees) from the vertical.
+// Gio does not yet report stylus pressure or tilt: until it does, Pressure is 1
+// while a button or finger is down and 0 otherwise, and the tilt angles are zero.
+type PointerEvent struct {
+	Type         pointer.Type
+	Source       pointer.Source
+	ID           pointer.ID
+	X, Y         float32
+	Pressure     float32
+	TiltX, TiltY float32
+	Buttons      pointer.Buttons
+	Modifiers    key.Modifiers
+	Time         time.Duration
+}
+
+// PointerInput registers the whole canvas as a pointer input area for tag,
+// receiving presses, drags, releases and moves
+func (c *Canvas) PointerInput(tag event.Tag) {
+	ops := c.Context.Ops
+	stack := clip.Rect{Max: image.Pt(int(c.Width), int(c.Height))}.Push(ops)
+	pointer.InputOp{
+		Tag:   tag,
+		Types: pointer.Press | pointer.Drag | pointer.Release | pointer.Move | pointer.Cancel,
+	}.Add(ops)
+	stack.Pop()
+}
+
+// PointerEvents returns the pointer events queued for tag, in percentage-based coordinates
+func (c *Canvas) PointerEvents(q event.Queue, tag event.Tag) []PointerEvent {
+	var pe []PointerEvent
+	if q == nil {
+		return pe
+	}
+	for _, ev := range q.Events(tag) {
+		if p, ok := ev.(pointer.Event); ok {
+			pe = append(pe, c.PointerEvent(p))
+		}
+	}
+	return pe
+}
+
+// PointerEvent converts a Gio pointer event to percentage-based coordinates
+func (c *Canvas) PointerEvent(p pointer.Event) PointerEvent {
+	x, y := c.PctCoord(p.Position.X, p.Position.Y)
+	var pressure float32
+	switch p.Type {
+	case pointer.Press, pointer.Drag:
+		pressure = 1
+	case pointer.Move, pointer.Scroll:
+		if p.Buttons != 0 {
+			pressure = 1
+		}
+	}
+	return PointerEvent{
+		Type:      p.Type,
+		Source:    p.Source,
+		ID:        p.PointerID,
+		X:         x,
+		Y:         y,
+		Pressure:  pressure,
+		Buttons:   p.Buttons,
+		Modifiers: p.Modifiers,
+		Time:      p.Time,
+	}
+}
+
+// TouchPhase is the phase of a touch point
+type TouchPhase int
+
+// Touch phases
+const (
+	TouchBegin TouchPhase = iota
+	TouchMove
+	TouchEnd
+	TouchCancel
+)
+
+// TouchPoint is a single touch contact, using percentage-based coordinates
+type TouchPoint struct {
+	ID    pointer.ID
+	X, Y  float32
+	Phase TouchPhase
+}
+
+// Touches tracks the active touch points across frames
+type Touches struct {
+	points map[pointer.ID]TouchPoint
+	order  []pointer.ID
+}
+
+// Update applies pointer events from touch sources, returning the touch points that changed.
+// Ended and cancelled points are returned once, then removed from the active set.
+func (t *Touches) Update(events []PointerEvent) []TouchPoint {
+	if t.points == nil {
+		t.points = make(map[pointer.ID]TouchPoint)
+	}
+	var changed []TouchPoint
+	for _, e := range events {
+		if e.Source != pointer.Touch {
+			continue
+		}
+		tp := TouchPoint{ID: e.ID, X: e.X, Y: e.Y}
+		switch e.Type {
+		case pointer.Press:
+			tp.Phase = TouchBegin
+			if _, ok := t.points[e.ID]; !ok {
+				t.order = append(t.order, e.ID)
+			}
+			t.points[e.ID] = tp
+		case pointer.Drag, pointer.Move:
+			if _, ok := t.points[e.ID]; !ok {
+				continue
+			}
+			tp.Phase = TouchMove
+			t.points[e.ID] = tp
+		case pointer.Release, pointer.Cancel:
+			tp.Phase = TouchEnd
+			if e.Type == pointer.Cancel {
+				tp.Phase = TouchCancel
+			}
+			t.remove(e.ID)
+		default:
+			continue
+		}
+		changed = append(changed, tp)
+	}
+	return changed
+}
+
+// remove drops a point from the active set
+func (t *Touches) remove(id pointer.ID) {
+	delete(t.points, id)
+	for i, o := range t.order {
+		if o == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Active returns the current touch points, in the order they began
+func (t *Touches) Active() []TouchPoint {
+	tp := make([]TouchPoint, 0, len(t.order))
+	for _, id := range t.order {
+		tp = append(tp, t.points[id])
+	}
+	return tp
+}
+
+// TwoFinger compares two pairs of touch points (before and after),
+// returning the movement of their midpoint (dx, dy), the change in their
+// distance as a scale factor, and the change in their angle (radians).
+func TwoFinger(a0, b0, a1, b1 TouchPoint) (dx, dy, scale, angle float32) {
+	mx0, my0 := (a0.X+b0.X)/2, (a0.Y+b0.Y)/2
+	mx1, my1 := (a1.X+b1.X)/2, (a1.Y+b1.Y)/2
+	d0 := math.Hypot(float64(b0.X-a0.X), float64(b0.Y-a0.Y))
+	d1 := math.Hypot(float64(b1.X-a1.X), float64(b1.Y-a1.Y))
+	scale = 1
+	if d0 > 0 {
+		scale = float32(d1 / d0)
+	}
+	t0 := math.Atan2(float64(b0.Y-a0.Y), float64(b0.X-a0.X))
+	t1 := math.Atan2(float64(b1.Y-a1.Y), float64(b1.X-a1.X))
+	return mx1 - mx0, my1 - my0, scale, float32(t1 - t0)
+}
@@ -0,0 +1,128 @@
+package giocanvas
+
+import (
+	"image/color"
+	"os"
+
+	"gioui.org/font"
+	"gioui.org/font/gofont"
+	"gioui.org/font/opentype"
+	"gioui.org/text"
+	"gioui.org/widget/material"
+)
+
+// Fonts: adding fonts, such as icon fonts, to those text is drawn with, and
+// choosing among the weights and styles of a family by logical names
+
+// fonts are the fonts added by RegisterFont
+var fonts []font.FontFace
+
+// RegisterFont adds the font in data (TrueType or OpenType) to those text may be
+// drawn with, as the typeface, replacing any font added before as that typeface
+// in the same weight and style; a canvas uses it when its Typeface is set to that name.
+// The weight and style (italic or not) are those of the font, so the fonts of a
+// family, such as its regular, bold and italic, are each added as the same typeface.
+func RegisterFont(typeface string, data []byte) error {
+	face, err := opentype.Parse(data)
+	if err != nil {
+		return err
+	}
+	textcache.Lock()
+	defer textcache.Unlock()
+	f := face.Font()
+	ff := font.FontFace{Font: font.Font{Typeface: font.Typeface(typeface), Weight: f.Weight, Style: f.Style}, Face: face}
+	replaced := false
+	for i := range fonts {
+		if fonts[i].Font == ff.Font {
+			fonts[i], replaced = ff, true
+		}
+	}
+	if !replaced {
+		fonts = append(fonts, ff)
+	}
+	textcache.theme = material.NewTheme(append(gofont.Collection(), fonts...))
+	textcache.layouts = nil
+	return nil
+}
+
+// LoadFont adds the font in data (TrueType or OpenType) as the typeface name,
+// as RegisterFont, and draws the canvas's text with it from then on
+func (c *Canvas) LoadFont(name string, data []byte) error {
+	if err := RegisterFont(name, data); err != nil {
+		return err
+	}
+	c.Typeface = name
+	return nil
+}
+
+// LoadFontFile loads the font in the named file as the typeface name, as LoadFont
+func (c *Canvas) LoadFontFile(name, filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return c.LoadFont(name, data)
+}
+
+// Weight is the weight of a font
+type Weight int
+
+// The weights: the family's regular, light and bold fonts, or the nearest it has
+const (
+	RegularWeight Weight = iota
+	LightWeight
+	BoldWeight
+)
+
+// Font is a font of a family: a typeface added by RegisterFont ("" for the Go fonts),
+// its weight, and whether it is italic
+type Font struct {
+	Typeface string
+	Weight   Weight
+	Italic   bool
+}
+
+// DefineFont gives the font a logical name, such as "heading" or "quote",
+// by which SetFont chooses it
+func (c *Canvas) DefineFont(name string, f Font) {
+	if c.Fonts == nil {
+		c.Fonts = map[string]Font{}
+	}
+	c.Fonts[name] = f
+}
+
+// SetFont chooses the font text is drawn in from then on by its logical name, reporting
+// whether the name is defined; the name "" chooses the regular Go font
+func (c *Canvas) SetFont(name string) bool {
+	f, ok := c.Fonts[name]
+	if !ok && name != "" {
+		return false
+	}
+	c.Typeface = f.Typeface
+	c.textstyle = font.Font{}
+	switch f.Weight {
+	case LightWeight:
+		c.textstyle.Weight = font.Light
+	case BoldWeight:
+		c.textstyle.Weight = font.Bold
+	}
+	if f.Italic {
+		c.textstyle.Style = font.Italic
+	}
+	return true
+}
+
+// AbsIcon draws the character r of the typeface (such as an icon font added by
+// RegisterFont), centered at x, baseline at y
+func (c *Canvas) AbsIcon(x, y, size float32, typeface string, r rune, fillcolor color.NRGBA) {
+	tf := c.Typeface
+	c.Typeface = typeface
+	c.textops(x, y, size, text.Middle, string(r), fillcolor)
+	c.Typeface = tf
+}
+
+// Icon draws the character r of the typeface, as AbsIcon, using percentage-based measures
+func (c *Canvas) Icon(x, y, size float32, typeface string, r rune, fillcolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsIcon(x, y, pct(size, c.Width), typeface, r, fillcolor)
+}
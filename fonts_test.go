@@ -0,0 +1,59 @@
+package giocanvas
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+func TestLoadFont(t *testing.T) {
+	c, _ := NewRecordingCanvas(400, 300)
+	if err := c.LoadFont("testmono", []byte("not a font")); err == nil {
+		t.Error("loaded a bad font")
+	}
+	if c.Typeface != "" {
+		t.Errorf("typeface %q after a bad font", c.Typeface)
+	}
+	before := len(fonts)
+	for i := 0; i < 2; i++ {
+		if err := c.LoadFont("testmono", gomono.TTF); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.Typeface != "testmono" {
+		t.Errorf("typeface %q, want testmono", c.Typeface)
+	}
+	if n := len(fonts) - before; n != 1 {
+		t.Errorf("loading a font twice added %d fonts, want 1", n)
+	}
+	if w, mono := c.TextWidth("iiii", 2), c.TextWidth("mmmm", 2); w != mono {
+		t.Errorf("widths of iiii and mmmm in a monospaced font: %v and %v", w, mono)
+	}
+	if err := c.LoadFontFile("testmono", "testdata/nofont.ttf"); err == nil {
+		t.Error("loaded a missing font file")
+	}
+}
+
+func TestSetFont(t *testing.T) {
+	c, _ := NewRecordingCanvas(400, 300)
+	regular := c.TextWidth("Heading", 5)
+	if c.SetFont("heading") {
+		t.Error("set an undefined font")
+	}
+	c.DefineFont("heading", Font{Weight: BoldWeight})
+	c.DefineFont("quote", Font{Italic: true})
+	if !c.SetFont("heading") {
+		t.Fatal("heading not set")
+	}
+	if bold := c.TextWidth("Heading", 5); bold <= regular {
+		t.Errorf("bold width %v, regular %v", bold, regular)
+	}
+	c.SetFont("quote")
+	if c.textstyle.Weight != 0 || c.textstyle.Style == 0 {
+		t.Errorf("quote style %+v", c.textstyle)
+	}
+	c.SetFont("")
+	if w := c.TextWidth("Heading", 5); w != regular {
+		t.Errorf("width after reset %v, want %v", w, regular)
+	}
+}
@@ -0,0 +1,158 @@
+package giocanvas
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale-aware formatting of numbers, percentages, currency and dates
+
+// Locale describes the conventions for formatting numbers and dates.
+// The zero value formats like the fmt package: "." decimal point, no grouping.
+type Locale struct {
+	Name           string
+	Decimal, Group string // decimal point and thousands separator
+	Currency       string // currency symbol
+	CurrencyAfter  bool   // place the currency symbol after the amount
+	PercentSpace   bool   // separate the percent sign with a space
+	DateLayout     string // time.Format layout for dates
+	MonthLayout    string // time.Format layout for month ticks
+	Months         []string
+}
+
+var englishMonths = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+// Locales are the predefined locales, by name
+var Locales = map[string]Locale{
+	"en-US": {Name: "en-US", Decimal: ".", Group: ",", Currency: "$", DateLayout: "Jan 2, 2006", MonthLayout: "Jan 2006"},
+	"en-GB": {Name: "en-GB", Decimal: ".", Group: ",", Currency: "£", DateLayout: "2 Jan 2006", MonthLayout: "Jan 2006"},
+	"de-DE": {Name: "de-DE", Decimal: ",", Group: ".", Currency: "€", CurrencyAfter: true, PercentSpace: true, DateLayout: "2.1.2006", MonthLayout: "Jan 2006",
+		Months: []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}},
+	"fr-FR": {Name: "fr-FR", Decimal: ",", Group: " ", Currency: "€", CurrencyAfter: true, PercentSpace: true, DateLayout: "2 January 2006", MonthLayout: "Jan 2006",
+		Months: []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}},
+	"es-ES": {Name: "es-ES", Decimal: ",", Group: ".", Currency: "€", CurrencyAfter: true, PercentSpace: true, DateLayout: "2 January 2006", MonthLayout: "Jan 2006",
+		Months: []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}},
+	"ja-JP": {Name: "ja-JP", Decimal: ".", Group: ",", Currency: "¥", DateLayout: "2006/01/02", MonthLayout: "2006/01"},
+	"hi-IN": {Name: "hi-IN", Decimal: ".", Group: ",", Currency: "₹", DateLayout: "2/1/2006", MonthLayout: "Jan 2006"},
+}
+
+// LookupLocale returns the named locale (for example "de-DE"), or the zero Locale if not found
+func LookupLocale(name string) Locale {
+	return Locales[name]
+}
+
+// group inserts the thousands separator into a string of digits
+func (l Locale) group(digits string) string {
+	if len(l.Group) == 0 || len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// localize rewrites the first number in s using the locale's separators
+func (l Locale) localize(s string) string {
+	start := strings.IndexAny(s, "0123456789")
+	if start < 0 {
+		return s
+	}
+	end := start
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	whole := l.group(s[start:end])
+	rest := s[end:]
+	if len(rest) > 1 && rest[0] == '.' && rest[1] >= '0' && rest[1] <= '9' {
+		dec := l.Decimal
+		if len(dec) == 0 {
+			dec = "."
+		}
+		rest = dec + rest[1:]
+	}
+	return s[:start] + whole + rest
+}
+
+// Number formats v with prec decimal places
+func (l Locale) Number(v float64, prec int) string {
+	return l.localize(strconv.FormatFloat(v, 'f', prec, 64))
+}
+
+// Format formats v using a fmt verb (for example "%.2f"), with the locale's separators
+func (l Locale) Format(format string, v float64) string {
+	return l.localize(fmt.Sprintf(format, v))
+}
+
+// Percent formats v (a percentage: 0-100) with prec decimal places
+func (l Locale) Percent(v float64, prec int) string {
+	if l.PercentSpace {
+		return l.Number(v, prec) + " %"
+	}
+	return l.Number(v, prec) + "%"
+}
+
+// Money formats a currency amount with prec decimal places
+func (l Locale) Money(v float64, prec int) string {
+	s := l.Number(math.Abs(v), prec)
+	if l.CurrencyAfter {
+		s = s + " " + l.Currency
+	} else {
+		s = l.Currency + s
+	}
+	if v < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// months replaces English month names with the locale's
+func (l Locale) months(s string) string {
+	if len(l.Months) != 12 {
+		return s
+	}
+	for i, m := range englishMonths {
+		if strings.Contains(s, m) {
+			return strings.Replace(s, m, l.Months[i], 1)
+		}
+	}
+	for i, m := range englishMonths {
+		if strings.Contains(s, m[:3]) {
+			short := []rune(l.Months[i])
+			if len(short) > 4 {
+				short = short[:3]
+			}
+			return strings.Replace(s, m[:3], string(short), 1)
+		}
+	}
+	return s
+}
+
+// Date formats a date, using the locale's layout
+func (l Locale) Date(t time.Time) string {
+	layout := l.DateLayout
+	if len(layout) == 0 {
+		layout = "2006-01-02"
+	}
+	return l.months(t.Format(layout))
+}
+
+// Month formats a date as a month, as used on axis ticks
+func (l Locale) Month(t time.Time) string {
+	layout := l.MonthLayout
+	if len(layout) == 0 {
+		layout = "2006-01"
+	}
+	return l.months(t.Format(layout))
+}
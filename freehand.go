@@ -0,0 +1,48 @@
+package giocanvas
+
+import (
+	"image/color"
+)
+
+// StrokePoint is a point on a freehand stroke, in percentage-based coordinates,
+// with the pen pressure (0-1) at that point
+type StrokePoint struct {
+	X, Y, Pressure float32
+}
+
+// minpressure is the fraction of the stroke width used at zero pressure
+const minpressure = 0.25
+
+// pressurewidth scales a stroke width by pressure
+func pressurewidth(size, pressure float32) float32 {
+	if pressure < 0 {
+		pressure = 0
+	}
+	if pressure > 1 {
+		pressure = 1
+	}
+	return size * (minpressure + (1-minpressure)*pressure)
+}
+
+// Freehand draws a freehand stroke through points, using percentage-based measures.
+// The stroke width varies with the pressure at each point, from a quarter of size
+// at no pressure, to size at full pressure.
+func (c *Canvas) Freehand(points []StrokePoint, size float32, strokecolor color.NRGBA) {
+	x, y := make([]float32, len(points)), make([]float32, len(points))
+	for i, p := range points {
+		x[i], y[i] = dimen(p.X, p.Y, c.Width, c.Height)
+	}
+	size = pct(size, c.Width)
+	switch len(points) {
+	case 0:
+		return
+	case 1:
+		c.AbsCircle(x[0], y[0], pressurewidth(size, points[0].Pressure)/2, strokecolor)
+		return
+	}
+	for i := 1; i < len(points); i++ {
+		w := pressurewidth(size, (points[i-1].Pressure+points[i].Pressure)/2)
+		c.AbsLine(x[i-1], y[i-1], x[i], y[i], w, strokecolor)
+		c.AbsCircle(x[i], y[i], w/2, strokecolor) // round joins
+	}
+}
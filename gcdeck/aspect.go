@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/deck"
+)
+
+// Slide shapes: a deck's canvas element, and width and height (or aspect)
+// attributes on its slides, set the shapes of slides, which are letterboxed
+// within the window
+
+// aspects are the aspect ratios of the slides of the deck shown (0 for the deck's own)
+var aspects []float64
+
+// canvassizes are the sizes declared in deck markup
+type canvassizes struct {
+	Canvas struct {
+		Width  float64 `xml:"width,attr"`
+		Height float64 `xml:"height,attr"`
+	} `xml:"canvas"`
+	Slide []struct {
+		Width  float64 `xml:"width,attr"`
+		Height float64 `xml:"height,attr"`
+		Aspect string  `xml:"aspect,attr"`
+	} `xml:"slide"`
+}
+
+// parseaspect parses an aspect ratio: a number, or width:height, such as 16:9
+func parseaspect(s string) (float64, error) {
+	var a float64
+	var err error
+	if i := strings.Index(s, ":"); i >= 0 {
+		var fw, fh float64
+		if fw, err = strconv.ParseFloat(strings.TrimSpace(s[:i]), 64); err == nil {
+			if fh, err = strconv.ParseFloat(strings.TrimSpace(s[i+1:]), 64); err == nil && fh != 0 {
+				a = fw / fh
+			}
+		}
+	} else {
+		a, err = strconv.ParseFloat(strings.TrimSpace(s), 64)
+	}
+	if err != nil || a <= 0 {
+		return 0, fmt.Errorf("bad aspect ratio %q", s)
+	}
+	return a, nil
+}
+
+// deckmeta is what deck markup says beyond what the deck package reads:
+// the aspect ratios of slides (nil if none declare one), their entry effects,
+// the graphic attributes of their elements, and the moves of their images
+type deckmeta struct {
+	aspects  []float64
+	entries  []slideentries
+	graphics []slidegraphics
+	panzooms []slidepanzooms
+}
+
+// readslides reads the deck in the file ("-" for standard input), with its
+// metadata. The deck's canvas is w wide, and h high, or the shape of its canvas
+// element, if it has one.
+func readslides(filename string, w, h float32) (deck.Deck, deckmeta, error) {
+	var meta deckmeta
+	var markup []byte
+	var err error
+	switch {
+	case isdecksh(filename):
+		markup, err = decksh(filename)
+	case filename == "-":
+		markup, err = io.ReadAll(os.Stdin)
+	default:
+		markup, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return deck.Deck{}, meta, err
+	}
+	d, err := deck.ReadDeck(io.NopCloser(bytes.NewReader(markup)), int(w), int(h))
+	d.Canvas.Width, d.Canvas.Height = int(w), int(h)
+	if err != nil {
+		return d, meta, err
+	}
+	var sizes canvassizes
+	if err := xml.Unmarshal(markup, &sizes); err != nil {
+		return d, meta, err
+	}
+	if cw, ch := sizes.Canvas.Width, sizes.Canvas.Height; cw > 0 && ch > 0 {
+		d.Canvas.Height = int(float64(w)*ch/cw + 0.5)
+	}
+	for i, s := range sizes.Slide {
+		var a float64
+		switch {
+		case s.Aspect != "":
+			if a, err = parseaspect(s.Aspect); err != nil {
+				return d, meta, fmt.Errorf("slide %d: %v", i+1, err)
+			}
+		case s.Width > 0 && s.Height > 0:
+			a = s.Width / s.Height
+		}
+		if a > 0 && meta.aspects == nil {
+			meta.aspects = make([]float64, len(sizes.Slide))
+		}
+		if a > 0 {
+			meta.aspects[i] = a
+		}
+	}
+	if meta.entries, err = parseentries(markup); err != nil {
+		return d, meta, err
+	}
+	if meta.graphics, err = parsegraphics(markup); err != nil {
+		return d, meta, err
+	}
+	meta.panzooms, err = parsepanzooms(markup)
+	return d, meta, err
+}
+
+// slideaspect returns the aspect ratio of slide n of the deck shown
+func slideaspect(d *deck.Deck, n int) float64 {
+	if n >= 0 && n < len(aspects) && aspects[n] > 0 {
+		return aspects[n]
+	}
+	return deckaspect(d)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"image"
+
+	"gioui.org/io/system"
+	"gioui.org/op"
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Comparison: a second deck (or another version of the same one) shown to the
+// right of the first, on the same slide, for reviewing edits
+
+// comparefile names the deck to compare with, if any, and othergraphics
+// are the graphic attributes of its elements
+var comparefile string
+var othergraphics []slidegraphics
+
+// slidebg and slidefg return the background and foreground colors of slide n,
+// (or the master's), or the defaults if the deck has no such slide
+func slidebg(d *deck.Deck, n int) string {
+	if n < 0 || n >= len(d.Slide) {
+		return "white"
+	}
+	if bg := withmaster(d.Slide[n]).Bg; bg != "" {
+		return bg
+	}
+	return "white"
+}
+
+func slidefg(d *deck.Deck, n int) string {
+	if n < 0 || n >= len(d.Slide) {
+		return "black"
+	}
+	if fg := withmaster(d.Slide[n]).Fg; fg != "" {
+		return fg
+	}
+	return "black"
+}
+
+// showcompare draws slide n of the deck on the canvas, offset x to the right,
+// at the size of the canvas
+func showcompare(c *gc.Canvas, d *deck.Deck, n int, x float32) {
+	tf := graphics
+	graphics = othergraphics
+	defer func() { graphics = tf }()
+	other := gc.NewCanvas(c.Width, c.Height, system.FrameEvent{})
+	other.Locale = decklocale
+	m := op.Record(other.Context.Ops)
+	slidearea(other, slidebg(d, n), deckaspect(d))
+	showslide(other, d, n)
+	other.ClearMargins()
+	call := m.Stop()
+	stack := op.Offset(image.Pt(int(x), 0)).Push(c.Context.Ops)
+	call.Add(c.Context.Ops)
+	stack.Pop()
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Remote control: commands such as "goto 5", "next" or "reload", read a line at a
+// time from a local socket, so that other programs can drive the presentation.
+// Lines beginning with "{" are JSON-RPC 2.0 requests, with the command as the method.
+
+// controladdr is the address of the control socket, if any
+var controladdr string
+
+// control is a command from the control socket, carried out by the window's event loop
+type control struct {
+	name  string
+	args  []string
+	reply chan controlreply
+}
+
+// controlreply is the result of a command
+type controlreply struct {
+	result string
+	err    error
+}
+
+// controltimeout bounds the wait for the window to carry out a command
+const controltimeout = 5 * time.Second
+
+// controlqueue is the number of commands that may wait for the window, and
+// controlretry how often the window is asked again to take a command
+const (
+	controlqueue = 16
+	controlretry = 50 * time.Millisecond
+)
+
+// rpcrequest and rpcresponse are JSON-RPC 2.0 messages; a request without an ID
+// is a notification, which gets no response
+type rpcrequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  []interface{}   `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcresponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcerror       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcerror struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// listencontrol accepts connections on addr: a path for a Unix domain socket,
+// otherwise a TCP address on the loopback interface such as "localhost:7777".
+// Commands are sent to cmds, calling changed so that the window carries them out.
+func listencontrol(addr string, cmds chan<- control, changed func()) error {
+	network := "tcp"
+	if strings.ContainsAny(addr, "/\\") {
+		network = "unix"
+		if err := removestale(addr); err != nil {
+			return err
+		}
+	} else if err := loopback(addr); err != nil {
+		return err
+	}
+	l, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "control: %v\n", err)
+				return
+			}
+			go servecontrol(conn, cmds, changed)
+		}
+	}()
+	return nil
+}
+
+// removestale removes the socket left at path by an earlier run, refusing to remove
+// anything else, or a socket still listening
+func removestale(path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists, and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use", path)
+	}
+	return os.Remove(path)
+}
+
+// loopback reports an error unless the host of a TCP address is a loopback address
+func loopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("%s: no host; use localhost", addr)
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		if !ip.IsLoopback() {
+			return fmt.Errorf("%s: not a loopback address", addr)
+		}
+	}
+	return nil
+}
+
+// exportname returns the file for an export command: name (a bare file name, of a
+// PNG image), or the default, in the directory of the deck
+func exportname(deckfile, name, def string) (string, error) {
+	if name == "" {
+		name = def
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("export: %q is not a file name", name)
+	}
+	if !strings.EqualFold(filepath.Ext(name), ".png") {
+		return "", fmt.Errorf("export: %q is not a .png file", name)
+	}
+	dir := "."
+	if deckfile != "-" {
+		dir = filepath.Dir(deckfile)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// httprequest matches the request line of HTTP
+var httprequest = regexp.MustCompile(`^[A-Z]+ \S+ HTTP/\d`)
+
+// servecontrol reads commands from a connection, writing a reply to each. A connection
+// beginning as an HTTP request is closed, so that web pages (which may send requests
+// to the loopback interface) cannot drive the presentation.
+func servecontrol(conn net.Conn, cmds chan<- control, changed func()) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	started := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !started && httprequest.MatchString(line) {
+			return
+		}
+		started = true
+		if line[0] == '{' {
+			if r, reply := rpccall(line, cmds, changed); reply {
+				json.NewEncoder(conn).Encode(r)
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		r := runcontrol(fields[0], fields[1:], cmds, changed)
+		if r.err != nil {
+			fmt.Fprintf(conn, "error: %v\n", r.err)
+		} else {
+			fmt.Fprintf(conn, "ok %s\n", r.result)
+		}
+	}
+}
+
+// rpccall carries out a JSON-RPC request, returning the response, and whether
+// it is to be sent (it is not, for notifications)
+func rpccall(line string, cmds chan<- control, changed func()) (rpcresponse, bool) {
+	null := json.RawMessage("null")
+	var req rpcrequest
+	if err := json.Unmarshal([]byte(line), &req); err != nil {
+		return rpcresponse{JSONRPC: "2.0", Error: &rpcerror{Code: -32700, Message: err.Error()}, ID: null}, true
+	}
+	notify := req.ID == nil
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		id := req.ID
+		if notify {
+			id = null
+		}
+		return rpcresponse{JSONRPC: "2.0", Error: &rpcerror{Code: -32600, Message: "invalid request"}, ID: id}, true
+	}
+	args := make([]string, len(req.Params))
+	for i, p := range req.Params {
+		args[i] = fmt.Sprint(p)
+	}
+	r := runcontrol(req.Method, args, cmds, changed)
+	if r.err != nil {
+		return rpcresponse{JSONRPC: "2.0", Error: &rpcerror{Code: -32000, Message: r.err.Error()}, ID: req.ID}, !notify
+	}
+	return rpcresponse{JSONRPC: "2.0", Result: r.result, ID: req.ID}, !notify
+}
+
+// runcontrol passes a command to the window, and waits for its reply. The window
+// takes commands only while drawing a frame, so it is asked for frames until the
+// command is taken (or queued), and once more after.
+func runcontrol(name string, args []string, cmds chan<- control, changed func()) controlreply {
+	c := control{name: strings.ToLower(name), args: args, reply: make(chan controlreply, 1)}
+	timeout := time.After(controltimeout)
+	retry := time.NewTicker(controlretry)
+	defer retry.Stop()
+	changed()
+	for sent := false; !sent; {
+		select {
+		case cmds <- c:
+			sent = true
+		case <-retry.C:
+			changed()
+		case <-timeout:
+			return controlreply{err: fmt.Errorf("timed out")}
+		}
+	}
+	changed()
+	select {
+	case r := <-c.reply:
+		return r
+	case <-timeout:
+		return controlreply{err: fmt.Errorf("timed out")}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunControl(t *testing.T) {
+	// the window takes commands only while drawing a frame, after an invalidate,
+	// whether or not they are queued
+	for _, cmds := range []chan control{make(chan control), make(chan control, controlqueue)} {
+		testcontrol(t, cmds)
+	}
+}
+
+// testcontrol runs commands through cmds, drained by a window that is otherwise idle
+func testcontrol(t *testing.T, cmds chan control) {
+	invalidate := make(chan bool, 1)
+	changed := func() {
+		select {
+		case invalidate <- true:
+		default:
+		}
+	}
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-invalidate:
+			case <-done:
+				return
+			}
+			for pending := true; pending; {
+				select {
+				case c := <-cmds:
+					c.reply <- controlreply{result: c.name + " done"}
+				default:
+					pending = false
+				}
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if r := runcontrol("Next", nil, cmds, changed); r.err != nil || r.result != "next done" {
+			t.Fatalf("command %d: %q, %v", i, r.result, r.err)
+		}
+	}
+}
+
+func TestServeControlHTTP(t *testing.T) {
+	// a web page's request is dropped, without running the commands in its body
+	cmds := make(chan control, controlqueue)
+	client, server := net.Pipe()
+	go servecontrol(server, cmds, func() {})
+	go fmt.Fprint(client, "POST / HTTP/1.1\r\nHost: localhost:7777\r\n\r\nexport deck.xml\n")
+	if b, _ := io.ReadAll(client); len(b) > 0 {
+		t.Errorf("replied %q", b)
+	}
+	if len(cmds) > 0 {
+		t.Errorf("%d commands run", len(cmds))
+	}
+}
+
+func TestExportName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"", filepath.Join("talks", "slide-1.png")},
+		{"out.PNG", filepath.Join("talks", "out.PNG")},
+		{"deck.xml", ""},
+		{"out", ""},
+		{"../out.png", ""},
+		{"..", ""},
+	}
+	for _, test := range tests {
+		got, err := exportname(filepath.Join("talks", "deck.xml"), test.name, "slide-1.png")
+		if got != test.want || (err == nil) != (test.want != "") {
+			t.Errorf("exportname(%q) = %q, %v", test.name, got, err)
+		}
+	}
+}
+
+func TestRemoveStale(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "deck.xml")
+	if err := os.WriteFile(name, []byte("<deck/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := removestale(name); err == nil {
+		t.Error("removed a file that is not a socket")
+	}
+	if _, err := os.Stat(name); err != nil {
+		t.Error(err)
+	}
+	if err := removestale(name + ".missing"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// decksh preprocessing: decksh sources are converted to deck markup by running decksh
+var (
+	usedecksh bool       // always preprocess the input
+	deckshcmd = "decksh" // the decksh command
+)
+
+// isdecksh reports whether the named input should be run through decksh
+func isdecksh(filename string) bool {
+	return usedecksh || strings.HasSuffix(filename, ".dsh")
+}
+
+// decksh runs the decksh command on the named file ("-" for standard input),
+// returning the deck markup it makes
+func decksh(filename string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if filename == "-" {
+		cmd = exec.Command(deckshcmd)
+		cmd.Stdin = os.Stdin
+	} else {
+		cmd = exec.Command(deckshcmd, filename)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s %s: %v: %s", deckshcmd, filename, err, msg)
+		}
+		return nil, fmt.Errorf("%s %s: %v", deckshcmd, filename, err)
+	}
+	return out, nil
+}
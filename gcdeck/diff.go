@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"time"
+
+	"github.com/ajstarks/deck"
+)
+
+// Reload diffing: when the deck is replaced, the slides that changed are noted,
+// so that V can jump between them
+
+// changes are the slides (numbered from 0) that changed when the deck was last replaced
+var changes []int
+
+// changedslides returns the slides of d that differ from those of old, including
+// slides added or removed at the end
+func changedslides(old, d *deck.Deck) []int {
+	var list []int
+	n := len(old.Slide)
+	if len(d.Slide) > n {
+		n = len(d.Slide)
+	}
+	for i := 0; i < n; i++ {
+		if i >= len(old.Slide) || i >= len(d.Slide) {
+			list = append(list, i)
+			continue
+		}
+		a, erra := xml.Marshal(old.Slide[i])
+		b, errb := xml.Marshal(d.Slide[i])
+		if erra != nil || errb != nil || !bytes.Equal(a, b) {
+			list = append(list, i)
+		}
+	}
+	return list
+}
+
+// nextchange returns the first changed slide after the current one,
+// starting again from the first, or -1 if none changed
+func nextchange(list []int, current int) int {
+	if len(list) == 0 {
+		return -1
+	}
+	for _, n := range list {
+		if n > current {
+			return n
+		}
+	}
+	return list[0]
+}
+
+// watching is set when the deck is reloaded when its file changes
+var watching bool
+
+// watchfile calls changed whenever the modification time of the named file
+// changes, checking every interval
+func watchfile(filename string, interval time.Duration, changed func()) {
+	var last time.Time
+	if fi, err := os.Stat(filename); err == nil {
+		last = fi.ModTime()
+	}
+	for range time.Tick(interval) {
+		fi, err := os.Stat(filename)
+		if err != nil || fi.ModTime().Equal(last) {
+			continue
+		}
+		last = fi.ModTime()
+		changed()
+	}
+}
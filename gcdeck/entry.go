@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"time"
+
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Entry effects: the elements of a slide fade, rise or wipe in when it is shown,
+// one after another. An enter attribute on a slide applies to all its elements,
+// started stagger apart, in the order they are drawn; on an element, enter and
+// delay set its own effect and when it starts.
+//
+// <slide enter="rise" stagger="0.2s">
+// <text enter="fade" delay="1s" ...>
+
+// enterduration is how long each element takes to enter
+const enterduration = 500 * time.Millisecond
+
+// entry is the effect an element enters with, and when it starts
+type entry struct {
+	effect string
+	delay  time.Duration
+}
+
+// slideentries are the entries of a slide's elements, by kind (as in deck markup) and index
+type slideentries map[string][]entry
+
+// entries are the entry effects of the slides of the deck shown (nil for slides without),
+// and entered is the slide whose elements entered at enterstart
+var (
+	entries    []slideentries
+	entered    = -1
+	enterstart time.Time
+	enternow   time.Time // the time the entering slide is drawn for; zero when not entering
+)
+
+// the kinds of element, in the order they are drawn
+var entrykinds = []string{"image", "rect", "ellipse", "curve", "arc", "line", "polygon", "text", "list"}
+
+// elementattr are the attributes of slide elements that the deck package does not read
+type elementattr struct {
+	Enter    string  `xml:"enter,attr"`
+	Delay    string  `xml:"delay,attr"`
+	Rotation float64 `xml:"rotation,attr"`
+	Scale    float64 `xml:"scale,attr"`
+	KenBurns string  `xml:"kenburns,attr"`
+	KBTime   string  `xml:"kbtime,attr"`
+
+	Gradcolor1  string  `xml:"gradcolor1,attr"`
+	Gradcolor2  string  `xml:"gradcolor2,attr"`
+	GradAngle   string  `xml:"gradangle,attr"`
+	GradPercent float64 `xml:"gp,attr"`
+}
+
+// elementmarkup is deck markup, read for those attributes
+type elementmarkup struct {
+	Slide []struct {
+		Enter   string        `xml:"enter,attr"`
+		Stagger string        `xml:"stagger,attr"`
+		Image   []elementattr `xml:"image"`
+		Rect    []elementattr `xml:"rect"`
+		Ellipse []elementattr `xml:"ellipse"`
+		Curve   []elementattr `xml:"curve"`
+		Arc     []elementattr `xml:"arc"`
+		Line    []elementattr `xml:"line"`
+		Polygon []elementattr `xml:"polygon"`
+		Text    []elementattr `xml:"text"`
+		List    []elementattr `xml:"list"`
+	} `xml:"slide"`
+}
+
+// elements returns the attributes of the elements of slide i, by kind, in the order of entrykinds
+func (m elementmarkup) elements(i int) [][]elementattr {
+	s := m.Slide[i]
+	return [][]elementattr{s.Image, s.Rect, s.Ellipse, s.Curve, s.Arc, s.Line, s.Polygon, s.Text, s.List}
+}
+
+// parseentries reads the entry effects in deck markup, returning nil if there are none
+func parseentries(markup []byte) ([]slideentries, error) {
+	var m elementmarkup
+	if err := xml.Unmarshal(markup, &m); err != nil {
+		return nil, err
+	}
+	var list []slideentries
+	for i, s := range m.Slide {
+		var stagger time.Duration
+		if s.Stagger != "" {
+			var err error
+			if stagger, err = parsetimestamp(s.Stagger); err != nil {
+				return nil, fmt.Errorf("slide %d: stagger: %v", i+1, err)
+			}
+		}
+		fx := slideentries{}
+		n, animated := 0, false
+		for k, attrs := range m.elements(i) {
+			kind := entrykinds[k]
+			for _, a := range attrs {
+				e := entry{effect: s.Enter, delay: time.Duration(n) * stagger}
+				if a.Enter != "" {
+					e.effect = a.Enter
+				}
+				if a.Delay != "" {
+					d, err := parsetimestamp(a.Delay)
+					if err != nil {
+						return nil, fmt.Errorf("slide %d: %s delay: %v", i+1, kind, err)
+					}
+					e.delay = d
+				}
+				switch e.effect {
+				case "", "none":
+					e.effect = ""
+				case "fade", "rise", "wipe":
+					animated = true
+					n++
+				default:
+					return nil, fmt.Errorf("slide %d: unknown entry effect %q (fade, rise, wipe)", i+1, e.effect)
+				}
+				fx[kind] = append(fx[kind], e)
+			}
+		}
+		if animated {
+			if list == nil {
+				list = make([]slideentries, len(m.Slide))
+			}
+			list[i] = fx
+		}
+	}
+	return list, nil
+}
+
+// length returns how long the slide's elements take to enter
+func (fx slideentries) length() time.Duration {
+	var d time.Duration
+	for _, list := range fx {
+		for _, e := range list {
+			if e.effect != "" && e.delay+enterduration > d {
+				d = e.delay + enterduration
+			}
+		}
+	}
+	return d
+}
+
+// entering notes that slide n is shown at now, starting the entrance of its
+// elements if it was not shown before, and reports whether they are still entering
+func entering(n int, now time.Time) bool {
+	if n != entered {
+		entered, enterstart = n, now
+	}
+	return n < len(entries) && entries[n] != nil && now.Sub(enterstart) < entries[n].length()
+}
+
+// enteringslide returns the entries of slide n, if it is being drawn entering
+func enteringslide(n int) slideentries {
+	if enternow.IsZero() || n != entered || n >= len(entries) {
+		return nil
+	}
+	return entries[n]
+}
+
+// enter applies the entry effect of element i of the kind to the canvas,
+// returning the fraction of its opacity to draw it with, and a function
+// that ends the effect. Images cannot be faded; they wipe in instead.
+func (fx slideentries) enter(doc *gc.Canvas, kind string, i int) (float64, func()) {
+	if i >= len(fx[kind]) || fx[kind][i].effect == "" {
+		return 1, func() {}
+	}
+	e := fx[kind][i]
+	tw := gc.Tween{Start: enterstart.Add(e.delay), Duration: enterduration, Ease: gc.EaseOut}
+	p := tw.At(enternow)
+	ops := doc.Context.Ops
+	switch {
+	case e.effect == "rise":
+		stack := op.Offset(image.Pt(0, int((1-p)*float64(doc.Height)*0.05))).Push(ops)
+		return p, stack.Pop
+	case e.effect == "wipe" || kind == "image":
+		stack := clip.Rect(image.Rect(0, 0, int(p*float64(doc.Width)), int(doc.Height))).Push(ops)
+		return 1, stack.Pop
+	}
+	return p, func() {}
+}
+
+// fadeop returns an element's opacity (percent, 0 for opaque), faded by the fraction f
+func fadeop(opacity, f float64) float64 {
+	if f >= 1 {
+		return opacity
+	}
+	if opacity <= 0 {
+		opacity = 100
+	}
+	if v := opacity * f; v > 0.01 {
+		return v
+	}
+	return 0.01
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gioui.org/io/system"
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/capture"
+)
+
+// renderslide draws slide n on a new offscreen canvas,
+// with its own background, even when the window uses a chroma key
+func renderslide(d *deck.Deck, n int, width, height float32) *gc.Canvas {
+	key := chromakey
+	chromakey = nil
+	defer func() { chromakey = key }()
+	canvas := gc.NewCanvas(width, height, system.FrameEvent{})
+	showslide(canvas, d, n)
+	return canvas
+}
+
+// slideitem is an element of slide content, in reading order
+type slideitem struct {
+	x, y float64
+	html string
+}
+
+// relpath returns the path of name, relative to dir
+func relpath(dir, name string) string {
+	an, err := filepath.Abs(name)
+	if err != nil {
+		return name
+	}
+	ad, err := filepath.Abs(dir)
+	if err != nil {
+		return name
+	}
+	r, err := filepath.Rel(ad, an)
+	if err != nil {
+		return name
+	}
+	return filepath.ToSlash(r)
+}
+
+// slidehtml returns the content of a slide as HTML, reading top to bottom, left to right
+func slidehtml(slide deck.Slide, dir string) string {
+	var items []slideitem
+	for _, t := range slide.Text {
+		tdata := t.Tdata
+		if t.File != "" {
+			tdata = includefile(t.File)
+		}
+		var s string
+		switch {
+		case t.Type == "code":
+			s = "<pre><code>" + html.EscapeString(tdata) + "</code></pre>"
+		case t.Sp >= 4:
+			s = "<h2>" + html.EscapeString(tdata) + "</h2>"
+		default:
+			s = "<p>" + html.EscapeString(tdata) + "</p>"
+		}
+		items = append(items, slideitem{t.Xp, t.Yp, s})
+	}
+	for _, l := range slide.List {
+		tag := "ul"
+		if l.Type == "number" {
+			tag = "ol"
+		}
+		var b strings.Builder
+		b.WriteString("<" + tag + ">\n")
+		for _, li := range l.Li {
+			b.WriteString("<li>" + html.EscapeString(li.ListText) + "</li>\n")
+		}
+		b.WriteString("</" + tag + ">")
+		items = append(items, slideitem{l.Xp, l.Yp, b.String()})
+	}
+	for _, im := range slide.Image {
+		s := "<figure><img src=\"" + html.EscapeString(relpath(dir, im.Name)) + "\" alt=\"" + html.EscapeString(im.Caption) + "\">"
+		if len(im.Caption) > 0 {
+			s += "<figcaption>" + html.EscapeString(im.Caption) + "</figcaption>"
+		}
+		s += "</figure>"
+		items = append(items, slideitem{im.Xp, im.Yp, s})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].y != items[j].y {
+			return items[i].y > items[j].y
+		}
+		return items[i].x < items[j].x
+	})
+	var b strings.Builder
+	for _, it := range items {
+		b.WriteString(it.html + "\n")
+	}
+	return b.String()
+}
+
+// slidename returns the base file name for slide n
+func slidename(n int) string {
+	return fmt.Sprintf("slide-%02d", n+1)
+}
+
+// exporthtml writes every slide as an HTML page with its text, list and image content,
+// plus a PNG rendering of the slide, and an index page, into the directory dir
+func exporthtml(d *deck.Deck, title, dir string, width, height float32) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	ns := len(d.Slide)
+	var index strings.Builder
+	fmt.Fprintf(&index, "<!DOCTYPE html>\n<html lang=\"en\">\n<head><meta charset=\"utf-8\"><title>%s</title></head>\n<body>\n<h1>%s</h1>\n<ol>\n",
+		html.EscapeString(title), html.EscapeString(title))
+	for i := 0; i < ns; i++ {
+		name := slidename(i)
+		canvas := renderslide(d, i, width, height)
+		if err := capture.WritePNG(canvas, filepath.Join(dir, name+".png")); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "<!DOCTYPE html>\n<html lang=\"en\">\n<head><meta charset=\"utf-8\"><title>%s: slide %d</title></head>\n<body>\n",
+			html.EscapeString(title), i+1)
+		fmt.Fprintf(&b, "<main aria-label=\"slide %d of %d\">\n%s</main>\n", i+1, ns, slidehtml(d.Slide[i], dir))
+		if len(d.Slide[i].Note) > 0 {
+			fmt.Fprintf(&b, "<aside>%s</aside>\n", html.EscapeString(d.Slide[i].Note))
+		}
+		fmt.Fprintf(&b, "<p><a href=\"%s.png\">slide image</a></p>\n<nav>", name)
+		if i > 0 {
+			fmt.Fprintf(&b, "<a href=\"%s.html\">previous</a> ", slidename(i-1))
+		}
+		b.WriteString("<a href=\"index.html\">contents</a>")
+		if i < ns-1 {
+			fmt.Fprintf(&b, " <a href=\"%s.html\">next</a>", slidename(i+1))
+		}
+		b.WriteString("</nav>\n</body>\n</html>\n")
+		if err := os.WriteFile(filepath.Join(dir, name+".html"), []byte(b.String()), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(&index, "<li><a href=\"%s.html\">slide %d</a></li>\n", name, i+1)
+	}
+	index.WriteString("</ol>\n</body>\n</html>\n")
+	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(index.String()), 0644)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ajstarks/deck"
+)
+
+// following is set when decks are read continuously from standard input
+var following bool
+
+// followdecks reads a stream of deck documents from r, one after another, sending
+// each to the returned channel and calling changed. Only the latest deck is kept,
+// if they arrive faster than they are shown; decks without slides are skipped.
+// The channel is closed at the end of the input.
+func followdecks(r io.Reader, w, h int, changed func()) <-chan deck.Deck {
+	decks := make(chan deck.Deck, 1)
+	go func() {
+		defer close(decks)
+		dec := xml.NewDecoder(r)
+		for {
+			var d deck.Deck
+			err := dec.Decode(&d)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "follow: %v\n", err)
+				return
+			}
+			if len(d.Slide) == 0 {
+				continue
+			}
+			if d.Canvas.Width == 0 || d.Canvas.Height == 0 {
+				d.Canvas.Width, d.Canvas.Height = w, h
+			}
+			select {
+			case <-decks:
+			default:
+			}
+			decks <- d
+			changed()
+		}
+	}()
+	return decks
+}
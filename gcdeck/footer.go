@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Footers: slide numbers, a footer and the date, drawn in corners of every
+// slide, over its content
+
+// the corners of the slide numbers, footer and date ("" for none), what they show,
+// and how they are drawn (in the slide's foreground color if footercolor is "")
+var (
+	pagecorner, footercorner, datecorner string
+	pageformat                           = "{page}"
+	footer, dateformat                   string
+	footerfont                           = "sans"
+	footersize                           = 1.2
+	footercolor                          string
+)
+
+// corners maps corner names to positions and alignments
+var corners = map[string]struct {
+	x, y  float64
+	align string
+}{
+	"tl": {3, 96, "begin"}, "tc": {50, 96, "center"}, "tr": {97, 96, "end"},
+	"bl": {3, 3, "begin"}, "bc": {50, 3, "center"}, "br": {97, 3, "end"},
+}
+
+// checkcorner reports an unknown corner name
+func checkcorner(name string) error {
+	if _, ok := corners[name]; name != "" && !ok {
+		return fmt.Errorf("unknown corner %q (tl, tc, tr, bl, bc, br)", name)
+	}
+	return nil
+}
+
+// datestring returns the date shown in footers: the deck's date, or today's,
+// in dateformat or the locale's layout
+func datestring(d *deck.Deck) string {
+	if d.Date != "" {
+		return d.Date
+	}
+	if dateformat != "" {
+		return time.Now().Format(dateformat)
+	}
+	return decklocale.Date(time.Now())
+}
+
+// showfooters draws the slide number, footer and date of slide n
+func showfooters(doc *gc.Canvas, d *deck.Deck, n int, fg string) {
+	if footercolor != "" {
+		fg = footercolor
+	}
+	color := gc.ColorLookup(fg)
+	show := func(corner, s string) {
+		p, ok := corners[corner]
+		if !ok || s == "" {
+			return
+		}
+		showtext(doc, p.x, p.y, s, footersize, color, footerfont, p.align)
+	}
+	page := strings.NewReplacer("{page}", strconv.Itoa(n+1), "{pages}", strconv.Itoa(len(d.Slide)))
+	show(pagecorner, page.Replace(pageformat))
+	show(footercorner, footer)
+	show(datecorner, datestring(d))
+}
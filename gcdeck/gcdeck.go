@@ -2,14 +2,19 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"image"
 	"image/color"
 	"math"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 	"unicode"
 
 	_ "image/gif"
@@ -17,6 +22,7 @@ import (
 	_ "image/png"
 
 	"gioui.org/app"
+	"gioui.org/f32"
 	"gioui.org/io/event"
 	"gioui.org/io/key"
 	"gioui.org/io/pointer"
@@ -25,6 +31,12 @@ import (
 	"gioui.org/unit"
 	"github.com/ajstarks/deck"
 	gc "github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/capture"
+	"github.com/ajstarks/giocanvas/widgets"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
 )
 
 const (
@@ -133,6 +145,15 @@ func setop(v float64) uint8 {
 
 // gradient sets the background color gradient
 func gradient(doc *gc.Canvas, w, h float64, gc1, gc2 string, gp float64) {
+	doc.BackgroundGradient(deckgradient(float64(doc.Width), float64(doc.Height), gc.ColorLookup(gc1), gc.ColorLookup(gc2), gp))
+}
+
+// rotate rotates by degrees around (x, y), without requesting continuous redraws
+func rotate(doc *gc.Canvas, x, y, degrees float64) op.TransformStack {
+	ax, ay := pct(x, float64(doc.Width)), pct(100-y, float64(doc.Height))
+	stack := op.Offset(image.Pt(0, 0)).Push(doc.Context.Ops)
+	op.Affine(f32.Affine2D{}.Rotate(f32.Pt(float32(ax), float32(ay)), float32(radians(degrees)))).Add(doc.Context.Ops)
+	return stack
 }
 
 // doline draws a line
@@ -142,11 +163,21 @@ func doline(doc *gc.Canvas, xp1, yp1, xp2, yp2, sw float64, color string, opacit
 	doc.Line(float32(xp1), float32(yp1), float32(xp2), float32(yp2), float32(sw), c)
 }
 
-// doarc draws an arc
+// doarc draws an arc, radii w and h (percentages of the width; the deck's wp and hp, as
+// gcdeck has always taken them), stroked sw wide, counter-clockwise from a1 to a2 degrees
+// (the canvas' angles increase downward, so they are negated)
 func doarc(doc *gc.Canvas, x, y, w, h, a1, a2, sw float64, color string, opacity float64) {
 	c := gc.ColorLookup(color)
 	c.A = setop(opacity)
-	doc.Arc(float32(x), float32(y), float32(w*2), radians(a1), radians(a2), c)
+	if h <= 0 {
+		h = w
+	}
+	if h == w {
+		doc.AnnularArc(float32(x), float32(y), float32(math.Max(0, w-sw/2)), float32(w+sw/2), -radians(a1), -radians(a2), c)
+		return
+	}
+	h *= float64(doc.Width / doc.Height)
+	doc.EllipticalArc(float32(x), float32(y), float32(w), float32(h), 0, -radians(a1), -radians(a2), float32(sw), c)
 }
 
 // docurve draws a bezier curve
@@ -172,13 +203,22 @@ func doellipse(doc *gc.Canvas, x, y, w, h float64, color string, opacity float64
 
 // dopoly draws a polygon
 func dopoly(doc *gc.Canvas, xc, yc string, cw, ch float64, color string, opacity float64) {
+	px, py := polypoints(xc, yc)
+	if len(px) < 3 {
+		return
+	}
+	c := gc.ColorLookup(color)
+	c.A = setop(opacity)
+	doc.Polygon(px, py, c)
+}
+
+// polypoints returns the points of a polygon's coordinates (space-separated),
+// or nil if they do not pair up
+func polypoints(xc, yc string) ([]float32, []float32) {
 	xs := strings.Split(xc, " ")
 	ys := strings.Split(yc, " ")
 	if len(xs) != len(ys) {
-		return
-	}
-	if len(xs) < 3 || len(ys) < 3 {
-		return
+		return nil, nil
 	}
 	px := make([]float32, len(xs))
 	py := make([]float32, len(xs))
@@ -196,21 +236,23 @@ func dopoly(doc *gc.Canvas, xc, yc string, cw, ch float64, color string, opacity
 			py[i] = float32(y)
 		}
 	}
-	c := gc.ColorLookup(color)
-	c.A = setop(opacity)
-	doc.Polygon(px, py, c)
+	return px, py
 }
 
 // dotext places text elements on the canvas according to type
 func dotext(doc *gc.Canvas, x, y, fs, wp, rotation, spacing float64, tdata, font, align, ttype, color string, opacity float64) {
 	td := strings.Split(tdata, "\n")
 	c := gc.ColorLookup(color)
+	c.A = setop(opacity)
 	var tstack op.TransformStack
 	if rotation > 0 {
-		tstack = doc.Rotate(float32(x), float32(y), float32(rotation*(math.Pi/180)))
+		tstack = rotate(doc, x, y, rotation)
 	}
 	if ttype == "code" {
 		font = "mono"
+	}
+	loadfont(doc, font, fs)
+	if ttype == "code" {
 		ch := float64(len(td)) * spacing * fs
 		bx := (x + (wp / 2))
 		by := (y - (ch / 2)) + (spacing * fs)
@@ -218,6 +260,14 @@ func dotext(doc *gc.Canvas, x, y, fs, wp, rotation, spacing float64, tdata, font
 	}
 	if ttype == "block" {
 		textwrap(doc, x, y, fs, wp, tdata, color, opacity)
+	} else if ttype == "markdown" {
+		doc.Markdown(float32(x), float32(y), float32(fs), float32(wp), tdata, c)
+	} else if ttype == "diff" {
+		doc.Diff(float32(x), float32(y), float32(fs), float32(wp), tdata, c)
+	} else if ttype == "terminal" {
+		tc := gc.ColorLookup("rgb(220,220,220)")
+		tc.A = c.A
+		doc.Terminal(float32(x), float32(y), float32(fs), float32(wp), tdata, tc)
 	} else {
 		ls := spacing * fs
 		for _, t := range td {
@@ -242,8 +292,66 @@ func whitespace(r rune) bool {
 	return r == ' ' || r == '\n' || r == '\t'
 }
 
-// loadfont loads a font at the specified size
+// loadfont loads a font at the specified size: a generic font, or one of its
+// weights and styles, such as sans-bold, serif-italic or mono-bolditalic
 func loadfont(doc *gc.Canvas, s string, size float64) {
+	if _, ok := doc.Fonts[s]; !ok {
+		doc.DefineFont(s, deckfont(s))
+	}
+	doc.SetFont(s)
+}
+
+// deckfont returns the font a deck font name stands for
+func deckfont(s string) gc.Font {
+	name, style := s, ""
+	if i := strings.LastIndexByte(s, '-'); i > 0 {
+		name, style = s[:i], s[i+1:]
+	}
+	f := gc.Font{Typeface: fontlookup(name)}
+	switch style {
+	case "light":
+		f.Weight = gc.LightWeight
+	case "bold":
+		f.Weight = gc.BoldWeight
+	case "italic":
+		f.Italic = true
+	case "bolditalic":
+		f.Weight, f.Italic = gc.BoldWeight, true
+	default:
+		f.Typeface = fontlookup(s)
+	}
+	return f
+}
+
+// setfonts loads the font files (several, separated by commas, for the weights and
+// styles of a family) for the generic fonts sans, serif and mono, mapping each name
+// given files to its fonts; mono is Go Mono unless it is given some. The others are
+// the Go fonts without any.
+func setfonts(sans, serif, mono string) error {
+	for _, f := range []struct{ name, files string }{{"sans", sans}, {"serif", serif}, {"mono", mono}} {
+		var fonts [][]byte
+		switch {
+		case f.files != "":
+			for _, file := range strings.Split(f.files, ",") {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				fonts = append(fonts, data)
+			}
+		case f.name == "mono":
+			fonts = [][]byte{gomono.TTF, gomonobold.TTF, gomonoitalic.TTF, gomonobolditalic.TTF}
+		default:
+			continue
+		}
+		for _, data := range fonts {
+			if err := gc.RegisterFont(f.name, data); err != nil {
+				return fmt.Errorf("%s: %v", f.name, err)
+			}
+		}
+		fontmap[f.name] = f.name
+	}
+	return nil
 }
 
 // showtext places fully attributed text at the specified location
@@ -269,9 +377,10 @@ func dolist(doc *gc.Canvas, cw, x, y, fs, lwidth, rotation, spacing float64, lis
 	}
 	var tstack op.TransformStack
 	if rotation > 0 {
-		tstack = doc.Rotate(float32(x), float32(y), float32(rotation*(math.Pi/180)))
+		tstack = rotate(doc, x, y, rotation)
 	}
 	c := gc.ColorLookup(color)
+	c.A = setop(opacity)
 	ls := listspacing * fs * 1.4
 	for i, tl := range list {
 		loadfont(doc, font, fs)
@@ -303,26 +412,43 @@ func showslide(doc *gc.Canvas, d *deck.Deck, n int) {
 	}
 	cw := float64(d.Canvas.Width)
 	ch := float64(d.Canvas.Height)
-	slide := d.Slide[n]
+	slide := withmaster(d.Slide[n])
+	doc.Emoji = emoji
 	// set default background
 	if slide.Bg == "" {
 		slide.Bg = "white"
 	}
-	doc.Background(gc.ColorLookup(slide.Bg))
+	if chromakey != nil {
+		doc.Background(*chromakey)
+	} else {
+		doc.Background(gc.ColorLookup(slide.Bg))
+	}
 
 	if slide.GradPercent <= 0 || slide.GradPercent > 100 {
 		slide.GradPercent = 100
 	}
 	// set gradient background, if specified. You need both colors
-	if len(slide.Gradcolor1) > 0 && len(slide.Gradcolor2) > 0 {
+	if len(slide.Gradcolor1) > 0 && len(slide.Gradcolor2) > 0 && chromakey == nil {
 		gradient(doc, cw, ch, slide.Gradcolor1, slide.Gradcolor2, slide.GradPercent)
 	}
 	// set the default foreground
 	if slide.Fg == "" {
 		slide.Fg = "black"
 	}
+	// the master's elements, beneath the slide's own
+	if master != nil {
+		showelements(doc, masterslide(d, n, slide.Fg), -1, cw, ch, nil, nil)
+	}
+	showelements(doc, slide, n, cw, ch, enteringslide(n), slidegraphic(n))
+	showfooters(doc, d, n, slide.Fg)
+}
+
+// showelements draws the images, graphics, text and lists of slide n (-1 for a master),
+// with the entry effects fx and graphic attributes tf, if any
+func showelements(doc *gc.Canvas, slide deck.Slide, n int, cw, ch float64, fx slideentries, tf slidegraphics) {
 	// for every image on the slide...
-	for _, im := range slide.Image {
+	for i, im := range slide.Image {
+		_, done := fx.enter(doc, "image", i)
 		iw, ih := im.Width, im.Height
 		// scale the image to a percentage of the canvas width
 		if im.Height == 0 && im.Width > 0 {
@@ -337,7 +463,22 @@ func showslide(doc *gc.Canvas, d *deck.Deck, n int) {
 			}
 			//println(im.Name, im.Xp, im.Yp, iw, ih, nw, nh)
 		}
-		doc.Image(im.Name, float32(im.Xp), float32(im.Yp), iw, ih, float32(im.Scale))
+		doc.Describe("image", im.Caption)
+		if view, ok := imageview(n, i); ok {
+			sc := im.Scale / 100
+			if sc == 0 {
+				sc = 1
+			}
+			if iw == 0 && ih == 0 {
+				if size, err := gc.ImageSize(im.Name); err == nil {
+					iw, ih = size.X, size.Y
+				}
+			}
+			w, h := float64(iw)*sc/cw*100, float64(ih)*sc/ch*100
+			doc.ImageView(im.Name, view, float32(im.Xp), float32(im.Yp), float32(w), float32(h))
+		} else {
+			doc.Image(im.Name, float32(im.Xp), float32(im.Yp), iw, ih, float32(im.Scale))
+		}
 		if len(im.Caption) > 0 {
 			capsize := 1.5
 			if im.Font == "" {
@@ -364,78 +505,123 @@ func showslide(doc *gc.Canvas, d *deck.Deck, n int) {
 			cy = im.Yp - (ih/2)/ch*100 - (capsize * 2)
 			showtext(doc, cx, cy, im.Caption, capsize, gc.ColorLookup(im.Color), im.Font, im.Align)
 		}
+		done()
 	}
 	// every graphic on the slide
 	const defaultColor = "rgb(127,127,127)"
 	// rect
-	for _, rect := range slide.Rect {
+	for i, rect := range slide.Rect {
 		if rect.Color == "" {
 			rect.Color = defaultColor
 		}
-		if rect.Hr == 100 {
+		fade, done := fx.enter(doc, "rect", i)
+		end := tf.apply(doc, "rect", i, rect.Xp, rect.Yp)
+		rect.Opacity = fadeop(rect.Opacity, fade)
+		if g, ok := tf.gradient("rect", i); ok {
+			h := rect.Hp
+			if rect.Hr == 100 {
+				h = rect.Wp * (cw / ch)
+			}
+			dogradrect(doc, rect.Xp, rect.Yp, rect.Wp, h, g, rect.Opacity)
+		} else if rect.Hr == 100 {
 			c := gc.ColorLookup(rect.Color)
 			c.A = setop(rect.Opacity)
 			doc.CenterRect(float32(rect.Xp), float32(rect.Yp), float32(rect.Wp), float32((rect.Wp)*(cw/ch)), c)
 		} else {
 			dorect(doc, rect.Xp, rect.Yp, rect.Wp, rect.Hp, rect.Color, rect.Opacity)
 		}
+		end()
+		done()
 	}
 	// ellipse
-	for _, ellipse := range slide.Ellipse {
+	for i, ellipse := range slide.Ellipse {
 		if ellipse.Color == "" {
 			ellipse.Color = defaultColor
 		}
-		if ellipse.Hr == 100 {
+		fade, done := fx.enter(doc, "ellipse", i)
+		end := tf.apply(doc, "ellipse", i, ellipse.Xp, ellipse.Yp)
+		ellipse.Opacity = fadeop(ellipse.Opacity, fade)
+		if g, ok := tf.gradient("ellipse", i); ok {
+			h := ellipse.Hp
+			if ellipse.Hr == 100 {
+				h = ellipse.Wp * (cw / ch)
+			}
+			dogradellipse(doc, ellipse.Xp, ellipse.Yp, ellipse.Wp, h, g, ellipse.Opacity)
+		} else if ellipse.Hr == 100 {
 			c := gc.ColorLookup(ellipse.Color)
 			c.A = setop(ellipse.Opacity)
 			doc.Circle(float32(ellipse.Xp), float32(ellipse.Yp), float32(ellipse.Wp/2), c)
 		} else {
 			doellipse(doc, ellipse.Xp, ellipse.Yp, ellipse.Wp, ellipse.Hp, ellipse.Color, ellipse.Opacity)
 		}
+		end()
+		done()
 	}
 	// curve
-	for _, curve := range slide.Curve {
+	for i, curve := range slide.Curve {
 		if curve.Color == "" {
 			curve.Color = defaultColor
 		}
+		fade, done := fx.enter(doc, "curve", i)
+		end := tf.apply(doc, "curve", i, (curve.Xp1+curve.Xp2+curve.Xp3)/3, (curve.Yp1+curve.Yp2+curve.Yp3)/3)
+		curve.Opacity = fadeop(curve.Opacity, fade)
 		if curve.Sp == 0 {
 			curve.Sp = 0.2
 		}
 		docurve(doc, curve.Xp1, curve.Yp1, curve.Xp2, curve.Yp2, curve.Xp3, curve.Yp3, curve.Sp, curve.Color, curve.Opacity)
+		end()
+		done()
 	}
 	// arc
-	for _, arc := range slide.Arc {
+	for i, arc := range slide.Arc {
 		if arc.Color == "" {
 			arc.Color = defaultColor
 		}
-		w := arc.Wp
-		h := arc.Hp
+		fade, done := fx.enter(doc, "arc", i)
+		end := tf.apply(doc, "arc", i, arc.Xp, arc.Yp)
+		arc.Opacity = fadeop(arc.Opacity, fade)
 		if arc.Sp == 0 {
 			arc.Sp = 0.2
 		}
-		doarc(doc, arc.Xp, arc.Yp, w/2, h/2, arc.A1, arc.A2, arc.Sp, arc.Color, arc.Opacity)
+		doarc(doc, arc.Xp, arc.Yp, arc.Wp, arc.Hp, arc.A1, arc.A2, arc.Sp, arc.Color, arc.Opacity)
+		end()
+		done()
 	}
 	// line
-	for _, line := range slide.Line {
+	for i, line := range slide.Line {
 		if line.Color == "" {
 			line.Color = defaultColor
 		}
+		fade, done := fx.enter(doc, "line", i)
+		end := tf.apply(doc, "line", i, (line.Xp1+line.Xp2)/2, (line.Yp1+line.Yp2)/2)
+		line.Opacity = fadeop(line.Opacity, fade)
 		if line.Sp == 0 {
 			line.Sp = 0.2
 		}
 		doline(doc, line.Xp1, line.Yp1, line.Xp2, line.Yp2, line.Sp, line.Color, line.Opacity)
+		end()
+		done()
 	}
 	// polygon
-	for _, poly := range slide.Polygon {
+	for i, poly := range slide.Polygon {
 		if poly.Color == "" {
 			poly.Color = defaultColor
 		}
-		dopoly(doc, poly.XC, poly.YC, cw, ch, poly.Color, poly.Opacity)
+		fade, done := fx.enter(doc, "polygon", i)
+		px, py := polycenter(poly.XC, poly.YC)
+		end := tf.apply(doc, "polygon", i, px, py)
+		if g, ok := tf.gradient("polygon", i); ok {
+			dogradpoly(doc, poly.XC, poly.YC, g, fadeop(poly.Opacity, fade))
+		} else {
+			dopoly(doc, poly.XC, poly.YC, cw, ch, poly.Color, fadeop(poly.Opacity, fade))
+		}
+		end()
+		done()
 	}
 
 	// for every text element...
 	var tdata string
-	for _, t := range slide.Text {
+	for i, t := range slide.Text {
 		if t.Color == "" {
 			t.Color = slide.Fg
 		}
@@ -450,10 +636,12 @@ func showslide(doc *gc.Canvas, d *deck.Deck, n int) {
 		if t.Lp == 0 {
 			t.Lp = linespacing
 		}
-		dotext(doc, t.Xp, t.Yp, t.Sp, t.Wp, t.Rotation, t.Lp*1.2, tdata, t.Font, t.Align, t.Type, t.Color, t.Opacity)
+		fade, done := fx.enter(doc, "text", i)
+		dotext(doc, t.Xp, t.Yp, t.Sp, t.Wp, t.Rotation, t.Lp*1.2, tdata, t.Font, t.Align, t.Type, t.Color, fadeop(t.Opacity, fade))
+		done()
 	}
 	// for every list element...
-	for _, l := range slide.List {
+	for i, l := range slide.List {
 		if l.Color == "" {
 			l.Color = slide.Fg
 		}
@@ -463,30 +651,51 @@ func showslide(doc *gc.Canvas, d *deck.Deck, n int) {
 		if l.Wp == 0 {
 			l.Wp = listwrap
 		}
-		dolist(doc, cw, l.Xp, l.Yp, l.Sp, l.Wp, l.Rotation, l.Lp, l.Li, l.Font, l.Type, l.Align, l.Color, l.Opacity)
+		fade, done := fx.enter(doc, "list", i)
+		dolist(doc, cw, l.Xp, l.Yp, l.Sp, l.Wp, l.Rotation, l.Lp, l.Li, l.Font, l.Type, l.Align, l.Color, fadeop(l.Opacity, fade))
+		done()
 	}
 
 }
 
 // imageinfo returns the dimensions of an image
 func imageInfo(s string) (int, int) {
-	f, err := os.Open(s)
-	defer f.Close()
+	size, err := gc.ImageSize(s)
 	if err != nil {
 		return 0, 0
 	}
-	im, _, err := image.DecodeConfig(f)
-	if err != nil {
-		return 0, 0
+	return size.X, size.Y
+}
+
+// deckimages returns the names of all the images in a deck
+func deckimages(d *deck.Deck) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, slide := range d.Slide {
+		for _, im := range slide.Image {
+			if !seen[im.Name] {
+				seen[im.Name] = true
+				names = append(names, im.Name)
+			}
+		}
+	}
+	return names
+}
+
+// loading shows the progress of decoding the deck images
+func loading(c *gc.Canvas, done, total int) {
+	c.Background(gc.ColorLookup("white"))
+	fg := gc.ColorLookup("gray")
+	c.TextMid(50, 52, 2, fmt.Sprintf("loading images: %d of %d", done, total), fg)
+	c.CornerRect(25, 48, 50, 2, gc.ColorLookup("lightgray"))
+	if total > 0 {
+		c.CornerRect(25, 48, 50*float32(done)/float32(total), 2, fg)
 	}
-	return im.Width, im.Height
 }
 
 // ReadDeck reads the deck file, rendering to the canvas
 func readDeck(filename string, w, h float32) (deck.Deck, error) {
-	d, err := deck.Read(filename, int(w), int(h))
-	d.Canvas.Width = int(w)
-	d.Canvas.Height = int(h)
+	d, _, err := readslides(filename, w, h)
 	return d, err
 }
 
@@ -501,6 +710,42 @@ func reload(filename string, c *gc.Canvas, w, h, n int) (deck.Deck, int) {
 	return d, len(d.Slide) - 1
 }
 
+// colorcheck warns about adjacent slide colors that are hard to distinguish
+// with color vision deficiencies
+func colorcheck(d *deck.Deck) {
+	for i, slide := range d.Slide {
+		var palette []color.NRGBA
+		add := func(s string) {
+			if s == "" {
+				return
+			}
+			c := gc.ColorLookup(s)
+			if n := len(palette); n > 0 && palette[n-1] == c {
+				return
+			}
+			palette = append(palette, c)
+		}
+		for _, r := range slide.Rect {
+			add(r.Color)
+		}
+		for _, e := range slide.Ellipse {
+			add(e.Color)
+		}
+		for _, p := range slide.Polygon {
+			add(p.Color)
+		}
+		for _, a := range slide.Arc {
+			add(a.Color)
+		}
+		for _, l := range slide.Line {
+			add(l.Color)
+		}
+		for _, w := range gc.CheckPalette(palette, 0) {
+			fmt.Fprintf(os.Stderr, "slide %d: %v\n", i+1, w)
+		}
+	}
+}
+
 // ngrid makes a numbered grid
 func ngrid(c *gc.Canvas, interval, ts float32, color color.NRGBA) {
 	color.A = 50
@@ -508,20 +753,126 @@ func ngrid(c *gc.Canvas, interval, ts float32, color color.NRGBA) {
 	var x, y float32
 	color.A = 220
 	for x = interval; x < 100; x += interval {
-		c.CText(x, ts, ts, fmt.Sprintf("%0.f", x), color)
+		c.CText(x, ts, ts, c.Locale.Number(float64(x), 0), color)
 	}
 	for y = interval; y < 100; y += interval {
-		c.CText(ts, y-(ts/2), ts, fmt.Sprintf("%0.f", y), color)
+		c.CText(ts, y-(ts/2), ts, c.Locale.Number(float64(y), 0), color)
 	}
 }
 
 func main() {
 	var (
-		title    = flag.String("title", "", "slide title")
-		pagesize = flag.String("pagesize", "Letter", "pagesize: w,h, or one of: Letter, Legal, Tabloid, A3, A4, A5, ArchA, 4R, Index, Widescreen")
-		initpage = flag.Int("page", 1, "initial page")
+		title     = flag.String("title", "", "slide title")
+		pagesize  = flag.String("pagesize", "Letter", "pagesize: w,h, or one of: Letter, Legal, Tabloid, A3, A4, A5, ArchA, 4R, Index, Widescreen")
+		initpage  = flag.Int("page", 1, "initial page")
+		pcolor    = flag.String("pencolor", "red", "annotation pen color")
+		psize     = flag.Float64("pensize", 0.5, "annotation pen size")
+		outline   = flag.Bool("outline", false, "print the outline of the deck: the title and text of each slide")
+		htmldir   = flag.String("html", "", "export slides as HTML pages and PNG images to this directory")
+		handout   = flag.String("handout", "", "export handouts, several slides to a page, to this PDF file (or PNG images, for a .png name)")
+		perpage   = flag.Int("perpage", 4, "slides on each handout page: 2, 4 or 6")
+		hnotes    = flag.Bool("handoutnotes", false, "print the notes beneath the slides on handouts")
+		hsize     = flag.String("handoutsize", "Letter", "handout page size (pages are printed upright)")
+		cbcheck   = flag.Bool("cbcheck", false, "warn about colors that are hard to distinguish with color blindness")
+		locale    = flag.String("locale", "", "locale for formatting numbers and dates (for example en-US, de-DE)")
+		safe      = flag.Float64("safe", 0, "margin (percent) on each side of the slide, for displays with overscan")
+		lbox      = flag.Bool("letterbox", false, "keep the deck's aspect ratio, letterboxing slides when the window is resized")
+		matte     = flag.String("matte", "black", "letterbox color")
+		wscale    = flag.Float64("scale", 1, "scale the initial window size")
+		maximize  = flag.Bool("maximize", false, "open the window maximized")
+		printsize = flag.Bool("printsize", false, "open the window at about the printed size of the page, taking a Dp as 1/160 inch")
+		maximage  = flag.Int("maximage", 0, "downscale images larger than this many pixels on the longest side (0 for no limit)")
+		dsh       = flag.Bool("decksh", false, "preprocess the input with decksh (the default for .dsh files)")
+		dshcmd    = flag.String("deckshcmd", "decksh", "decksh command")
+		follow    = flag.Bool("follow", false, "read a stream of decks from standard input, showing each as it arrives")
+		ctl       = flag.String("control", "", "accept commands (goto n, next, prev, first, last, reload, export, start, status) on this socket path or TCP address")
+		border    = flag.Bool("borderless", false, "open the window without decorations, for capture by streaming software")
+		chroma    = flag.String("chroma", "", "replace slide backgrounds with this chroma key color, for compositing")
+		timing    = flag.String("timing", "", "timing script: lines of slide number and time from the start (h:mm:ss)")
+		tstart    = flag.String("timingstart", "now", "start the timing script now, at a time of day (hh:mm:ss), or on trigger (shift-T, or the start command)")
+		watch     = flag.Bool("watch", false, "reload the deck when its file changes")
+		mfile     = flag.String("master", "", "draw the first slide of this deck beneath every slide ({page}, {pages} and {title} are filled in)")
+		pagenum   = flag.String("pagenum", "", "show slide numbers in this corner: tl, tc, tr, bl, bc or br")
+		pagefmt   = flag.String("pageformat", "{page}", "slide number format ({page} and {pages} are filled in)")
+		foot      = flag.String("footer", "", "footer text")
+		footpos   = flag.String("footercorner", "bl", "footer corner")
+		date      = flag.String("date", "", "show the date (the deck's, or today's) in this corner")
+		datefmt   = flag.String("datefmt", "", "date layout, as in Go's time package (default: the locale's)")
+		footfont  = flag.String("footerfont", "sans", "font of the slide numbers, footer and date")
+		footsize  = flag.Float64("footersize", 1.2, "size of the slide numbers, footer and date")
+		footcol   = flag.String("footercolor", "", "color of the slide numbers, footer and date (default: the slide's foreground)")
+		thumbsfl  = flag.Bool("thumbs", false, "show a strip of slide thumbnails when the pointer reaches the bottom of the window")
+		scroll    = flag.Bool("scroll", false, "lay the slides out one above the other, and scroll through them")
+		compare   = flag.String("compare", "", "show this deck beside the first, on the same slide, for reviewing edits")
+		emojifl   = flag.Bool("emoji", false, "replace :name: emoji shortcodes in text")
+		emfont    = flag.String("emojifont", "", "font file with the glyphs of emoji (implies -emoji)")
+		sansfont  = flag.String("sans", "", "TrueType or OpenType font files (separated by commas) for sans text (default: Go)")
+		seriffont = flag.String("serif", "", "TrueType or OpenType font files (separated by commas) for serif text (default: Go)")
+		monofont  = flag.String("mono", "", "TrueType or OpenType font files (separated by commas) for mono text and code (default: Go Mono)")
+		timer     = flag.Duration("timer", 0, "show a countdown of this length (for example 20m) over the slides; T toggles it, or a clock without a countdown")
 	)
 	flag.Parse()
+	pencolor = gc.ColorLookup(*pcolor)
+	decklocale = gc.LookupLocale(*locale)
+	pensize = float32(*psize)
+	gc.MaxImageSize = *maximage
+	safearea = float32(*safe)
+	letterbox = *lbox
+	winscale = float32(*wscale)
+	winmax = *maximize
+	printed = *printsize
+	mattecolor = gc.ColorLookup(*matte)
+	talktime = *timer
+	usedecksh = *dsh
+	following = *follow
+	controladdr = *ctl
+	borderless = *border
+	comparefile = *compare
+	watching = *watch
+	scrolling = *scroll
+	thumbstrip = *thumbsfl
+	pagecorner, pageformat = *pagenum, *pagefmt
+	footer, footercorner = *foot, *footpos
+	datecorner, dateformat = *date, *datefmt
+	footerfont, footersize, footercolor = *footfont, *footsize, *footcol
+	for _, corner := range []string{pagecorner, footercorner, datecorner} {
+		if err := checkcorner(corner); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *timing != "" {
+		var err error
+		if cues, err = loadtiming(*timing); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", *timing, err)
+			os.Exit(1)
+		}
+		if cuestart, err = starttime(*tstart, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *chroma != "" {
+		key := gc.ColorLookup(*chroma)
+		chromakey = &key
+	}
+	deckshcmd = *dshcmd
+	emoji = *emojifl || *emfont != ""
+	if *emfont != "" {
+		data, err := os.ReadFile(*emfont)
+		if err == nil {
+			err = gc.RegisterFont("emoji", data)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "emoji font: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := setfonts(*sansfont, *seriffont, *monofont); err != nil {
+		fmt.Fprintf(os.Stderr, "font: %v\n", err)
+		os.Exit(1)
+	}
+	showtimer = *timer > 0
 
 	// get the filename
 	var filename string
@@ -534,6 +885,67 @@ func main() {
 	if *title == "" {
 		*title = filename
 	}
+	if *mfile != "" {
+		width, height := pagedim(*pagesize)
+		m, err := loadmaster(*mfile, width, height)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		masterfile, master = *mfile, m
+	}
+	if *cbcheck && filename != "-" {
+		width, height := pagedim(*pagesize)
+		d, err := readDeck(filename, width, height)
+		if err == nil {
+			colorcheck(&d)
+		}
+	}
+	if *outline {
+		width, height := pagedim(*pagesize)
+		d, err := readDeck(filename, width, height)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if err := gc.WriteOutline(os.Stdout, &d); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *htmldir != "" {
+		width, height := pagedim(*pagesize)
+		d, meta, err := readslides(filename, width, height)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		graphics = meta.graphics
+		if err := exporthtml(&d, *title, *htmldir, width, height); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *handout != "" {
+		width, height := pagedim(*pagesize)
+		d, meta, err := readslides(filename, width, height)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		graphics = meta.graphics
+		pw, ph := pagedim(*hsize)
+		if pw > ph {
+			pw, ph = ph, pw
+		}
+		if err := exporthandout(&d, *handout, *perpage, *hnotes, pw, ph); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	go slidedeck(*title, *initpage, filename, *pagesize)
 	app.Main()
 }
@@ -542,7 +954,239 @@ var pressed bool
 var gridstate bool
 var slidenumber int
 
-func kbpointer(q event.Queue, ns int) {
+// annotation state: pen strokes drawn over each slide
+var annotating bool
+var annotations = map[int]*gc.Sketch{}
+var pencolor color.NRGBA
+var pensize float32
+
+// inkfile is where annotations are saved, so they are kept between runs
+var inkfile string
+
+// decklocale sets the formatting of numbers and dates
+var decklocale gc.Locale
+
+// safearea is the margin around slides
+var safearea float32
+
+// timer overlay: a countdown of the talk time, or a clock
+var talktime time.Duration
+var showtimer bool
+var countdown *widgets.Countdown
+
+// timeroverlay draws the countdown (or the time of day) in the lower right of the slide area,
+// returning when it next changes
+func timeroverlay(c *gc.Canvas, now time.Time, fg color.NRGBA) time.Time {
+	const size = 4
+	text := now.Format("15:04")
+	if talktime > 0 {
+		if countdown == nil {
+			countdown = widgets.NewCountdown(talktime, 0, 0, size, fg)
+		}
+		countdown.Color = fg
+		text = countdown.Text(now)
+	}
+	w := widgets.SegmentsWidth(size, text)
+	x, y := 97-w, float32(3)
+	bg := color.NRGBA{128, 128, 128, 64}
+	c.Rect(x+w/2, y+size*c.Width/c.Height/2, w+2, size*c.Width/c.Height+2, bg)
+	if countdown != nil {
+		countdown.X, countdown.Y = x, y
+		countdown.Draw(c, now)
+	} else {
+		widgets.Segments(c, x, y, size, text, fg, color.NRGBA{})
+	}
+	return now.Truncate(time.Second).Add(time.Second)
+}
+
+// toasts show status messages over the slides
+var toasts = widgets.NewToasts(gc.AnchorBottomLeft, 1.5)
+
+// exportslide asks for the current slide to be saved as a PNG image
+var exportslide bool
+
+// cropping mode: a selected region of the slide is saved as a PNG image
+var cropping bool
+var selection = widgets.NewSelection(color.NRGBA{0, 120, 215, 255})
+
+// picker chooses the annotation pen color, when shown
+var picker *widgets.ColorPicker
+
+// setpencolor changes the color of new annotation strokes
+func setpencolor(col color.NRGBA) {
+	pencolor = col
+	for _, sk := range annotations {
+		sk.Color = col
+	}
+}
+
+// eyedropper mode: the color of the slide under a click is shown
+var eyedropper bool
+var sample *gc.PointerEvent
+
+// colorname describes a color in hex and rgb form
+func colorname(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x rgb(%d,%d,%d)", c.R, c.G, c.B, c.R, c.G, c.B)
+}
+
+// redraw asks for another frame, for changes to the overlay
+var redraw bool
+
+// streaming: a window without decorations, and a chroma key color for slide backgrounds
+var borderless bool
+var chromakey *color.NRGBA
+
+// emoji replaces :name: shortcodes in text with emoji
+var emoji bool
+
+// initial window size: scale factor, printed page size or maximized. Gio does not report
+// the size or resolution of the display, so the window cannot be sized as a fraction of
+// it, or at the exact size of the page; -maximize fills the display instead.
+var winscale float32 = 1
+var printed, winmax bool
+
+// windowsize returns the initial window size for a page sized (width, height) points.
+// Points are used as Dp unless the printed size is wanted: a point is 1/72 inch, and a Dp
+// nominally 1/160 inch, as the system's scale factor makes it.
+func windowsize(width, height float32) (unit.Dp, unit.Dp) {
+	if printed {
+		width *= 160.0 / 72.0
+		height *= 160.0 / 72.0
+	}
+	if winscale > 0 {
+		width *= winscale
+		height *= winscale
+	}
+	return unit.Dp(width), unit.Dp(height)
+}
+
+// letterbox keeps the deck's aspect ratio, filling the rest of the window with the matte color
+var letterbox bool
+var mattecolor color.NRGBA
+
+// letterboxmargins returns the margins (percentages) that fit a slide with
+// the specified aspect ratio into a window of size (w, h)
+func letterboxmargins(w, h float32, aspect float64) (top, right, bottom, left float32) {
+	if !letterbox || aspect <= 0 || w <= 0 || h <= 0 {
+		return 0, 0, 0, 0
+	}
+	if float64(w/h) > aspect {
+		side := 50 * (1 - float32(aspect)*h/w)
+		return 0, side, 0, side
+	}
+	edge := 50 * (1 - w/(float32(aspect)*h))
+	return edge, 0, edge, 0
+}
+
+// slidemargins returns the margins around the slide area: the letterbox, and the safe area within it
+func slidemargins(w, h float32, aspect float64) (top, right, bottom, left float32) {
+	top, right, bottom, left = letterboxmargins(w, h, aspect)
+	sw := 100 - left - right
+	sh := 100 - top - bottom
+	return top + safearea*sh/100, right + safearea*sw/100, bottom + safearea*sh/100, left + safearea*sw/100
+}
+
+// slidearea fills the matte and slide background, and sets the canvas margins to the slide area
+func slidearea(c *gc.Canvas, bg string, aspect float64) {
+	top, right, bottom, left := slidemargins(c.Width, c.Height, aspect)
+	if top+right+bottom+left == 0 {
+		return
+	}
+	if bg == "" {
+		bg = "white"
+	}
+	bgcolor, matte := gc.ColorLookup(bg), mattecolor
+	if chromakey != nil {
+		bgcolor, matte = *chromakey, *chromakey
+	}
+	if letterbox {
+		c.Background(matte)
+		c.SetMargins(letterboxmargins(c.Width, c.Height, aspect))
+	}
+	c.Background(bgcolor)
+	c.SetMargins(top, right, bottom, left)
+}
+
+// sketch returns the annotations of slide n
+func sketch(n int) *gc.Sketch {
+	sk, ok := annotations[n]
+	if !ok {
+		sk = gc.NewSketch(pencolor, pensize)
+		annotations[n] = sk
+	}
+	return sk
+}
+
+// annotate records pen strokes on the current slide, saving them when a stroke is done
+func annotate(p gc.PointerEvent) {
+	sk := sketch(slidenumber)
+	sk.Input(p)
+	if p.Type == pointer.Release {
+		saveannotations()
+	}
+}
+
+// showannotations draws the pen strokes for a slide
+func showannotations(c *gc.Canvas, n int) {
+	if sk, ok := annotations[n]; ok {
+		sk.Draw(c)
+	}
+}
+
+// inkname returns the name of the file holding the annotations of a deck
+func inkname(filename string) string {
+	if filename == "-" {
+		return ""
+	}
+	return filename + ".ink.json"
+}
+
+// loadannotations reads saved annotations, keyed by slide number (from 1)
+func loadannotations() {
+	if inkfile == "" {
+		return
+	}
+	data, err := os.ReadFile(inkfile)
+	if err != nil {
+		return
+	}
+	var saved map[int]json.RawMessage
+	if err := json.Unmarshal(data, &saved); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", inkfile, err)
+		return
+	}
+	for n, raw := range saved {
+		if err := sketch(n - 1).ReadJSON(bytes.NewReader(raw)); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: slide %d: %v\n", inkfile, n, err)
+		}
+	}
+}
+
+// saveannotations writes the annotations, removing the file if there are none
+func saveannotations() {
+	if inkfile == "" {
+		return
+	}
+	saved := map[int]*gc.Sketch{}
+	for n, sk := range annotations {
+		if len(sk.Strokes) > 0 {
+			saved[n+1] = sk
+		}
+	}
+	if len(saved) == 0 {
+		os.Remove(inkfile)
+		return
+	}
+	data, err := json.Marshal(saved)
+	if err == nil {
+		err = os.WriteFile(inkfile, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", inkfile, err)
+	}
+}
+
+func kbpointer(q event.Queue, c *gc.Canvas, ns int) {
 	for _, ev := range q.Events(pressed) {
 		if k, ok := ev.(key.Event); ok {
 			switch k.State {
@@ -579,6 +1223,48 @@ func kbpointer(q event.Queue, ns int) {
 					slidenumber = ns
 				case "G":
 					gridstate = !gridstate
+				case "L": // toggle the outline sidebar
+					showoutline = !showoutline
+					redraw = true
+				case "T": // toggle the timer; with shift, start the timing script
+					if k.Modifiers.Contain(key.ModShift) && cues != nil {
+						cuestart, lastcue = time.Now(), -1
+						break
+					}
+					showtimer = !showtimer
+				case "D": // toggle annotation (drawing) mode
+					annotating = !annotating
+				case "X": // clear the annotations on this slide
+					sketch(slidenumber).Clear()
+					saveannotations()
+					toasts.Show(fmt.Sprintf("cleared the annotations on slide %d", slidenumber+1))
+				case "V": // show the next slide changed by the last reload
+					if n := nextchange(changes, slidenumber); n >= 0 {
+						slidenumber = n
+					}
+				case "S": // save the slide as a PNG image
+					exportslide = true
+				case "O": // toggle the pen color picker
+					if picker == nil {
+						picker = widgets.NewColorPicker(45, 50, 15, pencolor)
+					} else {
+						picker = nil
+					}
+					redraw = true
+				case "I": // toggle the eyedropper: click to show the color under the pointer
+					eyedropper = !eyedropper
+				case "C": // toggle cropping: drag out a region of the slide to save as a PNG image
+					cropping = !cropping
+					selection.Clear()
+					redraw = true
+				case "U": // undo the last annotation stroke
+					if sketch(slidenumber).Undo() {
+						saveannotations()
+					}
+				case "R": // redo an undone stroke
+					if sketch(slidenumber).Redo() {
+						saveannotations()
+					}
 				case key.NameSpace, "⏎":
 					if k.Modifiers == 0 {
 						slidenumber++
@@ -595,6 +1281,61 @@ func kbpointer(q event.Queue, ns int) {
 			}
 		}
 		if p, ok := ev.(pointer.Event); ok {
+			if p.Type == pointer.Scroll {
+				scrolly += p.Scroll.Y
+				scrolled = time.Now()
+				continue
+			}
+			// reveal the thumbnail strip at the bottom edge; click a thumbnail to show its slide
+			if thumbstrip {
+				// the pointer is relative to the slide area of the canvas; the strip is placed in the window
+				ox, oy := c.Origin()
+				x, y := p.Position.X+ox, p.Position.Y+oy
+				shown := p.Type != pointer.Leave && instrip(y)
+				if shown != thumbshown {
+					thumbshown = shown
+					redraw = true
+				}
+				if thumbshown && p.Type == pointer.Press {
+					if n := thumbat(x, y); n >= 0 {
+						slidenumber = n
+					}
+					continue
+				}
+			}
+			// click a title in the outline sidebar to show its slide
+			if showoutline && p.Type == pointer.Press {
+				if pe := c.PointerEvent(p); pe.X <= outlinewidth {
+					if n := outlineat(c, pe.X, pe.Y); n >= 0 {
+						slidenumber = n
+					}
+					continue
+				}
+			}
+			if picker != nil {
+				if picker.Input(c, c.PointerEvent(p)) {
+					setpencolor(picker.Color())
+					redraw = true
+				}
+				continue
+			}
+			if eyedropper {
+				if p.Type == pointer.Press {
+					pe := c.PointerEvent(p)
+					sample = &pe
+				}
+				continue
+			}
+			if cropping {
+				if selection.Input(c.PointerEvent(p)) {
+					redraw = true
+				}
+				continue
+			}
+			if annotating && !scrolling {
+				annotate(c.PointerEvent(p))
+				continue
+			}
 			switch p.Type {
 			case pointer.Press:
 				switch p.Buttons {
@@ -612,9 +1353,69 @@ func kbpointer(q event.Queue, ns int) {
 
 }
 
+// viewstate is what is shown in the window; the slide is only redrawn when it changes
+type viewstate struct {
+	slide, ink  int
+	grid, timer bool
+	size        image.Point
+	scroll      float32
+}
+
+// currentview returns the current view state for a window of the specified size
+func currentview(size image.Point) viewstate {
+	ink := 0
+	if sk, ok := annotations[slidenumber]; ok {
+		ink = sk.Revision()
+	}
+	return viewstate{slide: slidenumber, ink: ink, grid: gridstate, timer: showtimer, size: size, scroll: scrolly}
+}
+
+// deckaspect returns the aspect ratio of a deck's canvas, 0 if unknown
+func deckaspect(d *deck.Deck) float64 {
+	if d.Canvas.Height <= 0 {
+		return 0
+	}
+	return float64(d.Canvas.Width) / float64(d.Canvas.Height)
+}
+
+// newdeck prepares to show a replacement deck, keeping the slide number if possible.
+// It returns the number of the last slide, and the aspect ratio.
+func newdeck(d *deck.Deck) (int, float64) {
+	nslides := len(d.Slide) - 1
+	setthumbs(d)
+	settitles(d)
+	if slidenumber > nslides {
+		slidenumber = nslides
+	}
+	return nslides, deckaspect(d)
+}
+
 func slidedeck(s string, initpage int, filename, pagesize string) {
 	width, height := pagedim(pagesize)
-	deck, err := readDeck(filename, width, height)
+	w := app.NewWindow(app.Title(s), app.Size(windowsize(width, height)))
+	if winmax {
+		w.Option(app.Maximized.Option())
+	}
+	if borderless {
+		w.Option(app.Decorated(false))
+	}
+	var updates <-chan deck.Deck
+	var other *deck.Deck
+	var deck deck.Deck
+	var err error
+	if following {
+		// the first deck on standard input is shown, then replaced by those that follow
+		updates = followdecks(os.Stdin, int(width), int(height), w.Invalidate)
+		var ok bool
+		if deck, ok = <-updates; !ok {
+			fmt.Fprintln(os.Stderr, "no deck on standard input")
+			os.Exit(1)
+		}
+	} else {
+		var meta deckmeta
+		deck, meta, err = readslides(filename, width, height)
+		aspects, entries, graphics, panzooms = meta.aspects, meta.entries, meta.graphics, meta.panzooms
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
@@ -626,28 +1427,378 @@ func slidedeck(s string, initpage int, filename, pagesize string) {
 	}
 	slidenumber = initpage - 1
 	gridstate = false
-	w := app.NewWindow(app.Title(s), app.Size(unit.Dp(width), unit.Dp(height)))
+	inkfile = inkname(filename)
+	loadannotations()
+	aspect := deckaspect(&deck)
+	setthumbs(&deck)
+	if thumbstrip {
+		startthumbs(w.Invalidate)
+	}
+	settitles(&deck)
+	// decks that set the shapes of their slides are letterboxed
+	if aspects != nil || math.Abs(aspect-float64(width)/float64(height)) > 0.01 {
+		letterbox = true
+	}
+
+	// the deck to compare with, navigated together with this one
+	if comparefile != "" {
+		d, meta, err := readslides(comparefile, width, height)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		other, othergraphics = &d, meta.graphics
+		if n := len(d.Slide) - 1; n > nslides {
+			nslides = n
+		}
+	}
+
+	// decode the images in the background, showing progress
+	images := deckimages(&deck)
+	var imagesdone int32
+	go gc.PreloadImages(images, runtime.NumCPU(), func(done, total int) {
+		atomic.StoreInt32(&imagesdone, int32(done))
+		w.Invalidate()
+	})
+
+	// commands from the control socket
+	var controls chan control
+	if controladdr != "" {
+		controls = make(chan control, controlqueue)
+		if err := listencontrol(controladdr, controls, w.Invalidate); err != nil {
+			fmt.Fprintf(os.Stderr, "control: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var drawn viewstate
+	var canvas *gc.Canvas
+	var slidecall op.CallOp
+	var overlay *gc.Canvas
+	var wasentering bool
+	frame := new(op.Ops)
+
+	// exportpng saves the current slide as a PNG image
+	exportpng := func(name string) error {
+		return capture.WritePNG(renderslide(&deck, slidenumber, width, height), name)
+	}
+
+	// reloaddeck reads the deck (and its master, and the one compared with it)
+	// again, noting which slides changed
+	reloaddeck := func() error {
+		d, meta, err := readslides(filename, width, height)
+		if err != nil {
+			return err
+		}
+		aspects, entries, graphics, panzooms = meta.aspects, meta.entries, meta.graphics, meta.panzooms
+		if masterfile != "" {
+			m, err := loadmaster(masterfile, width, height)
+			if err != nil {
+				return err
+			}
+			master = m
+		}
+		changes = changedslides(&deck, &d)
+		deck = d
+		nslides, aspect = newdeck(&deck)
+		if other != nil {
+			od, meta, err := readslides(comparefile, width, height)
+			if err != nil {
+				return err
+			}
+			other, othergraphics = &od, meta.graphics
+			if n := len(od.Slide) - 1; n > nslides {
+				nslides = n
+			}
+		}
+		canvas = nil
+		switch len(changes) {
+		case 0:
+			toasts.Show("reloaded: no slides changed")
+		case 1:
+			toasts.Show(fmt.Sprintf("reloaded: slide %d changed (V to show it)", changes[0]+1))
+		default:
+			toasts.Show(fmt.Sprintf("reloaded: %d slides changed (V to show them)", len(changes)))
+		}
+		return nil
+	}
+
+	// reload when the file changes
+	reloads := make(chan bool, 1)
+	if watching && !following && filename != "-" {
+		go watchfile(filename, time.Second, func() {
+			select {
+			case reloads <- true:
+			default:
+			}
+			w.Invalidate()
+		})
+	}
+
+	// docontrol carries out a command from the control socket
+	docontrol := func(c control) controlreply {
+		switch c.name {
+		case "goto":
+			if len(c.args) < 1 {
+				return controlreply{err: fmt.Errorf("goto: missing slide number")}
+			}
+			n, err := strconv.Atoi(c.args[0])
+			if err != nil || n < 1 || n > nslides+1 {
+				return controlreply{err: fmt.Errorf("goto: bad slide number %q", c.args[0])}
+			}
+			slidenumber = n - 1
+		case "next":
+			slidenumber++
+		case "prev", "previous":
+			slidenumber--
+		case "first":
+			slidenumber = 0
+		case "last":
+			slidenumber = nslides
+		case "reload":
+			if following {
+				return controlreply{err: fmt.Errorf("reload: decks are read from standard input")}
+			}
+			if err := reloaddeck(); err != nil {
+				return controlreply{err: err}
+			}
+		case "export":
+			var arg string
+			if len(c.args) > 0 {
+				arg = c.args[0]
+			}
+			name, err := exportname(filename, arg, slidename(slidenumber)+".png")
+			if err != nil {
+				return controlreply{err: err}
+			}
+			if err := exportpng(name); err != nil {
+				return controlreply{err: err}
+			}
+			return controlreply{result: name}
+		case "start": // start the timing script
+			if cues == nil {
+				return controlreply{err: fmt.Errorf("start: no timing script")}
+			}
+			cuestart, lastcue = time.Now(), -1
+		case "status":
+		default:
+			return controlreply{err: fmt.Errorf("unknown command %q", c.name)}
+		}
+		if slidenumber > nslides {
+			slidenumber = 0
+		}
+		if slidenumber < 0 {
+			slidenumber = nslides
+		}
+		return controlreply{result: fmt.Sprintf("slide %d of %d", slidenumber+1, nslides+1)}
+	}
 	for {
 		ev := <-w.Events()
 		switch e := ev.(type) {
 		case system.DestroyEvent:
 			os.Exit(0)
 		case system.FrameEvent:
-			canvas := gc.NewCanvas(float32(e.Size.X), float32(e.Size.Y), system.FrameEvent{})
-			key.InputOp{Tag: pressed}.Add(canvas.Context.Ops)
-			pointer.InputOp{Tag: pressed, Grab: false, Types: pointer.Press}.Add(canvas.Context.Ops)
+			// in follow mode, show the latest deck, keeping the slide number if possible
+			select {
+			case d, ok := <-updates:
+				if ok {
+					changes = changedslides(&deck, &d)
+					deck, aspects, entries, graphics, panzooms = d, nil, nil, nil, nil
+					nslides, aspect = newdeck(&deck)
+					canvas = nil
+				} else {
+					updates = nil
+				}
+			default:
+			}
+			select {
+			case <-reloads:
+				if err := reloaddeck(); err != nil {
+					toasts.Show(fmt.Sprintf("reload: %v", err))
+				}
+			default:
+			}
+			for pending := true; pending; {
+				select {
+				case c := <-controls:
+					c.reply <- docontrol(c)
+				default:
+					pending = false
+				}
+			}
+			// follow the timing script; between cues, the slides can be changed by hand
+			var nextcue time.Time
+			if cues != nil && !cuestart.IsZero() {
+				if elapsed := e.Now.Sub(cuestart); elapsed < 0 {
+					nextcue = cuestart
+				} else {
+					i, next := cueat(cues, elapsed)
+					if i >= 0 && i != lastcue {
+						slidenumber, lastcue = cues[i].slide, i
+					}
+					if next > 0 {
+						nextcue = cuestart.Add(next)
+					}
+				}
+			}
 			if slidenumber > nslides {
 				slidenumber = 0
 			}
 			if slidenumber < 0 {
 				slidenumber = nslides
 			}
-			showslide(canvas, &deck, slidenumber)
-			if gridstate {
-				ngrid(canvas, 5, 1, gc.ColorLookup(deck.Slide[slidenumber].Fg))
+			aspect = slideaspect(&deck, slidenumber)
+			if done := int(atomic.LoadInt32(&imagesdone)); done < len(images) {
+				lc := gc.NewCanvas(float32(e.Size.X), float32(e.Size.Y), system.FrameEvent{})
+				loading(lc, done, len(images))
+				e.Frame(lc.Context.Ops)
+				continue
+			}
+			// the elements of a newly shown slide enter, and its images pan, redrawn every frame until they are in place
+			inentry := !scrolling && (entering(slidenumber, e.Now) || panning(slidenumber, e.Now))
+			if inentry || wasentering {
+				canvas = nil
+			}
+			wasentering = inentry
+			// when scrolling, follow the pointer, then settle on the current slide
+			var moving bool
+			var tops []float32
+			if scrolling {
+				var total float32
+				tops, total = slidetops(&deck, float32(e.Size.X))
+				moving = scrollstep(e.Now, tops, total, float32(e.Size.Y))
+			}
+			// only rebuild the slide when something changed, otherwise replay it
+			if state := currentview(e.Size); scrolling && (state != drawn || canvas == nil) {
+				canvas = gc.NewCanvas(float32(e.Size.X), float32(e.Size.Y), system.FrameEvent{})
+				canvas.Semantic = true
+				canvas.Locale = decklocale
+				m := op.Record(canvas.Context.Ops)
+				showscroll(canvas, &deck, tops)
+				slidecall = m.Stop()
+				drawn = state
+			} else if state != drawn || canvas == nil {
+				// when comparing, the deck takes the left half of the window
+				cw := float32(e.Size.X)
+				if other != nil {
+					cw /= 2
+				}
+				canvas = gc.NewCanvas(cw, float32(e.Size.Y), system.FrameEvent{})
+				canvas.Semantic = true
+				canvas.Locale = decklocale
+				m := op.Record(canvas.Context.Ops)
+				slidearea(canvas, slidebg(&deck, slidenumber), aspect)
+				if inentry {
+					enternow = e.Now
+				}
+				showslide(canvas, &deck, slidenumber)
+				enternow = time.Time{}
+				showannotations(canvas, slidenumber)
+				if gridstate {
+					ngrid(canvas, 5, 1, gc.ColorLookup(slidefg(&deck, slidenumber)))
+				}
+				canvas.ClearMargins()
+				if other != nil {
+					showcompare(canvas, other, slidenumber, cw)
+				}
+				slidecall = m.Stop()
+				drawn = state
+			}
+			frame.Reset()
+			if !nextcue.IsZero() {
+				op.InvalidateOp{At: nextcue}.Add(frame)
+			}
+			if moving || inentry {
+				op.InvalidateOp{}.Add(frame)
+			}
+			key.InputOp{Tag: pressed}.Add(frame)
+			// pointer positions are relative to the slide area, whose margins are set on the
+			// canvas while the frame's input is handled
+			if !scrolling {
+				canvas.SetMargins(slidemargins(canvas.Width, canvas.Height, aspect))
+			}
+			ox, oy := canvas.Origin()
+			inset := op.Affine(f32.Affine2D{}.Offset(f32.Pt(ox, oy))).Push(frame)
+			input := pointer.InputOp{Tag: pressed, Grab: false, Types: pointer.Press | pointer.Drag | pointer.Release}
+			if thumbstrip {
+				setthumbarea(float32(e.Size.X), float32(e.Size.Y), aspect)
+				input.Types |= pointer.Move | pointer.Leave
+			}
+			if scrolling {
+				input.Types |= pointer.Scroll
+				input.ScrollBounds = image.Rect(0, -1<<20, 0, 1<<20)
+			}
+			input.Add(frame)
+			inset.Pop()
+			slidecall.Add(frame)
+			// the timer and messages are drawn over the slide every frame
+			if showtimer || toasts.Active() || cropping || picker != nil || thumbshown || showoutline {
+				if overlay == nil {
+					overlay = gc.NewCanvas(float32(e.Size.X), float32(e.Size.Y), system.FrameEvent{})
+				}
+				overlay.Reset(float32(e.Size.X), float32(e.Size.Y), system.FrameEvent{})
+				m := op.Record(overlay.Context.Ops)
+				overlay.SetMargins(slidemargins(overlay.Width, overlay.Height, aspect))
+				if showtimer {
+					next := timeroverlay(overlay, e.Now, gc.ColorLookup(slidefg(&deck, slidenumber)))
+					op.InvalidateOp{At: next}.Add(frame)
+				}
+				if showoutline {
+					showsidebar(overlay)
+				}
+				if thumbshown {
+					// thumbnails are rendered in the background, and shown as they are ready
+					queuethumbs(&deck)
+					showthumbs(overlay)
+				}
+				selection.Draw(overlay)
+				if picker != nil {
+					picker.Draw(overlay)
+				}
+				if toasts.Draw(overlay, e.Now) {
+					op.InvalidateOp{}.Add(frame)
+				}
+				overlay.ClearMargins()
+				m.Stop().Add(frame)
+			}
+			kbpointer(e.Queue, canvas, nslides)
+			canvas.ClearMargins()
+			if exportslide {
+				exportslide = false
+				name := slidename(slidenumber) + ".png"
+				if err := exportpng(name); err != nil {
+					toasts.Show(fmt.Sprintf("export failed: %v", err))
+				} else {
+					toasts.Show(fmt.Sprintf("exported slide %d to %s", slidenumber+1, name))
+				}
+				w.Invalidate()
+			}
+			if sample != nil {
+				col, err := capture.ColorAt(renderslide(&deck, slidenumber, width, height), sample.X, sample.Y)
+				if err != nil {
+					toasts.Show(fmt.Sprintf("eyedropper: %v", err))
+				} else {
+					fmt.Printf("%.1f %.1f %s\n", sample.X, sample.Y, colorname(col))
+					toasts.Show(fmt.Sprintf("(%.1f, %.1f): %s", sample.X, sample.Y, colorname(col)))
+				}
+				sample = nil
+				redraw = true
+			}
+			if b, ok := selection.Done(); ok {
+				cropping = false
+				name := slidename(slidenumber) + "-crop.png"
+				if err := capture.WritePNGRegion(renderslide(&deck, slidenumber, width, height), b, name); err != nil {
+					toasts.Show(fmt.Sprintf("export failed: %v", err))
+				} else {
+					toasts.Show(fmt.Sprintf("exported part of slide %d to %s", slidenumber+1, name))
+				}
+				redraw = true
+			}
+			e.Frame(frame)
+			if currentview(e.Size) != drawn || redraw {
+				redraw = false
+				w.Invalidate()
 			}
-			kbpointer(e.Queue, nslides)
-			e.Frame(canvas.Context.Ops)
 		}
 	}
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Graphic attributes: rotation (degrees) and scale (percent) of graphic elements,
+// about their centers (text, lists and images have rotation of their own), and
+// gradient fills of rects, ellipses and polygons, as pdfdeck draws them.
+//
+// <rect xp="50" yp="50" wp="20" hp="10" rotation="30" scale="150"/>
+// <ellipse xp="50" yp="50" wp="20" hp="10" gradcolor1="red" gradcolor2="blue" gp="50"/>
+
+// graphic is the rotation and scale of an element, and its gradient fill, if any:
+// from gradcolor1 to gradcolor2 along gradangle (degrees counter-clockwise from left
+// to right; the default, 270, runs top to bottom), the colors changing across the
+// percentage gp of the element
+type graphic struct {
+	rotation, scale        float64
+	gradcolor1, gradcolor2 string
+	gradangle, gradpercent float64
+}
+
+// slidegraphics are the graphic attributes of a slide's graphic elements, by kind and index
+type slidegraphics map[string][]graphic
+
+// graphics are the graphic attributes of the slides of the deck shown (nil for slides without)
+var graphics []slidegraphics
+
+// gradientkinds are the kinds of element that may have gradient fills
+var gradientkinds = map[string]bool{"rect": true, "ellipse": true, "polygon": true}
+
+// parsegraphics reads the graphic attributes in deck markup, returning nil if there are none
+func parsegraphics(markup []byte) ([]slidegraphics, error) {
+	var m elementmarkup
+	err := xml.Unmarshal(markup, &m)
+	if err != nil {
+		return nil, err
+	}
+	var list []slidegraphics
+	for i := range m.Slide {
+		tf := slidegraphics{}
+		attributed := false
+		for k, attrs := range m.elements(i) {
+			switch entrykinds[k] {
+			case "image", "text", "list":
+				continue
+			}
+			for _, a := range attrs {
+				g := graphic{rotation: a.Rotation, scale: a.Scale}
+				if gradientkinds[entrykinds[k]] && a.Gradcolor1 != "" && a.Gradcolor2 != "" {
+					g.gradcolor1, g.gradcolor2 = a.Gradcolor1, a.Gradcolor2
+					g.gradpercent = a.GradPercent
+					g.gradangle = 270
+					if a.GradAngle != "" {
+						if g.gradangle, err = strconv.ParseFloat(a.GradAngle, 64); err != nil {
+							return nil, fmt.Errorf("slide %d: bad gradangle %q", i+1, a.GradAngle)
+						}
+					}
+				}
+				if g.transformed() || g.gradcolor1 != "" {
+					attributed = true
+				}
+				tf[entrykinds[k]] = append(tf[entrykinds[k]], g)
+			}
+		}
+		if attributed {
+			if list == nil {
+				list = make([]slidegraphics, len(m.Slide))
+			}
+			list[i] = tf
+		}
+	}
+	return list, nil
+}
+
+// slidegraphic returns the graphic attributes of slide n of the deck shown
+func slidegraphic(n int) slidegraphics {
+	if n < 0 || n >= len(graphics) {
+		return nil
+	}
+	return graphics[n]
+}
+
+// apply rotates and scales element i of the kind about (x, y) (percentages),
+// returning a function that ends the transform
+func (tf slidegraphics) apply(doc *gc.Canvas, kind string, i int, x, y float64) func() {
+	if i >= len(tf[kind]) {
+		return func() {}
+	}
+	t := tf[kind][i]
+	if !t.transformed() {
+		return func() {}
+	}
+	center := f32.Pt(float32(pct(x, float64(doc.Width))), float32(pct(100-y, float64(doc.Height))))
+	m := f32.Affine2D{}
+	if t.scale != 0 {
+		s := float32(t.scale / 100)
+		m = m.Scale(center, f32.Pt(s, s))
+	}
+	m = m.Rotate(center, float32(radians(t.rotation)))
+	return op.Affine(m).Push(doc.Context.Ops).Pop
+}
+
+// transformed reports whether the element is rotated or scaled
+func (g graphic) transformed() bool {
+	return g.rotation != 0 || (g.scale != 0 && g.scale != 100)
+}
+
+// gradient returns the attributes of element i of the kind, if it has a gradient fill
+func (tf slidegraphics) gradient(kind string, i int) (graphic, bool) {
+	if i >= len(tf[kind]) || tf[kind][i].gradcolor1 == "" {
+		return graphic{}, false
+	}
+	return tf[kind][i], true
+}
+
+// deckgradient returns the gradient pdfdeck fills a box, w by h (absolute), with: from
+// c1, gp percent of the way up its left side, toward c2 at its top right corner
+func deckgradient(w, h float64, c1, c2 color.NRGBA, gp float64) gc.LinearGradient {
+	gp = math.Max(0, math.Min(gp/100, 1))
+	dx, dy := w, (1-gp)*h
+	l := math.Hypot(dx, dy)
+	// the gradient runs from the lower left to the upper right corner; the colors
+	// start at their places along it
+	ux, uy := dx/l, dy/l
+	start := gp * h * uy / (w*ux + h*uy)
+	return gc.LinearGradient{
+		Angle: math.Atan2(dy, dx) * 180 / math.Pi,
+		Stops: []gc.GradientStop{{Offset: float32(start), Color: c1}, {Offset: 1, Color: c2}},
+	}
+}
+
+// fill returns the gradient fill of an element at the opacity
+func (g graphic) fill(opacity float64) gc.LinearGradient {
+	c1, c2 := gc.ColorLookup(g.gradcolor1), gc.ColorLookup(g.gradcolor2)
+	c1.A, c2.A = setop(opacity), setop(opacity)
+	span := g.gradpercent / 100
+	if span <= 0 || span > 1 {
+		span = 1
+	}
+	return gc.LinearGradient{Angle: g.gradangle, Stops: []gc.GradientStop{{Offset: 0, Color: c1}, {Offset: float32(span), Color: c2}}}
+}
+
+// dogradrect draws a rectangle centered at (x, y) with a gradient fill
+func dogradrect(doc *gc.Canvas, x, y, w, h float64, g graphic, opacity float64) {
+	doc.CenterRectGradient(float32(x), float32(y), float32(w), float32(h), g.fill(opacity))
+}
+
+// dogradellipse draws an ellipse with a gradient fill
+func dogradellipse(doc *gc.Canvas, x, y, w, h float64, g graphic, opacity float64) {
+	doc.EllipseGradient(float32(x), float32(y), float32(w/2), float32(h/2), g.fill(opacity))
+}
+
+// dogradpoly draws a polygon with a gradient fill
+func dogradpoly(doc *gc.Canvas, xc, yc string, g graphic, opacity float64) {
+	px, py := polypoints(xc, yc)
+	if len(px) < 3 {
+		return
+	}
+	doc.PolygonGradient(px, py, g.fill(opacity))
+}
+
+// polycenter returns the center of a polygon's points (space-separated percentages)
+func polycenter(xc, yc string) (float64, float64) {
+	mean := func(s string) float64 {
+		var sum float64
+		f := strings.Fields(s)
+		for _, v := range f {
+			p, _ := strconv.ParseFloat(v, 64)
+			sum += p
+		}
+		if len(f) == 0 {
+			return 0
+		}
+		return sum / float64(len(f))
+	}
+	return mean(xc), mean(yc)
+}
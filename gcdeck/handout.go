@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+
+	"gioui.org/io/system"
+	"gioui.org/op"
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/capture"
+)
+
+// Handouts: pages of two, four or six slides, with their notes, for printing
+
+// handoutdpi is the resolution of handout pages
+const handoutdpi = 150
+
+// handoutgrid returns the columns and rows of a page of n slides: 2, 4 or 6
+func handoutgrid(n int) (cols, rows int, err error) {
+	switch n {
+	case 2:
+		return 1, 2, nil
+	case 4:
+		return 2, 2, nil
+	case 6:
+		return 2, 3, nil
+	}
+	return 0, 0, fmt.Errorf("%d slides per handout page: use 2, 4 or 6", n)
+}
+
+// handoutslide draws slide n of the deck on the page, w by h pixels, with its top left at (x, y)
+func handoutslide(page *gc.Canvas, d *deck.Deck, n int, x, y, w, h float32) {
+	page.AbsRect(x-1, y-1, w+2, h+2, gc.ColorLookup("gray"))
+	sc := gc.NewCanvas(w, h, system.FrameEvent{})
+	sc.Locale = decklocale
+	m := op.Record(sc.Context.Ops)
+	showslide(sc, d, n)
+	call := m.Stop()
+	stack := op.Offset(image.Pt(int(x), int(y))).Push(page.Context.Ops)
+	call.Add(page.Context.Ops)
+	stack.Pop()
+}
+
+// exporthandout writes the slides, perpage to a page of pagewidth by pageheight points,
+// with their notes beneath them if notes is set, to the named PDF document, or as PNG
+// images named after it (handout.png makes handout-1.png, handout-2.png, ...)
+func exporthandout(d *deck.Deck, filename string, perpage int, notes bool, pagewidth, pageheight float32) error {
+	cols, rows, err := handoutgrid(perpage)
+	if err != nil {
+		return err
+	}
+	key := chromakey
+	chromakey = nil
+	defer func() { chromakey = key }()
+
+	pw, ph := pagewidth*handoutdpi/72, pageheight*handoutdpi/72
+	margin, gap := pw*0.06, pw*0.04
+	cellw := (pw - 2*margin - float32(cols-1)*gap) / float32(cols)
+	cellh := (ph - 2*margin - float32(rows-1)*gap) / float32(rows)
+
+	// the slides fill their cells, leaving room for the notes
+	room := cellh
+	if notes {
+		room = cellh * 0.7
+	}
+	sw, sh := cellw, cellw
+	if aspect := float32(deckaspect(d)); aspect > 0 {
+		sh = sw / aspect
+		if sh > room {
+			sh, sw = room, room*aspect
+		}
+	}
+	textsize := pw * 0.012
+	fg := gc.ColorLookup("black")
+
+	pdf := strings.EqualFold(filepath.Ext(filename), ".pdf")
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	var pages []image.Image
+	pn := 0
+	for first := 0; first < len(d.Slide); first += perpage {
+		page := gc.NewCanvas(pw, ph, system.FrameEvent{})
+		page.Background(gc.ColorLookup("white"))
+		for i := 0; i < perpage && first+i < len(d.Slide); i++ {
+			n := first + i
+			cx := margin + float32(i%cols)*(cellw+gap)
+			cy := margin + float32(i/cols)*(cellh+gap)
+			handoutslide(page, d, n, cx+(cellw-sw)/2, cy, sw, sh)
+			if note := strings.TrimSpace(d.Slide[n].Note); notes && note != "" {
+				page.AbsTextWrap(cx, cy+sh+textsize*2.5, textsize, cellw, note, fg)
+			}
+		}
+		pn++
+		page.TextMid(50, 2, 1.2, fmt.Sprintf("%d", pn), gc.ColorLookup("gray"))
+		if pdf {
+			img, err := capture.Image(page)
+			if err != nil {
+				return err
+			}
+			pages = append(pages, img)
+			continue
+		}
+		if err := capture.WritePNG(page, fmt.Sprintf("%s-%d.png", base, pn)); err != nil {
+			return err
+		}
+	}
+	if pdf {
+		return capture.WritePDF(pages, filename, float64(pagewidth), float64(pageheight))
+	}
+	return nil
+}
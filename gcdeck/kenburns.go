@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Ken Burns: images that pan and zoom slowly across a photo once their slide is shown.
+// kenburns is the part of the image shown at the start, then at the end, each as
+// x,y,w,h (percentages of the image, lower left at x,y); kbtime is how long the move takes.
+//
+// <image name="harbor.jpg" xp="50" yp="50" width="1200" height="675" kenburns="0,0,100,100 40,30,50,50" kbtime="12s"/>
+
+// kbduration is how long images pan when kbtime is not given
+const kbduration = 10 * time.Second
+
+// kenburns is the move of an image: from one part of it to another, over the duration
+type kenburns struct {
+	from, to gc.Box
+	duration time.Duration
+}
+
+// slidepanzooms are the moves of a slide's images, by index
+type slidepanzooms map[int]kenburns
+
+// panzooms are the moves of the images of the slides of the deck shown (nil for slides without)
+var panzooms []slidepanzooms
+
+// parsebox reads a box as x,y,w,h
+func parsebox(s string) (gc.Box, error) {
+	f := strings.Split(s, ",")
+	if len(f) != 4 {
+		return gc.Box{}, fmt.Errorf("%q: want x,y,w,h", s)
+	}
+	var v [4]float32
+	for i := range f {
+		n, err := strconv.ParseFloat(strings.TrimSpace(f[i]), 32)
+		if err != nil {
+			return gc.Box{}, fmt.Errorf("%q: %v", s, err)
+		}
+		v[i] = float32(n)
+	}
+	if v[2] <= 0 || v[3] <= 0 {
+		return gc.Box{}, fmt.Errorf("%q: empty box", s)
+	}
+	return gc.Box{X: v[0], Y: v[1], W: v[2], H: v[3]}, nil
+}
+
+// parsepanzooms reads the image moves in deck markup, returning nil if there are none
+func parsepanzooms(markup []byte) ([]slidepanzooms, error) {
+	var m elementmarkup
+	if err := xml.Unmarshal(markup, &m); err != nil {
+		return nil, err
+	}
+	var list []slidepanzooms
+	for i, s := range m.Slide {
+		for j, a := range s.Image {
+			if a.KenBurns == "" {
+				continue
+			}
+			boxes := strings.Fields(a.KenBurns)
+			if len(boxes) != 2 {
+				return nil, fmt.Errorf("slide %d: kenburns %q: want two boxes", i+1, a.KenBurns)
+			}
+			kb := kenburns{duration: kbduration}
+			var err error
+			if kb.from, err = parsebox(boxes[0]); err != nil {
+				return nil, fmt.Errorf("slide %d: kenburns %v", i+1, err)
+			}
+			if kb.to, err = parsebox(boxes[1]); err != nil {
+				return nil, fmt.Errorf("slide %d: kenburns %v", i+1, err)
+			}
+			if a.KBTime != "" {
+				if kb.duration, err = parsetimestamp(a.KBTime); err != nil {
+					return nil, fmt.Errorf("slide %d: kbtime: %v", i+1, err)
+				}
+			}
+			if list == nil {
+				list = make([]slidepanzooms, len(m.Slide))
+			}
+			if list[i] == nil {
+				list[i] = slidepanzooms{}
+			}
+			list[i][j] = kb
+		}
+	}
+	return list, nil
+}
+
+// panning reports whether the images of slide n, shown since enterstart, are still moving at now
+func panning(n int, now time.Time) bool {
+	if n != entered || n >= len(panzooms) {
+		return false
+	}
+	for _, kb := range panzooms[n] {
+		if now.Sub(enterstart) < kb.duration {
+			return true
+		}
+	}
+	return false
+}
+
+// imageview returns the part of image i of slide n shown as it is drawn, if the image moves:
+// where it has moved to while the slide is entering, where it ends after that, and where
+// it starts on slides not shown
+func imageview(n, i int) (gc.Box, bool) {
+	if n < 0 || n >= len(panzooms) {
+		return gc.Box{}, false
+	}
+	kb, ok := panzooms[n][i]
+	if !ok {
+		return gc.Box{}, false
+	}
+	switch {
+	case n != entered:
+		return kb.from, true
+	case enternow.IsZero():
+		return kb.to, true
+	}
+	pz := gc.PanZoom{From: kb.from, To: kb.to, Tween: gc.Tween{Start: enterstart, Duration: kb.duration}}
+	return pz.View(enternow), true
+}
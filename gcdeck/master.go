@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/deck"
+)
+
+// Master slides: the elements of the first slide of a master deck (a logo, footer,
+// page number or background) are drawn beneath every slide. In the master's text,
+// {page}, {pages} and {title} are replaced by the slide number, the number of
+// slides and the deck title.
+
+// masterfile names the master deck, if any, and master is its first slide
+var masterfile string
+var master *deck.Slide
+
+// loadmaster reads the master slide, the first slide of the deck in the file
+func loadmaster(filename string, w, h float32) (*deck.Slide, error) {
+	d, err := readDeck(filename, w, h)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Slide) == 0 {
+		return nil, fmt.Errorf("%s: no slides", filename)
+	}
+	return &d.Slide[0], nil
+}
+
+// withmaster returns the slide with the colors and gradient it leaves
+// unspecified taken from the master
+func withmaster(slide deck.Slide) deck.Slide {
+	if master == nil {
+		return slide
+	}
+	if slide.Bg == "" && slide.Gradcolor1 == "" {
+		slide.Bg = master.Bg
+		slide.Gradcolor1, slide.Gradcolor2, slide.GradPercent = master.Gradcolor1, master.Gradcolor2, master.GradPercent
+	}
+	if slide.Fg == "" {
+		slide.Fg = master.Fg
+	}
+	return slide
+}
+
+// masterslide returns the master as it appears on slide n of the deck:
+// its text filled in, and its foreground fg unless it has its own
+func masterslide(d *deck.Deck, n int, fg string) deck.Slide {
+	m := *master
+	if m.Fg == "" {
+		m.Fg = fg
+	}
+	r := strings.NewReplacer("{page}", strconv.Itoa(n+1), "{pages}", strconv.Itoa(len(d.Slide)), "{title}", d.Title)
+	m.Text = make([]deck.Text, len(master.Text))
+	for i, t := range master.Text {
+		t.Tdata = r.Replace(t.Tdata)
+		m.Text[i] = t
+	}
+	return m
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// The outline sidebar: slide titles for moving between slides
+
+// the outline sidebar, and the titles it lists
+var (
+	showoutline bool
+	titles      []string
+)
+
+const (
+	outlinewidth = 30  // width of the sidebar, percent of the slide area
+	outlinesize  = 1.5 // size of its text
+)
+
+// settitles lists the titles of the deck's slides for the sidebar
+func settitles(d *deck.Deck) {
+	titles = make([]string, len(d.Slide))
+	for n, slide := range d.Slide {
+		titles[n] = gc.SlideTitle(slide, n)
+	}
+}
+
+// outlinelayout returns the height of the sidebar's lines (percent), and the first
+// and number of titles shown, keeping the current slide in view
+func outlinelayout(c *gc.Canvas) (lineh float32, first, count int) {
+	lineh = outlinesize * 2 * c.Width / c.Height
+	count = int(96 / lineh)
+	if count > len(titles) {
+		count = len(titles)
+	}
+	first = slidenumber - count/2
+	if first > len(titles)-count {
+		first = len(titles) - count
+	}
+	if first < 0 {
+		first = 0
+	}
+	return lineh, first, count
+}
+
+// outlineat returns the slide whose title is at (x, y) in the sidebar, or -1
+func outlineat(c *gc.Canvas, x, y float32) int {
+	lineh, first, count := outlinelayout(c)
+	i := int((98 - y) / lineh)
+	if x > outlinewidth || y > 98 || i < 0 || i >= count {
+		return -1
+	}
+	return first + i
+}
+
+// showsidebar draws the sidebar, the current slide's title highlighted
+func showsidebar(c *gc.Canvas) {
+	lineh, first, count := outlinelayout(c)
+	c.BoxRect(gc.Box{X: 0, Y: 0, W: outlinewidth, H: 100}, color.NRGBA{0, 0, 0, 200})
+	for i := 0; i < count; i++ {
+		n := first + i
+		top := 98 - float32(i)*lineh
+		fg := color.NRGBA{220, 220, 220, 255}
+		if n == slidenumber {
+			c.BoxRect(gc.Box{X: 0, Y: top - lineh, W: outlinewidth, H: lineh}, color.NRGBA{0, 120, 215, 255})
+			fg = color.NRGBA{255, 255, 255, 255}
+		}
+		s := fmt.Sprintf("%d  %s", n+1, titles[n])
+		for r := []rune(s); c.TextWidth(s, outlinesize) > outlinewidth-3 && len(r) > 1; {
+			r = r[:len(r)-1]
+			s = string(r) + "…"
+		}
+		c.Text(1.5, top-lineh*0.7, outlinesize, s, fg)
+	}
+}
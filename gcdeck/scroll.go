@@ -0,0 +1,130 @@
+package main
+
+import (
+	"image"
+	"time"
+
+	"gioui.org/io/system"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Continuous scrolling: the slides are laid out one above the other, the width
+// of the window, and scrolled through, snapping to the nearest slide when the
+// scrolling stops
+
+// scrolling is set to show the slides continuously
+var scrolling bool
+
+// scrolly is the offset (pixels) of the top of the window from the top of the
+// first slide, and scrolled is when it was last changed by the pointer
+var (
+	scrolly  float32
+	scrolled time.Time
+)
+
+const (
+	slidegap  = 12                     // pixels between slides
+	snapdelay = 150 * time.Millisecond // how long after scrolling to snap to a slide
+)
+
+// slideheight returns the height of slide n, when it is w wide
+func slideheight(d *deck.Deck, n int, w float32) float32 {
+	if a := slideaspect(d, n); a > 0 {
+		return w / float32(a)
+	}
+	return w * 3 / 4
+}
+
+// slidetops returns the offsets of the tops of the slides, laid out for a window
+// w wide, and their total height
+func slidetops(d *deck.Deck, w float32) ([]float32, float32) {
+	tops := make([]float32, len(d.Slide))
+	var y float32
+	for n := range d.Slide {
+		tops[n] = y
+		y += slideheight(d, n, w) + slidegap
+	}
+	return tops, y - slidegap
+}
+
+// nearestslide returns the slide whose top is nearest y
+func nearestslide(tops []float32, y float32) int {
+	n := 0
+	for i, top := range tops {
+		if abs32(top-y) < abs32(tops[n]-y) {
+			n = i
+		}
+	}
+	return n
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// scrollstep moves the view for a window h high: following the pointer while it
+// scrolls (making the slide nearest the top current), then easing toward the
+// current slide. It reports whether the view is still moving.
+func scrollstep(now time.Time, tops []float32, total, h float32) bool {
+	if len(tops) == 0 {
+		return false
+	}
+	limit := total - h
+	if limit < 0 {
+		limit = 0
+	}
+	if scrolly > limit {
+		scrolly = limit
+	}
+	if scrolly < 0 {
+		scrolly = 0
+	}
+	if now.Sub(scrolled) < snapdelay {
+		slidenumber = nearestslide(tops, scrolly)
+		return true
+	}
+	if slidenumber >= len(tops) {
+		slidenumber = len(tops) - 1
+	}
+	target := tops[slidenumber]
+	if target > limit {
+		target = limit
+	}
+	d := target - scrolly
+	if abs32(d) < 1 {
+		scrolly = target
+		return false
+	}
+	scrolly += d * 0.3
+	return true
+}
+
+// showscroll draws the slides visible in the window, scrolled to scrolly
+func showscroll(c *gc.Canvas, d *deck.Deck, tops []float32) {
+	ops := c.Context.Ops
+	c.Background(mattecolor)
+	for n, top := range tops {
+		h := slideheight(d, n, c.Width)
+		y := top - scrolly
+		if y+h < 0 || y > c.Height {
+			continue
+		}
+		sc := gc.NewCanvas(c.Width, h, system.FrameEvent{})
+		sc.Locale = decklocale
+		m := op.Record(sc.Context.Ops)
+		showslide(sc, d, n)
+		showannotations(sc, n)
+		call := m.Stop()
+		stack := op.Offset(image.Pt(0, int(y))).Push(ops)
+		area := clip.Rect(image.Rect(0, 0, int(c.Width), int(h))).Push(ops)
+		call.Add(ops)
+		area.Pop()
+		stack.Pop()
+	}
+}
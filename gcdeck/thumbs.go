@@ -0,0 +1,191 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"github.com/ajstarks/deck"
+	gc "github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/capture"
+)
+
+// Thumbnails: a strip of slide thumbnails along the bottom of the slide area,
+// revealed when the pointer reaches the bottom edge, and clicked to go to a slide
+
+var (
+	thumbstrip bool // offer the strip
+	thumbshown bool // the strip is revealed
+
+	// the number of slides in the deck, and its aspect ratio
+	thumbcount  int
+	thumbaspect float64
+
+	// the slide area the strip is drawn in, in window pixels
+	thumbarea struct{ x, y, w, h float32 }
+)
+
+const (
+	thumbpixels = 120  // height of the rendered thumbnails
+	thumbstripH = 0.16 // height of the strip, a fraction of the slide area
+	thumbedge   = 0.03 // the pointer reveals the strip within this fraction of the bottom
+	thumbqueue  = 4    // the slides waiting to be rendered at most
+)
+
+// thumbcache holds the thumbnails of the deck, by slide, as they are rendered in the background.
+// A slide queued, or that failed to render, is present with a nil image.
+var thumbcache struct {
+	sync.Mutex
+	imgs map[int]image.Image
+	gen  int // counts the decks, so that thumbnails of one replaced are dropped
+}
+
+// thumbjob is a slide drawn for the worker to render
+type thumbjob struct {
+	gen, n int
+	canvas *gc.Canvas
+}
+
+// thumbjobs is the queue of the thumbnail worker
+var thumbjobs chan thumbjob
+
+// startthumbs starts the worker that renders thumbnails offscreen, calling invalidate as
+// each is ready
+func startthumbs(invalidate func()) {
+	thumbjobs = make(chan thumbjob, thumbqueue)
+	go func() {
+		r := new(capture.Renderer)
+		defer r.Release()
+		for j := range thumbjobs {
+			img, err := r.Render(j.canvas.Context.Ops, int(j.canvas.Width), int(j.canvas.Height))
+			thumbcache.Lock()
+			if j.gen == thumbcache.gen && err == nil {
+				thumbcache.imgs[j.n] = img
+			}
+			thumbcache.Unlock()
+			invalidate()
+		}
+	}()
+}
+
+// setthumbs prepares the strip for the deck, discarding old thumbnails
+func setthumbs(d *deck.Deck) {
+	thumbcache.Lock()
+	thumbcache.imgs = make(map[int]image.Image)
+	thumbcache.gen++
+	thumbcache.Unlock()
+	thumbcount, thumbaspect = len(d.Slide), deckaspect(d)
+}
+
+// thumbnail draws slide n at the size of a thumbnail
+func thumbnail(d *deck.Deck, n int) *gc.Canvas {
+	a := float32(deckaspect(d))
+	if a <= 0 {
+		a = 4.0 / 3
+	}
+	return renderslide(d, n, thumbpixels*a, thumbpixels)
+}
+
+// queuethumbs queues the shown slides that have no thumbnail for the worker. Slides are drawn
+// here, as drawing uses the state of the show, and only rendered in the background.
+func queuethumbs(d *deck.Deck) {
+	if thumbjobs == nil {
+		return
+	}
+	_, _, _, first, count := thumblayout(thumbarea.w, thumbarea.h, thumbaspect, thumbcount, slidenumber)
+	for s := first; s < first+count && len(thumbjobs) < cap(thumbjobs); s++ {
+		thumbcache.Lock()
+		_, seen := thumbcache.imgs[s]
+		if !seen {
+			thumbcache.imgs[s] = nil
+		}
+		gen := thumbcache.gen
+		thumbcache.Unlock()
+		if !seen {
+			thumbjobs <- thumbjob{gen: gen, n: s, canvas: thumbnail(d, s)}
+		}
+	}
+}
+
+// thumbimage returns the thumbnail of slide n, or nil if it is not ready
+func thumbimage(n int) image.Image {
+	thumbcache.Lock()
+	defer thumbcache.Unlock()
+	return thumbcache.imgs[n]
+}
+
+// setthumbarea places the strip in the slide area of a window w by h pixels
+func setthumbarea(w, h float32, aspect float64) {
+	top, right, bottom, left := slidemargins(w, h, aspect)
+	thumbarea.x, thumbarea.y = left*w/100, top*h/100
+	thumbarea.w, thumbarea.h = w-(left+right)*w/100, h-(top+bottom)*h/100
+}
+
+// thumblayout returns the size of the thumbnails in an area w by h pixels,
+// the space between them, and the first and number of n slides shown, keeping
+// the current one in view
+func thumblayout(w, h float32, aspect float64, n, current int) (tw, th, gap float32, first, count int) {
+	if aspect <= 0 {
+		aspect = 4.0 / 3
+	}
+	th = h * thumbstripH * 0.8
+	tw = th * float32(aspect)
+	gap = h * thumbstripH * 0.1
+	count = int((w - gap) / (tw + gap))
+	if count > n {
+		count = n
+	}
+	first = current - count/2
+	if first > n-count {
+		first = n - count
+	}
+	if first < 0 {
+		first = 0
+	}
+	return tw, th, gap, first, count
+}
+
+// thumbat returns the slide whose thumbnail is at (x, y) (window pixels), or -1
+func thumbat(x, y float32) int {
+	x, y = x-thumbarea.x, y-thumbarea.y
+	tw, th, gap, first, count := thumblayout(thumbarea.w, thumbarea.h, thumbaspect, thumbcount, slidenumber)
+	if y < thumbarea.h-gap-th || y > thumbarea.h-gap {
+		return -1
+	}
+	for i := 0; i < count; i++ {
+		tx := gap + float32(i)*(tw+gap)
+		if x >= tx && x <= tx+tw {
+			return first + i
+		}
+	}
+	return -1
+}
+
+// instrip reports whether y (window pixels) is within the strip, or at the edge that reveals it
+func instrip(y float32) bool {
+	y -= thumbarea.y
+	if thumbshown {
+		return y >= thumbarea.h*(1-thumbstripH) && y <= thumbarea.h
+	}
+	return y >= thumbarea.h*(1-thumbedge) && y <= thumbarea.h
+}
+
+// showthumbs draws the strip of thumbnails, the current one outlined, on a canvas
+// whose margins are those of the slide area
+func showthumbs(c *gc.Canvas) {
+	tw, th, gap, first, count := thumblayout(c.Width, c.Height, thumbaspect, thumbcount, slidenumber)
+	top := c.Height * (1 - thumbstripH)
+	c.AbsRect(0, top, c.Width, c.Height-top, color.NRGBA{0, 0, 0, 160})
+	for i := 0; i < count; i++ {
+		s := first + i
+		tx, ty := gap+float32(i)*(tw+gap), c.Height-gap-th
+		if s == slidenumber {
+			c.AbsRect(tx-3, ty-3, tw+6, th+6, color.NRGBA{0, 120, 215, 255})
+		}
+		if img := thumbimage(s); img != nil {
+			c.AbsImg(img, tx+tw/2, ty+th/2, 0, 0, 100*th/float32(img.Bounds().Dy()))
+		} else {
+			c.AbsRect(tx, ty, tw, th, color.NRGBA{128, 128, 128, 255})
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timing scripts: slides shown at set times, for recorded or broadcast talks.
+// Each line of a script is a slide number and the time it is shown, from the
+// start of the talk: "3 1:30" shows slide 3 after a minute and a half.
+// Times may be h:mm:ss, m:ss, seconds or Go durations (1m30s); # begins a comment.
+
+// cue shows a slide at a time from the start
+type cue struct {
+	at    time.Duration
+	slide int
+}
+
+// timing state: the script, when it started (zero if it has not), and the last cue shown
+var cues []cue
+var cuestart time.Time
+var lastcue = -1
+
+// readtiming reads a timing script, returning its cues in order of time
+func readtiming(r io.Reader) ([]cue, error) {
+	var list []cue
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		t := scanner.Text()
+		if i := strings.IndexByte(t, '#'); i >= 0 {
+			t = t[:i]
+		}
+		f := strings.Fields(t)
+		if len(f) == 0 {
+			continue
+		}
+		if len(f) != 2 {
+			return nil, fmt.Errorf("line %d: want a slide number and a time", line)
+		}
+		n, err := strconv.Atoi(f[0])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("line %d: bad slide number %q", line, f[0])
+		}
+		at, err := parsetimestamp(f[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		list = append(list, cue{at: at, slide: n - 1})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].at < list[j].at })
+	return list, scanner.Err()
+}
+
+// loadtiming reads the named timing script
+func loadtiming(filename string) ([]cue, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readtiming(f)
+}
+
+// parsetimestamp reads a time as h:mm:ss, m:ss, seconds, or a Go duration
+func parsetimestamp(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	var total float64
+	for _, part := range strings.Split(s, ":") {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("bad time %q", s)
+		}
+		total = total*60 + v
+	}
+	return time.Duration(total * float64(time.Second)), nil
+}
+
+// starttime returns when a script starts: now, or at a time of day today (hh:mm or hh:mm:ss).
+// An empty start, or "trigger", waits to be started.
+func starttime(start string, now time.Time) (time.Time, error) {
+	switch start {
+	case "", "trigger":
+		return time.Time{}, nil
+	case "now":
+		return now, nil
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, start, now.Location()); err == nil {
+			y, m, d := now.Date()
+			return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad start time %q", start)
+}
+
+// cueat returns the index of the last cue due at elapsed, or -1 if none is,
+// and when the next cue is due (0 if there are no more)
+func cueat(list []cue, elapsed time.Duration) (int, time.Duration) {
+	i := sort.Search(len(list), func(i int) bool { return list[i].at > elapsed })
+	if i < len(list) {
+		return i - 1, list[i].at
+	}
+	return i - 1, 0
+}
@@ -4,6 +4,7 @@ package giocanvas
 import (
 	"image/color"
 
+	"gioui.org/font"
 	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -14,19 +15,59 @@ type Canvas struct {
 	Width, Height float32
 	TextColor     color.NRGBA
 	Context       layout.Context
+	Semantic      bool            // record text and images for accessibility
+	TextDirection TextDirection   // base direction for text (default: automatic)
+	Locale        Locale          // number and date formatting conventions
+	Recorder      Recorder        // if set, receives every drawing call
+	Renderer      Renderer        // renders opacity groups offscreen, to be faded
+	Typeface      string          // typeface of text, from those added by RegisterFont (default: Go)
+	Fonts         map[string]Font // fonts by logical name, chosen with SetFont
+	Emoji         bool            // replace :name: emoji shortcodes in text
+
+	textstyle         font.Font // weight, style and variant of text, set by SetFont and while drawing styled text
+	semrole, semlabel string
+	semnodes          []SemanticNode
+	layer             op.MacroOp // recording of a layer's drawing, until merged
+	layered           bool
+	groups            []group           // opacity groups begun and not yet ended
+	margins           op.TransformStack // offset of the safe area, while margins are set
+	inset             bool
+	fullw, fullh      float32
+	originx, originy  float32
+	shapes            shapecache // paths of circles and ellipses, kept across frames
 }
 
 // NewCanvas initializes a Canvas
 func NewCanvas(width, height float32, e system.FrameEvent) *Canvas {
 	canvas := new(Canvas)
-	canvas.Width = width
-	canvas.Height = height
 	canvas.TextColor = color.NRGBA{0, 0, 0, 255}
-	canvas.Context = layout.NewContext(new(op.Ops), e)
-	iw, ih := int(width), int(height)
-	canvas.Context.Constraints.Min.X = iw
-	canvas.Context.Constraints.Min.Y = ih
-	canvas.Context.Constraints.Max.X = iw
-	canvas.Context.Constraints.Max.Y = ih
+	canvas.Reset(width, height, e)
 	return canvas
 }
+
+// Reset prepares the canvas for a new frame, reusing its operation list and
+// buffers instead of allocating new ones, which reduces garbage in animation loops.
+// The operations of the previous frame must no longer be in use: reset only
+// after the FrameEvent's Frame method has returned. Drawing settings
+// (TextColor, Semantic, TextDirection, Locale, Recorder) are kept; the slice
+// returned by SemanticNodes is reused.
+func (c *Canvas) Reset(width, height float32, e system.FrameEvent) {
+	ops := c.Context.Ops
+	if ops == nil {
+		ops = new(op.Ops)
+	}
+	ops.Reset()
+	c.Width = width
+	c.Height = height
+	c.Context = layout.NewContext(ops, e)
+	iw, ih := int(width), int(height)
+	c.Context.Constraints.Min.X = iw
+	c.Context.Constraints.Min.Y = ih
+	c.Context.Constraints.Max.X = iw
+	c.Context.Constraints.Max.Y = ih
+	c.semrole, c.semlabel = "", ""
+	c.semnodes = c.semnodes[:0]
+	c.inset = false
+	c.groups = c.groups[:0]
+	c.originx, c.originy = 0, 0
+}
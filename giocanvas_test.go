@@ -2,6 +2,9 @@ package giocanvas
 
 import (
 	"testing"
+
+	"gioui.org/io/system"
+	"gioui.org/unit"
 )
 
 func BenchmarkC0(b *testing.B) {
@@ -33,3 +36,63 @@ func BenchmarkC4(b *testing.B) {
 		ColorLookup("rgb(100,100,100,100)")
 	}
 }
+
+func BenchmarkText(b *testing.B) {
+	c, _ := NewRecordingCanvas(1000, 1000)
+	c.Recorder = nil
+	for n := 0; n < b.N; n++ {
+		c.Text(10, 50, 3, "hello, world", c.TextColor)
+	}
+}
+
+func BenchmarkCircle(b *testing.B) {
+	c, _ := NewRecordingCanvas(1000, 1000)
+	c.Recorder = nil
+	for n := 0; n < b.N; n++ {
+		if n%1000 == 0 {
+			c.Context.Ops.Reset()
+		}
+		c.Circle(50, 50, 1, c.TextColor)
+	}
+}
+
+func TestShapeCache(t *testing.T) {
+	a, _ := NewRecordingCanvas(100, 100)
+	b, _ := NewRecordingCanvas(100, 100)
+	a.Circle(50, 50, 10, a.TextColor)
+	a.Reset(100, 100, system.FrameEvent{})
+	a.Circle(20, 20, 10, a.TextColor)
+	if len(a.shapes.paths) != 1 || b.shapes.paths != nil {
+		t.Errorf("cached shapes: %d, other canvas %d", len(a.shapes.paths), len(b.shapes.paths))
+	}
+	a.ClearShapeCache()
+	if a.shapes.paths != nil {
+		t.Error("shapes not cleared")
+	}
+}
+
+func TestTextCacheSizes(t *testing.T) {
+	// canvases of two sizes, drawn in turn, keep each other's layouts
+	ClearTextCache()
+	a, _ := NewRecordingCanvas(100, 100)
+	b, _ := NewRecordingCanvas(200, 100)
+	a.AbsText(10, 50, 12, "hello", a.TextColor)
+	b.AbsText(10, 50, 12, "hello", b.TextColor)
+	a.AbsText(10, 50, 12, "hello", a.TextColor)
+	textcache.Lock()
+	n := len(textcache.layouts)
+	textcache.Unlock()
+	if n != 2 {
+		t.Errorf("cached layouts: %d, want 2", n)
+	}
+}
+
+func TestTextCacheMetric(t *testing.T) {
+	// a HiDPI window and an offscreen canvas of the same size lay text out apart
+	ClearTextCache()
+	screen := NewCanvas(200, 100, system.FrameEvent{Metric: unit.Metric{PxPerDp: 2, PxPerSp: 2}})
+	offscreen := NewCanvas(200, 100, system.FrameEvent{})
+	if w1, w2 := screen.TextWidth("hello", 5), offscreen.TextWidth("hello", 5); w1 <= w2 {
+		t.Errorf("width at 2 px per sp %v, at 1 %v", w1, w2)
+	}
+}
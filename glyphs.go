@@ -0,0 +1,169 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Glyphs: built-in vector symbols -- arrows, checks, warnings and the like --
+// drawn by name, for dashboards and slides without external images
+
+// ring is a closed outline of a glyph, in units of its size (centered at the origin,
+// y downward); holes are cut out of the rings they lie in
+type ring struct {
+	points []f32.Point
+	hole   bool
+}
+
+// circlering returns a circle as a ring
+func circlering(x, y, r float32, hole bool) ring {
+	const n = 32
+	points := make([]f32.Point, n)
+	for i := range points {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / n)
+		points[i] = f32.Pt(x+r*float32(cos), y+r*float32(sin))
+	}
+	return ring{points: points, hole: hole}
+}
+
+// polyring returns a polygon, from alternating x and y coordinates, as a ring
+func polyring(hole bool, coords ...float32) ring {
+	points := make([]f32.Point, len(coords)/2)
+	for i := range points {
+		points[i] = f32.Pt(coords[2*i], coords[2*i+1])
+	}
+	return ring{points: points, hole: hole}
+}
+
+// strokerings returns the outline of a stroke through the points, size wide, with
+// round caps and joins, as rings
+func strokerings(size float32, coords ...float32) []ring {
+	var rings []ring
+	for _, piece := range strokeoutline(polyring(false, coords...).points, size, RoundCap, RoundJoin, 0) {
+		if piece.round {
+			rings = append(rings, circlering(piece.points[0].X, piece.points[0].Y, size/2, false))
+			continue
+		}
+		rings = append(rings, ring{points: piece.points})
+	}
+	return rings
+}
+
+// rotated returns the rings turned by angle (radians) about the origin
+func rotated(rings []ring, angle float64) []ring {
+	sin, cos := math.Sincos(angle)
+	s, co := float32(sin), float32(cos)
+	out := make([]ring, len(rings))
+	for i, r := range rings {
+		points := make([]f32.Point, len(r.points))
+		for j, p := range r.points {
+			points[j] = f32.Pt(p.X*co-p.Y*s, p.X*s+p.Y*co)
+		}
+		out[i] = ring{points: points, hole: r.hole}
+	}
+	return out
+}
+
+// arrow is a right pointing arrow
+var arrow = []ring{polyring(false, -0.45, -0.1, 0.05, -0.1, 0.05, -0.3, 0.45, 0, 0.05, 0.3, 0.05, 0.1, -0.45, 0.1)}
+
+// gear returns a gear of eight teeth
+func gear() []ring {
+	const teeth = 8
+	var coords []float32
+	for i := 0; i < teeth; i++ {
+		a := 2 * math.Pi * float64(i) / teeth
+		for _, p := range []struct{ r, da float64 }{{0.36, -0.3}, {0.5, -0.17}, {0.5, 0.17}, {0.36, 0.3}} {
+			sin, cos := math.Sincos(a + p.da)
+			coords = append(coords, float32(p.r*cos), float32(p.r*sin))
+		}
+	}
+	return []ring{polyring(false, coords...), circlering(0, 0, 0.15, true)}
+}
+
+// glyphs are the built-in glyphs, by name
+var glyphs = map[string][]ring{
+	"arrow-right": arrow,
+	"arrow-down":  rotated(arrow, math.Pi/2),
+	"arrow-left":  rotated(arrow, math.Pi),
+	"arrow-up":    rotated(arrow, -math.Pi/2),
+	"check":       strokerings(0.14, -0.35, 0.02, -0.1, 0.27, 0.38, -0.3),
+	"cross":       append(strokerings(0.14, -0.32, -0.32, 0.32, 0.32), strokerings(0.14, -0.32, 0.32, 0.32, -0.32)...),
+	"gear":        gear(),
+	"info": {
+		circlering(0, 0, 0.48, false),
+		polyring(true, -0.06, -0.08, 0.06, -0.08, 0.06, 0.3, -0.06, 0.3),
+		circlering(0, -0.22, 0.07, true),
+	},
+	"pin": {
+		circlering(0, -0.15, 0.3, false),
+		polyring(false, -0.26, 0, 0.26, 0, 0, 0.5),
+		circlering(0, -0.15, 0.12, true),
+	},
+	"warning": {
+		polyring(false, 0, -0.45, 0.5, 0.42, -0.5, 0.42),
+		polyring(true, -0.05, -0.18, 0.05, -0.18, 0.04, 0.14, -0.04, 0.14),
+		circlering(0, 0.27, 0.06, true),
+	},
+}
+
+// GlyphNames returns the names of the built-in glyphs, in order
+func GlyphNames() []string {
+	names := make([]string, 0, len(glyphs))
+	for name := range glyphs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AbsGlyph draws the named glyph centered at (x, y), size across, reporting whether
+// there is a glyph of that name
+func (c *Canvas) AbsGlyph(name string, x, y, size float32, fillcolor color.NRGBA) bool {
+	rings, ok := glyphs[name]
+	if !ok {
+		return false
+	}
+	c.record(DrawCall{Op: "glyph", Size: size, Text: name, Color: fillcolor}, x, y)
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	for _, r := range rings {
+		// rings wind one way, holes the other, so holes cancel under the non-zero rule
+		pts := r.points
+		reverse := area(pts) < 0
+		if r.hole {
+			reverse = !reverse
+		}
+		for i := range pts {
+			p := pts[i]
+			if reverse {
+				p = pts[len(pts)-1-i]
+			}
+			p = f32.Pt(x+p.X*size, y+p.Y*size)
+			if i == 0 {
+				path.MoveTo(p)
+			} else {
+				path.LineTo(p)
+			}
+		}
+		path.Close()
+	}
+	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
+	paint.ColorOp{Color: fillcolor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+	return true
+}
+
+// Glyph draws the named glyph centered at (x, y), size across (a percentage of the width),
+// using percentage-based measures, reporting whether there is a glyph of that name
+func (c *Canvas) Glyph(name string, x, y, size float32, fillcolor color.NRGBA) bool {
+	x, y = dimen(x, y, c.Width, c.Height)
+	return c.AbsGlyph(name, x, y, pct(size, c.Width), fillcolor)
+}
@@ -0,0 +1,31 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGlyphs(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 1000)
+	for _, name := range GlyphNames() {
+		for _, r := range glyphs[name] {
+			for _, p := range r.points {
+				if p.X < -0.5 || p.X > 0.5 || p.Y < -0.5 || p.Y > 0.5 {
+					t.Errorf("%s: %v outside the glyph", name, p)
+				}
+			}
+		}
+		if !c.Glyph(name, 50, 50, 5, color.NRGBA{0, 0, 0, 255}) {
+			t.Errorf("%s not drawn", name)
+		}
+	}
+	if c.Glyph("unicorn", 50, 50, 5, color.NRGBA{0, 0, 0, 255}) {
+		t.Error("drew an unknown glyph")
+	}
+	if n := len(log.Find("glyph")); n != len(glyphs) {
+		t.Errorf("recorded %d glyphs, want %d", n, len(glyphs))
+	}
+	if err := RegisterFont("bad", []byte("not a font")); err == nil {
+		t.Error("registered a bad font")
+	}
+}
@@ -0,0 +1,439 @@
+package giocanvas
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Gradients
+
+// GradientStop is a color at a position (0-1) along a gradient
+type GradientStop struct {
+	Offset float32
+	Color  color.NRGBA
+}
+
+// lerpcolor interpolates between two colors, t between 0 and 1
+func lerpcolor(a, b color.NRGBA, t float32) color.NRGBA {
+	l := func(x, y uint8) uint8 {
+		return uint8(float32(x) + (float32(y)-float32(x))*t + 0.5)
+	}
+	return color.NRGBA{R: l(a.R, b.R), G: l(a.G, b.G), B: l(a.B, b.B), A: l(a.A, b.A)}
+}
+
+// GradientColor returns the color at position t (0-1) along the gradient defined by stops,
+// which are in order of offset
+func GradientColor(stops []GradientStop, t float32) color.NRGBA {
+	switch {
+	case len(stops) == 0:
+		return color.NRGBA{}
+	case t <= stops[0].Offset:
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Offset {
+			a, b := stops[i-1], stops[i]
+			span := b.Offset - a.Offset
+			if span <= 0 {
+				return b.Color
+			}
+			return lerpcolor(a.Color, b.Color, (t-a.Offset)/span)
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// conicsegments returns the number of segments used to draw a conic gradient of radius r
+func conicsegments(r float32) int {
+	n := int(r)
+	if n < 90 {
+		n = 90
+	}
+	if n > 720 {
+		n = 720
+	}
+	return n
+}
+
+// AbsConicGradient fills an annulus centered at (x, y) with inner radius r1 and outer
+// radius r2 (r1 = 0 for a disc), with colors sweeping clockwise around the center,
+// starting at the angle start (radians, as AbsArc)
+func (c *Canvas) AbsConicGradient(x, y, r1, r2 float32, start float64, stops []GradientStop) {
+	if len(stops) > 0 {
+		c.record(DrawCall{Op: "conic", Size: r2, W: r1, A1: start, Color: stops[0].Color}, x, y)
+	}
+	ops := c.Context.Ops
+	n := conicsegments(r2)
+	step := 2 * math.Pi / float64(n)
+	for i := 0; i < n; i++ {
+		a0 := start + float64(i)*step
+		a1 := a0 + step*1.05 // overlap slightly, to hide seams
+		path := new(clip.Path)
+		path.Begin(ops)
+		path.MoveTo(arcpoint(x, y, r2, a0))
+		path.LineTo(arcpoint(x, y, r2, a1))
+		if r1 > 0 {
+			path.LineTo(arcpoint(x, y, r1, a1))
+			path.LineTo(arcpoint(x, y, r1, a0))
+		} else {
+			path.LineTo(f32.Pt(x, y))
+		}
+		path.Close()
+		stack := clip.Outline{Path: path.End()}.Op().Push(ops)
+		paint.ColorOp{Color: GradientColor(stops, (float32(i)+0.5)/float32(n))}.Add(ops)
+		paint.PaintOp{}.Add(ops)
+		stack.Pop()
+	}
+}
+
+// ConicGradient fills a circle centered at (x, y), radius r with a conic (angular)
+// gradient, starting at angle start (radians), using percentage-based measures
+func (c *Canvas) ConicGradient(x, y, r float32, start float64, stops []GradientStop) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsConicGradient(x, y, 0, pct(r, c.Width), start, stops)
+}
+
+// ConicAnnulus fills a ring centered at (x, y), between radii r1 and r2 with a
+// conic gradient, starting at angle start (radians), using percentage-based measures
+func (c *Canvas) ConicAnnulus(x, y, r1, r2 float32, start float64, stops []GradientStop) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsConicGradient(x, y, pct(r1, c.Width), pct(r2, c.Width), start, stops)
+}
+
+// HueStops returns gradient stops sweeping through the hues of the color wheel,
+// with saturation and value (0-100), as used by "hsv(...)" colors
+func HueStops(saturation, value float64, alpha uint8) []GradientStop {
+	stops := make([]GradientStop, 13)
+	for i := range stops {
+		r, g, b := hsv2rgb(float64(i%12)*30, saturation, value)
+		stops[i] = GradientStop{Offset: float32(i) / 12, Color: color.NRGBA{r, g, b, alpha}}
+	}
+	return stops
+}
+
+// gradientends returns the ends of a linear gradient across the box with upper left
+// at (x, y), size (w, h), along the angle (degrees, counter-clockwise from left to right),
+// with the colors changing over the fraction span (0-1) of the way across
+func gradientends(x, y, w, h float32, angle, span float64) (f32.Point, f32.Point) {
+	if span <= 0 || span > 1 {
+		span = 1
+	}
+	s, cs := math.Sincos(angle * math.Pi / 180)
+	d := f32.Pt(float32(cs), float32(-s))
+	// the extent of the box along the direction, from its center
+	extent := (abs32(d.X)*w + abs32(d.Y)*h) / 2
+	center := f32.Pt(x+w/2, y+h/2)
+	p1 := center.Sub(d.Mul(extent))
+	return p1, p1.Add(d.Mul(2 * extent * float32(span)))
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// polypath returns the closed path through the points
+func (c *Canvas) polypath(x, y []float32) clip.PathSpec {
+	path := new(clip.Path)
+	path.Begin(c.Context.Ops)
+	path.MoveTo(f32.Pt(x[0], y[0]))
+	for i := 1; i < len(x); i++ {
+		path.LineTo(f32.Pt(x[i], y[i]))
+	}
+	path.Close()
+	return path.End()
+}
+
+// bounds returns the least and greatest coordinates of the points
+func bounds(x, y []float32) (minx, miny, maxx, maxy float32) {
+	minx, miny, maxx, maxy = x[0], y[0], x[0], y[0]
+	for i := 1; i < len(x); i++ {
+		minx, maxx = min32(minx, x[i]), max32(maxx, x[i])
+		miny, maxy = min32(miny, y[i]), max32(maxy, y[i])
+	}
+	return minx, miny, maxx, maxy
+}
+
+// Gradient is a fill that varies across a shape: a LinearGradient or RadialGradient,
+// an image Pattern, or a Hatch
+type Gradient interface {
+	// paint fills the current clip with the gradient laid across the box,
+	// upper left at (x, y), size (w, h) (absolute)
+	paint(c *Canvas, x, y, w, h float32)
+}
+
+// LinearGradient varies through its color stops (in order of offset) along the
+// angle (degrees, counter-clockwise from left to right), across the shape it fills
+type LinearGradient struct {
+	Stops []GradientStop
+	Angle float64
+}
+
+// gradientsteps is the number of colors a gradient of more than two stops is drawn with
+const gradientsteps = 256
+
+// gradientcache holds the strips of colors that gradients of more than two stops are drawn
+// with, by their stops, so that they are made (and uploaded to the GPU) once
+var gradientcache struct {
+	sync.Mutex
+	strips  map[string]paint.ImageOp
+	radials map[string]paint.ImageOp
+}
+
+// maxgradients limits the strips cached, and maxradials the (larger) images of radial
+// gradients; a cache is emptied when it is full
+const (
+	maxgradients = 256
+	maxradials   = 32
+)
+
+// stopskey returns a key identifying gradient stops
+func stopskey(stops []GradientStop) string {
+	b := make([]byte, 0, len(stops)*8)
+	for _, s := range stops {
+		o := math.Float32bits(s.Offset)
+		b = append(b, byte(o), byte(o>>8), byte(o>>16), byte(o>>24), s.Color.R, s.Color.G, s.Color.B, s.Color.A)
+	}
+	return string(b)
+}
+
+// gradientstrip returns the image operation of a strip of the colors of the stops,
+// gradientsteps wide
+func gradientstrip(stops []GradientStop) paint.ImageOp {
+	key := stopskey(stops)
+	gradientcache.Lock()
+	defer gradientcache.Unlock()
+	if im, ok := gradientcache.strips[key]; ok {
+		return im
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, gradientsteps, 1))
+	for i := 0; i < gradientsteps; i++ {
+		img.SetNRGBA(i, 0, GradientColor(stops, (float32(i)+0.5)/gradientsteps))
+	}
+	if len(gradientcache.strips) >= maxgradients || gradientcache.strips == nil {
+		gradientcache.strips = make(map[string]paint.ImageOp)
+	}
+	im := paint.NewImageOp(img)
+	gradientcache.strips[key] = im
+	return im
+}
+
+func (g LinearGradient) paint(c *Canvas, x, y, w, h float32) {
+	ops := c.Context.Ops
+	switch len(g.Stops) {
+	case 0:
+		return
+	case 1:
+		paint.ColorOp{Color: g.Stops[0].Color}.Add(ops)
+		paint.PaintOp{}.Add(ops)
+		return
+	}
+	p1, p2 := gradientends(x, y, w, h, g.Angle, 1)
+	d := p2.Sub(p1)
+	if len(g.Stops) == 2 && g.Stops[1].Offset > g.Stops[0].Offset {
+		// Gio draws two distinct stops itself
+		a, b := g.Stops[0], g.Stops[1]
+		paint.LinearGradientOp{Stop1: p1.Add(d.Mul(a.Offset)), Color1: a.Color, Stop2: p1.Add(d.Mul(b.Offset)), Color2: b.Color}.Add(ops)
+		paint.PaintOp{}.Add(ops)
+		return
+	}
+	// otherwise, a strip of the colors is stretched along the gradient, and across the box
+	length := float32(math.Hypot(float64(d.X), float64(d.Y)))
+	if length == 0 {
+		return
+	}
+	across := w + h
+	perp := f32.Pt(-d.Y/length, d.X/length)
+	origin := p1.Sub(perp.Mul(across / 2))
+	m := f32.NewAffine2D(d.X/gradientsteps, perp.X*across, origin.X, d.Y/gradientsteps, perp.Y*across, origin.Y)
+	stack := op.Affine(m).Push(ops)
+	gradientstrip(g.Stops).Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
+
+// AbsCenterRectGradient fills a rectangle centered at (x, y), with dimensions (w, h), with a gradient
+func (c *Canvas) AbsCenterRectGradient(x, y, w, h float32, g Gradient) {
+	c.record(DrawCall{Op: "rect", W: w, H: h}, x-w/2, y-h/2)
+	x, y = x-w/2, y-h/2
+	stack := clip.Rect{Min: image.Pt(int(x), int(y)), Max: image.Pt(int(x+w+0.5), int(y+h+0.5))}.Push(c.Context.Ops)
+	g.paint(c, x, y, w, h)
+	stack.Pop()
+}
+
+// AbsCircleGradient fills a circle centered at (x, y), radius r, with a gradient
+func (c *Canvas) AbsCircleGradient(x, y, r float32, g Gradient) {
+	c.AbsEllipseGradient(x, y, r, r, g)
+}
+
+// AbsEllipseGradient fills an ellipse centered at (x, y), radii (w, h), with a gradient
+func (c *Canvas) AbsEllipseGradient(x, y, w, h float32, g Gradient) {
+	c.record(DrawCall{Op: "ellipse", W: w, H: h}, x, y)
+	ops := c.Context.Ops
+	t := op.Affine(f32.Affine2D{}.Offset(f32.Pt(x, y))).Push(ops)
+	stack := clip.Outline{Path: c.ellipsepath(w, h)}.Op().Push(ops)
+	g.paint(c, -w, -h, 2*w, 2*h)
+	stack.Pop()
+	t.Pop()
+}
+
+// AbsPolygonGradient fills a polygon with a gradient across its bounds
+func (c *Canvas) AbsPolygonGradient(x, y []float32, g Gradient) {
+	if len(x) != len(y) || len(x) < 3 {
+		return
+	}
+	if c.Recorder != nil {
+		points := make([]float32, 0, len(x)*2)
+		for i := range x {
+			points = append(points, x[i], y[i])
+		}
+		c.record(DrawCall{Op: "polygon"}, points...)
+	}
+	minx, miny, maxx, maxy := bounds(x, y)
+	stack := clip.Outline{Path: c.polypath(x, y)}.Op().Push(c.Context.Ops)
+	g.paint(c, minx, miny, maxx-minx, maxy-miny)
+	stack.Pop()
+}
+
+// CenterRectGradient fills a rectangle centered at (x, y), sized (w, h), with a gradient,
+// using percentage-based measures
+func (c *Canvas) CenterRectGradient(x, y, w, h float32, g Gradient) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsCenterRectGradient(x, y, pct(w, c.Width), pct(h, c.Height), g)
+}
+
+// CircleGradient fills a circle centered at (x, y), radius r, with a gradient,
+// using percentage-based measures
+func (c *Canvas) CircleGradient(x, y, r float32, g Gradient) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsCircleGradient(x, y, pct(r, c.Width), g)
+}
+
+// EllipseGradient fills an ellipse centered at (x, y), radii (w, h), with a gradient,
+// using percentage-based measures
+func (c *Canvas) EllipseGradient(x, y, w, h float32, g Gradient) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsEllipseGradient(x, y, pct(w, c.Width), pct(h, c.Height), g)
+}
+
+// PolygonGradient fills a polygon with a gradient, using percentage-based measures
+func (c *Canvas) PolygonGradient(x, y []float32, g Gradient) {
+	if len(x) != len(y) {
+		return
+	}
+	px := make([]float32, len(x))
+	py := make([]float32, len(y))
+	for i := range x {
+		px[i], py[i] = dimen(x[i], y[i], c.Width, c.Height)
+	}
+	c.AbsPolygonGradient(px, py, g)
+}
+
+// BackgroundGradient fills the canvas with a gradient
+func (c *Canvas) BackgroundGradient(g Gradient) {
+	c.AbsCenterRectGradient(c.Width/2, c.Height/2, c.Width, c.Height, g)
+}
+
+// RadialGradient varies through its color stops outward, from its focal point to a circle
+// about its center (an ellipse, in a box that is not square). The center is offset from the
+// middle of the shape's box (CX, CY), and the focal point from the center (FX, FY), by
+// fractions of the box's half-width and half-height (y upward); the radius is such a
+// fraction too (0 is taken as 1, reaching the box's edges).
+type RadialGradient struct {
+	Stops          []GradientStop
+	CX, CY, FX, FY float32
+	Radius         float32
+}
+
+// maxradialsize limits the size of the image a radial gradient is drawn with, which is
+// otherwise the size of the shape, in pixels
+const maxradialsize = 1024
+
+// at returns the position (0-1, beyond 1 outside the circle) along the gradient of the
+// point (x, y), offset from the middle of the box by fractions of its half-size
+func (g RadialGradient) at(x, y float32) float32 {
+	r := float64(g.Radius)
+	if r <= 0 {
+		r = 1
+	}
+	fx, fy := float64(g.CX+g.FX), float64(g.CY+g.FY)
+	// the circles of the gradient grow from the focal point (t = 0) to the outer circle (t = 1):
+	// solve |d - t*e| = t*r for t
+	dx, dy := float64(x)-fx, float64(y)-fy
+	ex, ey := -float64(g.FX), -float64(g.FY)
+	a := ex*ex + ey*ey - r*r
+	b := -2 * (dx*ex + dy*ey)
+	c := dx*dx + dy*dy
+	if math.Abs(a) < 1e-9 {
+		if b == 0 {
+			return 0
+		}
+		return float32(math.Max(0, -c/b))
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return 1
+	}
+	sq := math.Sqrt(disc)
+	t := math.Max((-b+sq)/(2*a), (-b-sq)/(2*a))
+	return float32(math.Max(0, t))
+}
+
+// radialsize returns the size of the image to draw a length of a radial gradient with
+func radialsize(v float32) int {
+	n := int(math.Ceil(float64(v)))
+	if n > maxradialsize {
+		n = maxradialsize
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// image returns the image operation of the gradient, iw by ih, cached by the gradient and size
+func (g RadialGradient) image(iw, ih int) paint.ImageOp {
+	key := fmt.Sprintf("%s%v,%v,%v,%v,%v,%d,%d", stopskey(g.Stops), g.CX, g.CY, g.FX, g.FY, g.Radius, iw, ih)
+	gradientcache.Lock()
+	defer gradientcache.Unlock()
+	if im, ok := gradientcache.radials[key]; ok {
+		return im
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, iw, ih))
+	for j := 0; j < ih; j++ {
+		uy := 1 - 2*(float32(j)+0.5)/float32(ih)
+		for i := 0; i < iw; i++ {
+			ux := 2*(float32(i)+0.5)/float32(iw) - 1
+			img.SetNRGBA(i, j, GradientColor(g.Stops, g.at(ux, uy)))
+		}
+	}
+	if len(gradientcache.radials) >= maxradials || gradientcache.radials == nil {
+		gradientcache.radials = make(map[string]paint.ImageOp)
+	}
+	im := paint.NewImageOp(img)
+	gradientcache.radials[key] = im
+	return im
+}
+
+func (g RadialGradient) paint(c *Canvas, x, y, w, h float32) {
+	if len(g.Stops) == 0 || w <= 0 || h <= 0 {
+		return
+	}
+	iw, ih := radialsize(w), radialsize(h)
+	ops := c.Context.Ops
+	m := f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(w/float32(iw), h/float32(ih))).Offset(f32.Pt(x, y))
+	stack := op.Affine(m).Push(ops)
+	g.image(iw, ih).Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
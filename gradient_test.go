@@ -0,0 +1,120 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gioui.org/f32"
+)
+
+func TestGradientEnds(t *testing.T) {
+	near := func(a, b f32.Point) bool {
+		return abs32(a.X-b.X) < 0.01 && abs32(a.Y-b.Y) < 0.01
+	}
+	tests := []struct {
+		angle, span float64
+		p1, p2      f32.Point
+	}{
+		{0, 1, f32.Pt(10, 40), f32.Pt(110, 40)},     // left to right
+		{90, 1, f32.Pt(60, 60), f32.Pt(60, 20)},     // bottom to top
+		{180, 0.5, f32.Pt(110, 40), f32.Pt(60, 40)}, // right to left, over half the width
+		{0, 0, f32.Pt(10, 40), f32.Pt(110, 40)},     // no span: all the way across
+	}
+	for _, tc := range tests {
+		p1, p2 := gradientends(10, 20, 100, 40, tc.angle, tc.span)
+		if !near(p1, tc.p1) || !near(p2, tc.p2) {
+			t.Errorf("gradientends(angle %v, span %v) = %v, %v; want %v, %v", tc.angle, tc.span, p1, p2, tc.p1, tc.p2)
+		}
+	}
+}
+
+func TestLinearGradientStops(t *testing.T) {
+	c, log := NewRecordingCanvas(200, 100)
+	g := LinearGradient{Angle: 90, Stops: []GradientStop{
+		{0, color.NRGBA{255, 0, 0, 255}}, {0.5, color.NRGBA{0, 255, 0, 255}}, {1, color.NRGBA{0, 0, 255, 255}},
+	}}
+	c.CenterRectGradient(50, 50, 20, 10, g)
+	c.BackgroundGradient(g)
+	if r := log.Find("rect"); len(r) != 2 || r[0].Points[0] != 40 || r[0].Points[1] != 55 || r[1].W != 100 {
+		t.Errorf("rects: %+v", r)
+	}
+}
+
+func TestRadialGradient(t *testing.T) {
+	near := func(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-4 }
+	g := RadialGradient{}
+	if v := g.at(0, 0); !near(v, 0) {
+		t.Errorf("center: %v", v)
+	}
+	if v := g.at(1, 0); !near(v, 1) {
+		t.Errorf("edge: %v", v)
+	}
+	if v := g.at(0, 0.5); !near(v, 0.5) {
+		t.Errorf("halfway: %v", v)
+	}
+	// with the focal point offset halfway to the right, the gradient starts there,
+	// and still reaches the edge of the circle
+	g.FX = 0.5
+	if v := g.at(0.5, 0); !near(v, 0) {
+		t.Errorf("focus: %v", v)
+	}
+	for _, p := range [][2]float32{{-1, 0}, {1, 0}, {0, 1}} {
+		if v := g.at(p[0], p[1]); !near(v, 1) {
+			t.Errorf("edge at %v: %v", p, v)
+		}
+	}
+}
+
+func TestRadialImage(t *testing.T) {
+	if radialsize(40.2) != 41 || radialsize(5000) != maxradialsize || radialsize(0) != 1 {
+		t.Errorf("sizes: %d, %d, %d", radialsize(40.2), radialsize(5000), radialsize(0))
+	}
+	g := RadialGradient{Stops: []GradientStop{{0, color.NRGBA{255, 0, 0, 255}}, {1, color.NRGBA{0, 0, 255, 255}}}}
+	if g.image(40, 20) != g.image(40, 20) {
+		t.Error("gradient image not cached")
+	}
+	if g.image(40, 20) == g.image(80, 40) {
+		t.Error("gradient image cached across sizes")
+	}
+	g.FX = 0.5
+	if g.image(40, 20) == (RadialGradient{Stops: g.Stops}).image(40, 20) {
+		t.Error("gradient image cached across focal points")
+	}
+}
+
+func TestGradientColor(t *testing.T) {
+	red, green, blue := color.NRGBA{255, 0, 0, 255}, color.NRGBA{0, 255, 0, 255}, color.NRGBA{0, 0, 255, 0}
+	stops := []GradientStop{{0.2, red}, {0.6, green}, {0.6, blue}, {1, red}}
+	tests := []struct {
+		t    float32
+		want color.NRGBA
+	}{
+		{0, red},                            // before the first stop
+		{0.2, red},                          // at it
+		{0.3, color.NRGBA{191, 64, 0, 255}}, // a quarter of the way to the next
+		{0.6, green},                        // stops at the same offset: the first reached
+		{0.7, color.NRGBA{64, 0, 191, 64}},  // on from the later one, alpha too
+		{1.5, red},                          // beyond the last
+	}
+	for _, tc := range tests {
+		if got := GradientColor(stops, tc.t); got != tc.want {
+			t.Errorf("GradientColor(%v) = %v, want %v", tc.t, got, tc.want)
+		}
+	}
+	if got := GradientColor(nil, 0.5); got != (color.NRGBA{}) {
+		t.Errorf("no stops: %v", got)
+	}
+	if got := GradientColor(stops[:1], 0.9); got != red {
+		t.Errorf("one stop: %v", got)
+	}
+
+	// strips of the same stops are made once
+	s1, s2 := gradientstrip(stops), gradientstrip(append([]GradientStop{}, stops...))
+	if s1 != s2 {
+		t.Error("gradient strip not reused")
+	}
+	if s3 := gradientstrip(stops[1:]); s3 == s1 {
+		t.Error("gradient strip reused for other stops")
+	}
+}
@@ -0,0 +1,134 @@
+package giocanvas
+
+import (
+	"errors"
+	"image"
+
+	"gioui.org/op"
+	"gioui.org/op/paint"
+)
+
+// Opacity groups: fading a set of drawing calls as one unit. Fading each shape
+// shows where shapes overlap; a group is instead rendered offscreen, and the
+// result faded, or blended (see BeginBlendGroup). Gio has no offscreen rendering
+// within a frame, so this is done by the canvas' Renderer, such as one from the capture package:
+//
+//	c.Renderer = new(capture.Renderer)
+
+// Renderer renders operations offscreen, width by height pixels, returning the pixels
+type Renderer interface {
+	Render(ops *op.Ops, width, height int) (*image.RGBA, error)
+}
+
+// ErrNoRenderer is returned when a group is to be faded, and the canvas has no Renderer
+var ErrNoRenderer = errors.New("giocanvas: no renderer for opacity groups")
+
+// group is an opacity group being drawn: the operations drawing resumes on when
+// it ends, and those of the group, drawn by calls (one for each time what has been
+// drawn in the group was needed beneath a blended group within it) and the macro
+// recording the rest
+type group struct {
+	alpha float32
+	mode  BlendMode
+	ops   *op.Ops
+	own   *op.Ops
+	macro op.MacroOp
+	calls []op.CallOp
+}
+
+// drawn returns the calls drawing the group so far, starting a new macro for the rest
+func (g *group) drawn() []op.CallOp {
+	g.calls = append(g.calls, g.macro.Stop())
+	g.macro = op.Record(g.own)
+	return g.calls
+}
+
+// BeginGroup starts a group of drawing calls, faded to alpha (0-1) as a whole
+// when the group is ended with EndGroup. Groups may be nested.
+func (c *Canvas) BeginGroup(alpha float32) {
+	c.BeginBlendGroup(alpha, BlendNormal)
+}
+
+// BeginBlendGroup starts a group, as BeginGroup, that is composited, when ended, onto what
+// is beneath it in the blend mode: at the top level, what has been drawn on the canvas'
+// operations (so the canvas should be at the origin of the frame, as the capture package's
+// are); within another group, what has been drawn in that group. The blending is exact
+// over an opaque background, as the result is painted over what it was blended with.
+func (c *Canvas) BeginBlendGroup(alpha float32, mode BlendMode) {
+	c.record(DrawCall{Op: "group", A1: float64(alpha), A2: float64(mode)})
+	g := group{alpha: alpha, mode: mode, ops: c.Context.Ops, own: new(op.Ops)}
+	c.Context.Ops = g.own
+	g.macro = op.Record(g.own)
+	c.groups = append(c.groups, g)
+}
+
+// EndGroup ends the group begun last, drawing it faded, and blended. If the group cannot be
+// rendered (with no Renderer, ErrNoRenderer), it is drawn as it is, and the error returned.
+func (c *Canvas) EndGroup() error {
+	if len(c.groups) == 0 {
+		return nil
+	}
+	g := c.groups[len(c.groups)-1]
+	c.groups = c.groups[:len(c.groups)-1]
+	c.record(DrawCall{Op: "endgroup", A1: float64(g.alpha), A2: float64(g.mode)})
+	calls := append(g.calls, g.macro.Stop())
+	c.Context.Ops = g.ops
+	add := func(ops *op.Ops) {
+		for _, call := range calls {
+			call.Add(ops)
+		}
+	}
+	switch {
+	case g.alpha <= 0:
+		return nil
+	case g.alpha >= 1 && g.mode == BlendNormal:
+		add(c.Context.Ops)
+		return nil
+	case c.Renderer == nil:
+		add(c.Context.Ops)
+		return ErrNoRenderer
+	}
+	add(g.own)
+	img, err := c.Renderer.Render(g.own, int(c.Width), int(c.Height))
+	if err == nil {
+		fade(img, g.alpha)
+		if g.mode != BlendNormal {
+			img, err = c.blend(img, g.mode)
+		}
+	}
+	if err != nil {
+		add(c.Context.Ops)
+		return err
+	}
+	paint.NewImageOp(img).Add(c.Context.Ops)
+	paint.PaintOp{}.Add(c.Context.Ops)
+	return nil
+}
+
+// blend renders what a group ending now is beneath, and composites the group's pixels onto it
+func (c *Canvas) blend(img *image.RGBA, mode BlendMode) (*image.RGBA, error) {
+	ops := c.Context.Ops
+	if len(c.groups) > 0 {
+		ops = new(op.Ops)
+		for _, call := range c.groups[len(c.groups)-1].drawn() {
+			call.Add(ops)
+		}
+	}
+	backdrop, err := c.Renderer.Render(ops, int(c.Width), int(c.Height))
+	if err != nil {
+		return nil, err
+	}
+	Blend(backdrop, img, mode)
+	return backdrop, nil
+}
+
+// fade scales the (premultiplied) pixels of img by alpha
+func fade(img *image.RGBA, alpha float32) {
+	if alpha >= 1 {
+		return
+	}
+	a := uint32(alpha*256 + 0.5)
+	for i := range img.Pix {
+		img.Pix[i] = uint8(uint32(img.Pix[i]) * a >> 8)
+	}
+}
@@ -0,0 +1,98 @@
+package giocanvas
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gioui.org/op"
+)
+
+func TestGroup(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.SetRGBA(0, 0, color.RGBA{200, 100, 0, 200})
+	fade(img, 0.5)
+	if got, want := img.RGBAAt(0, 0), (color.RGBA{100, 50, 0, 100}); got != want {
+		t.Errorf("fade: got %v, want %v", got, want)
+	}
+
+	c, log := NewRecordingCanvas(200, 100)
+	c.BeginGroup(0.5)
+	if err := c.EndGroup(); err != ErrNoRenderer {
+		t.Errorf("group without a renderer: got %v, want ErrNoRenderer", err)
+	}
+
+	r := new(testrenderer)
+	c, log = NewRecordingCanvas(200, 100)
+	c.Renderer = r
+	main := c.Context.Ops
+	c.BeginGroup(0.5)
+	c.Circle(50, 50, 10, color.NRGBA{255, 0, 0, 255})
+	c.BeginGroup(1)
+	c.Circle(60, 50, 10, color.NRGBA{0, 0, 255, 255})
+	for i := 0; i < 3; i++ { // the last, unbalanced, is ignored
+		if err := c.EndGroup(); err != nil {
+			t.Errorf("EndGroup: %v", err)
+		}
+	}
+	if c.Context.Ops != main {
+		t.Error("drawing did not resume on the canvas' operations")
+	}
+	if len(r.rendered) != 1 || r.rendered[0] != 200 {
+		t.Errorf("rendered %v, want the faded group only, 200 wide", r.rendered)
+	}
+	if g := log.Find("group"); len(g) != 2 || g[0].A1 != 0.5 {
+		t.Errorf("group calls %v, want two, the first of alpha 0.5", g)
+	}
+	if n := len(log.Calls); n != 6 {
+		t.Errorf("%d calls recorded, want 6", n)
+	}
+}
+
+func TestBlendGroup(t *testing.T) {
+	c, _ := NewRecordingCanvas(200, 100)
+	c.BeginBlendGroup(1, BlendMultiply)
+	if err := c.EndGroup(); err != ErrNoRenderer {
+		t.Errorf("blended group without a renderer: got %v, want ErrNoRenderer", err)
+	}
+
+	r := new(testrenderer)
+	c, log := NewRecordingCanvas(200, 100)
+	c.Renderer = r
+	c.Background(color.NRGBA{255, 255, 255, 255})
+	c.BeginBlendGroup(1, BlendMultiply) // the group, and the canvas beneath
+	c.Circle(50, 50, 10, color.NRGBA{255, 0, 0, 255})
+	c.EndGroup()
+	if len(r.rendered) != 2 {
+		t.Errorf("rendered %d images, want 2", len(r.rendered))
+	}
+
+	r.rendered = nil
+	c.BeginGroup(0.5) // the group, the inner group, and the outer's drawing beneath it
+	c.Circle(40, 50, 10, color.NRGBA{0, 0, 255, 255})
+	c.BeginBlendGroup(1, BlendScreen)
+	c.Circle(60, 50, 10, color.NRGBA{255, 0, 0, 255})
+	c.EndGroup()
+	outer := &c.groups[0]
+	if len(outer.calls) != 1 {
+		t.Errorf("the outer group was cut %d times, want once, for the inner's backdrop", len(outer.calls))
+	}
+	c.Circle(50, 60, 10, color.NRGBA{0, 255, 0, 255})
+	c.EndGroup()
+	if len(r.rendered) != 3 {
+		t.Errorf("rendered %d images, want 3", len(r.rendered))
+	}
+	if g := log.Find("group"); len(g) != 3 || BlendMode(g[2].A2) != BlendScreen {
+		t.Errorf("group calls %v, want three, the last screened", g)
+	}
+}
+
+// testrenderer notes the widths it renders, returning blank images
+type testrenderer struct {
+	rendered []int
+}
+
+func (r *testrenderer) Render(ops *op.Ops, w, h int) (*image.RGBA, error) {
+	r.rendered = append(r.rendered, w)
+	return image.NewRGBA(image.Rect(0, 0, w, h)), nil
+}
@@ -0,0 +1,73 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Hatch fills a shape with parallel lines, Spacing apart and Width wide (percentages of
+// the canvas width), at Angle (degrees, counter-clockwise from left to right); Cross adds
+// lines at right angles to those. Lines are laid from the canvas' origin, so those of
+// neighboring shapes line up. A Hatch may be used wherever a Gradient is, as for
+// charts to be printed in one color.
+type Hatch struct {
+	Color          color.NRGBA
+	Angle          float64
+	Spacing, Width float32
+	Cross          bool
+}
+
+// hatchlines returns the quadrilaterals of lines at angle, spacing apart and width wide,
+// that cross the box
+func hatchlines(x, y, w, h float32, angle float64, spacing, width float32) [][4]f32.Point {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	d := f32.Pt(float32(cos), -float32(sin)) // along the lines
+	n := f32.Pt(-d.Y, d.X)                   // across them
+	dot := func(a, b f32.Point) float32 { return a.X*b.X + a.Y*b.Y }
+	corners := []f32.Point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x, Y: y + h}, {X: x + w, Y: y + h}}
+	lo, hi := dot(corners[0], n), dot(corners[0], n)
+	for _, p := range corners[1:] {
+		lo, hi = min32(lo, dot(p, n)), max32(hi, dot(p, n))
+	}
+	mid := f32.Pt(x+w/2, y+h/2)
+	along := d.Mul(float32(math.Hypot(float64(w), float64(h))) / 2)
+	var lines [][4]f32.Point
+	first := int(math.Ceil(float64((lo - width/2) / spacing)))
+	last := int(math.Floor(float64((hi + width/2) / spacing)))
+	for k := first; k <= last; k++ {
+		o := float32(k) * spacing
+		c := mid.Add(n.Mul(o - dot(mid, n))) // the point of the line nearest the middle
+		a, b := n.Mul(-width/2), n.Mul(width/2)
+		lines = append(lines, [4]f32.Point{c.Sub(along).Add(a), c.Add(along).Add(a), c.Add(along).Add(b), c.Sub(along).Add(b)})
+	}
+	return lines
+}
+
+func (p Hatch) paint(c *Canvas, x, y, w, h float32) {
+	spacing, width := pct(p.Spacing, c.Width), pct(p.Width, c.Width)
+	if spacing <= 0 || width <= 0 || w <= 0 || h <= 0 {
+		return
+	}
+	lines := hatchlines(x, y, w, h, p.Angle, spacing, width)
+	if p.Cross {
+		lines = append(lines, hatchlines(x, y, w, h, p.Angle+90, spacing, width)...)
+	}
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	for _, q := range lines {
+		path.MoveTo(q[0])
+		path.LineTo(q[1])
+		path.LineTo(q[2])
+		path.LineTo(q[3])
+		path.Close()
+	}
+	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
+	paint.ColorOp{Color: p.Color}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
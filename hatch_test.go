@@ -0,0 +1,35 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+
+	"gioui.org/f32"
+)
+
+func TestHatch(t *testing.T) {
+	// horizontal lines, 10 apart, at 0, 10 and 20 across a box 20 high
+	lines := hatchlines(0, 0, 100, 20, 0, 10, 2)
+	if len(lines) != 3 {
+		t.Fatalf("%d lines, want 3", len(lines))
+	}
+	for i, q := range lines {
+		y := float32(10 * i)
+		if !nearpoint(q[0], f32.Pt(q[0].X, y-1)) || !nearpoint(q[2], f32.Pt(q[2].X, y+1)) {
+			t.Errorf("line %d: %v, want from y %v to %v", i, q, y-1, y+1)
+		}
+		if q[0].X > 0 || q[1].X < 100 {
+			t.Errorf("line %d: %v does not cross the box", i, q)
+		}
+	}
+	// lines are laid from the origin, not the box
+	if got := len(hatchlines(5, 5, 10, 10, 90, 4, 1)); got != 2 {
+		t.Errorf("%d vertical lines across 5-15, want 2 (at 8 and 12)", got)
+	}
+
+	c, log := NewRecordingCanvas(200, 100)
+	c.PolygonGradient([]float32{10, 50, 30}, []float32{10, 10, 50}, Hatch{Color: color.NRGBA{0, 0, 0, 255}, Angle: 45, Spacing: 2, Width: 0.5, Cross: true})
+	if len(log.Find("polygon")) != 1 {
+		t.Errorf("recorded %v, want a polygon", log.Calls)
+	}
+}
@@ -0,0 +1,174 @@
+package giocanvas
+
+// Undo and redo: edits to a scene made as commands that can be reversed
+
+// Command is an edit to a scene that can be undone
+type Command interface {
+	Do(s *Scene)
+	Undo(s *Scene)
+}
+
+// History applies commands to a scene, keeping them for undo and redo.
+// Limit bounds the number of commands kept for undo (0 for no limit).
+type History struct {
+	Scene  *Scene
+	Limit  int
+	done   []Command
+	undone []Command
+}
+
+// NewHistory makes a history for editing a scene
+func NewHistory(s *Scene) *History {
+	return &History{Scene: s}
+}
+
+// Do applies a command, and makes it the one to undo next. Redo is no longer possible.
+func (h *History) Do(cmd Command) {
+	cmd.Do(h.Scene)
+	h.done = append(h.done, cmd)
+	if h.Limit > 0 && len(h.done) > h.Limit {
+		h.done = h.done[len(h.done)-h.Limit:]
+	}
+	h.undone = nil
+}
+
+// Undo reverses the last command, reporting whether there was one
+func (h *History) Undo() bool {
+	n := len(h.done)
+	if n == 0 {
+		return false
+	}
+	cmd := h.done[n-1]
+	cmd.Undo(h.Scene)
+	h.done = h.done[:n-1]
+	h.undone = append(h.undone, cmd)
+	return true
+}
+
+// Redo applies the last undone command again, reporting whether there was one
+func (h *History) Redo() bool {
+	n := len(h.undone)
+	if n == 0 {
+		return false
+	}
+	cmd := h.undone[n-1]
+	cmd.Do(h.Scene)
+	h.undone = h.undone[:n-1]
+	h.done = append(h.done, cmd)
+	return true
+}
+
+// CanUndo reports whether there is a command to undo
+func (h *History) CanUndo() bool {
+	return len(h.done) > 0
+}
+
+// CanRedo reports whether there is a command to redo
+func (h *History) CanRedo() bool {
+	return len(h.undone) > 0
+}
+
+// Clear forgets all commands
+func (h *History) Clear() {
+	h.done, h.undone = nil, nil
+}
+
+// AddNode adds Node to the group Parent (0 for the top level of the scene)
+type AddNode struct {
+	Node   *Node
+	Parent int
+}
+
+// Do adds the node
+func (a *AddNode) Do(s *Scene) {
+	s.Insert(a.Parent, -1, a.Node)
+}
+
+// Undo removes the node
+func (a *AddNode) Undo(s *Scene) {
+	s.Remove(a.Node.ID)
+}
+
+// RemoveNode removes the node with the specified ID
+type RemoveNode struct {
+	ID            int
+	node          *Node
+	parent, index int
+}
+
+// Do removes the node, remembering where it was
+func (r *RemoveNode) Do(s *Scene) {
+	r.node, r.parent, r.index = s.Remove(r.ID)
+}
+
+// Undo puts the node back
+func (r *RemoveNode) Undo(s *Scene) {
+	if r.node != nil {
+		s.Insert(r.parent, r.index, r.node)
+	}
+}
+
+// MoveNode moves the node with the specified ID by (DX, DY), using percentage-based measures
+type MoveNode struct {
+	ID     int
+	DX, DY float32
+}
+
+// Do moves the node
+func (m *MoveNode) Do(s *Scene) {
+	if n := s.Find(m.ID); n != nil {
+		n.Transform.DX += m.DX
+		n.Transform.DY += m.DY
+	}
+}
+
+// Undo moves the node back
+func (m *MoveNode) Undo(s *Scene) {
+	if n := s.Find(m.ID); n != nil {
+		n.Transform.DX -= m.DX
+		n.Transform.DY -= m.DY
+	}
+}
+
+// SetNode replaces the attributes (shape, style and transform) of the node with
+// the ID of Node by those of Node; the node's children are kept
+type SetNode struct {
+	Node Node
+	old  Node
+}
+
+// Do changes the node, remembering its attributes
+func (e *SetNode) Do(s *Scene) {
+	if n := s.Find(e.Node.ID); n != nil {
+		e.old = *n
+		children := n.Children
+		*n = e.Node
+		n.Children = children
+	}
+}
+
+// Undo restores the node's attributes
+func (e *SetNode) Undo(s *Scene) {
+	if n := s.Find(e.Node.ID); n != nil {
+		children := n.Children
+		*n = e.old
+		n.Children = children
+	}
+}
+
+// Commands is a group of commands, done and undone together
+type Commands []Command
+
+// Do applies the commands in order
+func (c Commands) Do(s *Scene) {
+	for _, cmd := range c {
+		cmd.Do(s)
+	}
+}
+
+// Undo reverses the commands in reverse order
+func (c Commands) Undo(s *Scene) {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i].Undo(s)
+	}
+}
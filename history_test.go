@@ -0,0 +1,29 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	s := NewScene()
+	h := NewHistory(s)
+	red := color.NRGBA{255, 0, 0, 255}
+	group := &Node{Kind: "group", Children: []*Node{{Kind: "circle", X: 50, Y: 50, W: 5, Fill: red}}}
+	h.Do(&AddNode{Node: group})
+	h.Do(&AddNode{Node: &Node{Kind: "rect", X: 20, Y: 20, W: 10, H: 10, Fill: red}})
+	circle := group.Children[0].ID
+	h.Do(Commands{&MoveNode{ID: circle, DX: 5}, &RemoveNode{ID: circle}})
+	if len(group.Children) != 0 {
+		t.Fatalf("circle not removed: %+v", group.Children)
+	}
+	if !h.Undo() || len(group.Children) != 1 || group.Children[0].Transform.DX != 0 {
+		t.Errorf("undo: %+v", group.Children)
+	}
+	if !h.Undo() || len(s.Nodes) != 1 || !h.Redo() || len(s.Nodes) != 2 {
+		t.Errorf("undo/redo add: %d nodes", len(s.Nodes))
+	}
+	if n := s.Hit(20, 20); n == nil || n.Kind != "rect" {
+		t.Errorf("hit: %+v", n)
+	}
+}
@@ -0,0 +1,179 @@
+package giocanvas
+
+import (
+	"image"
+	"os"
+	"sync"
+
+	"gioui.org/op/paint"
+	"golang.org/x/image/draw"
+)
+
+// Image cache: images read from files are decoded once, and their
+// image operations reused, so they are only uploaded to the GPU once.
+
+// MaxImageSize limits the longest side (in pixels) of images decoded from files.
+// Larger images are downscaled when decoded, and decoded again at a higher
+// resolution when they are drawn larger than that. Zero means no limit.
+var MaxImageSize int
+
+// cachedimage is a decoded image with its paint operation, and the
+// dimensions of the image in the file
+type cachedimage struct {
+	img     image.Image
+	imop    paint.ImageOp
+	natural image.Point
+}
+
+// imagekey identifies a cached image: the file name and the size limit used to decode it
+type imagekey struct {
+	name  string
+	limit int
+}
+
+var imagecache struct {
+	sync.Mutex
+	images map[imagekey]cachedimage
+}
+
+// decodeimage reads and decodes the named image file
+func decodeimage(name string) (image.Image, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	im, _, err := image.Decode(r)
+	return im, err
+}
+
+// downscale returns im scaled so that its longest side is at most limit pixels
+func downscale(im image.Image, limit int) image.Image {
+	b := im.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if limit <= 0 || (w <= limit && h <= limit) {
+		return im
+	}
+	if w > h {
+		w, h = limit, h*limit/w
+	} else {
+		w, h = w*limit/h, limit
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.BiLinear.Scale(dst, dst.Bounds(), im, b, draw.Src, nil)
+	return dst
+}
+
+// loadimage returns the cached image and operation for the named file, decoding on first use
+func loadimage(name string) (cachedimage, error) {
+	return loadimagelimit(name, MaxImageSize)
+}
+
+// loadimagelimit returns the named image, downscaled to limit pixels on its longest side
+func loadimagelimit(name string, limit int) (cachedimage, error) {
+	key := imagekey{name, limit}
+	imagecache.Lock()
+	ci, ok := imagecache.images[key]
+	imagecache.Unlock()
+	if ok {
+		return ci, nil
+	}
+	im, err := decodeimage(name)
+	if err != nil {
+		return ci, err
+	}
+	b := im.Bounds()
+	im = downscale(im, limit)
+	ci = cachedimage{img: im, imop: paint.NewImageOp(im), natural: image.Pt(b.Dx(), b.Dy())}
+	imagecache.Lock()
+	if imagecache.images == nil {
+		imagecache.images = make(map[imagekey]cachedimage)
+	}
+	imagecache.images[key] = ci
+	imagecache.Unlock()
+	return ci, nil
+}
+
+// loadimagefor returns the named image, decoded at a resolution suitable for drawing it at scale sc
+func loadimagefor(name string, sc float32) (cachedimage, error) {
+	ci, err := loadimage(name)
+	if err != nil || MaxImageSize <= 0 {
+		return ci, err
+	}
+	size := float32(longest(ci.natural)) * sc
+	if size <= float32(longest(ci.imop.Size())) || ci.imop.Size() == ci.natural {
+		return ci, nil
+	}
+	limit := MaxImageSize
+	for float32(limit) < size {
+		limit *= 2
+	}
+	return loadimagelimit(name, limit)
+}
+
+// longest returns the longest side of p
+func longest(p image.Point) int {
+	if p.Y > p.X {
+		return p.Y
+	}
+	return p.X
+}
+
+// LoadImage returns the decoded image in the named file, decoding it on first use.
+// The image is downscaled if it is larger than MaxImageSize.
+func LoadImage(name string) (image.Image, error) {
+	ci, err := loadimage(name)
+	return ci.img, err
+}
+
+// ImageSize returns the dimensions of the image in the named file, before any downscaling
+func ImageSize(name string) (image.Point, error) {
+	ci, err := loadimage(name)
+	return ci.natural, err
+}
+
+// PreloadImages decodes the named image files into the cache concurrently, using
+// up to workers goroutines. If progress is not nil, it is called (from the worker
+// goroutines) as each image is done. Images that cannot be read are skipped.
+func PreloadImages(names []string, workers int, progress func(done, total int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	work := make(chan string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range work {
+				loadimage(name)
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, len(names))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, name := range names {
+		work <- name
+	}
+	close(work)
+	wg.Wait()
+}
+
+// ClearImageCache drops all cached images
+func ClearImageCache() {
+	imagecache.Lock()
+	imagecache.images = nil
+	imagecache.Unlock()
+}
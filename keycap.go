@@ -0,0 +1,55 @@
+package giocanvas
+
+import (
+	"image/color"
+
+	"gioui.org/font"
+	"gioui.org/text"
+)
+
+// Keycaps: key names drawn as the keys of a keyboard, for keyboard shortcuts in text
+
+// The colors of keycaps: the face, its edge, and the shadow beneath
+var (
+	keycapface   = color.NRGBA{248, 248, 248, 255}
+	keycapedge   = color.NRGBA{190, 190, 190, 255}
+	keycapshadow = color.NRGBA{0, 0, 0, 50}
+)
+
+// keycapwidth returns the width of the keycap of the key, at the size of the text it is in
+func (c *Canvas) keycapwidth(key string, size float32) float32 {
+	style := c.textstyle
+	c.textstyle = font.Font{Variant: "Mono"}
+	w := float32(c.measuretext(key, size*0.8).X)
+	c.textstyle = style
+	return w + size*0.8
+}
+
+// AbsKeycap draws the key name as a keycap in a line of text of the size, with the baseline
+// at y and the left edge at x, returning its width
+func (c *Canvas) AbsKeycap(x, y, size float32, key string, textcolor color.NRGBA) float32 {
+	w := c.keycapwidth(key, size)
+	c.record(DrawCall{Op: "keycap", W: w, Size: size, Text: key, Color: textcolor}, x, y)
+	rec, style := c.Recorder, c.textstyle
+	c.Recorder = nil
+	alpha := func(col color.NRGBA) color.NRGBA {
+		col.A = uint8(uint32(col.A) * uint32(textcolor.A) / 255)
+		return col
+	}
+	top, h, r := y-size*0.95, size*1.3, size*0.25
+	c.AbsRoundedRect(x, top+size*0.1, w, h, r, alpha(keycapshadow))
+	c.AbsRoundedRect(x, top, w, h, r, alpha(keycapedge))
+	edge := size * 0.06
+	c.AbsRoundedRect(x+edge, top+edge, w-2*edge, h-3*edge, r-edge, alpha(keycapface))
+	c.textstyle = font.Font{Variant: "Mono"}
+	c.textops(x+w/2, y, size*0.8, text.Middle, key, textcolor)
+	c.Recorder, c.textstyle = rec, style
+	return w
+}
+
+// Keycap draws the key name as a keycap, as AbsKeycap, using percentage-based measures,
+// returning its width (a percentage of the width)
+func (c *Canvas) Keycap(x, y, size float32, key string, textcolor color.NRGBA) float32 {
+	x, y = dimen(x, y, c.Width, c.Height)
+	return 100 * c.AbsKeycap(x, y, pct(size, c.Width), key, textcolor) / c.Width
+}
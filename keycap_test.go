@@ -0,0 +1,15 @@
+package giocanvas
+
+import "testing"
+
+func TestKeycap(t *testing.T) {
+	c, log := NewRecordingCanvas(400, 200)
+	w1 := c.AbsKeycap(10, 50, 20, "C", c.TextColor)
+	w2 := c.AbsKeycap(10+w1, 50, 20, "Ctrl", c.TextColor)
+	if w1 <= 20*0.8 || w2 <= w1 {
+		t.Errorf("widths %v and %v, want the longer name wider, both wider than their padding", w1, w2)
+	}
+	if got := log.Find("keycap"); len(got) != 2 || len(log.Calls) != 2 {
+		t.Errorf("recorded %v, want two keycaps", log.Calls)
+	}
+}
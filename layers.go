@@ -0,0 +1,65 @@
+package giocanvas
+
+import (
+	"sync"
+
+	"gioui.org/op"
+)
+
+// Layers: preparing independent parts of a drawing concurrently
+
+// Layer returns a canvas with the same dimensions and settings as c, but with
+// its own operations, so that it may be drawn on another goroutine.
+// Add the drawing to c (on the goroutine that owns c) with Merge.
+// If c has a Recorder, the layer's calls are logged and passed on when merged.
+func (c *Canvas) Layer() *Canvas {
+	l := new(Canvas)
+	*l = *c
+	l.Context.Ops = new(op.Ops)
+	l.semnodes = nil
+	l.semrole, l.semlabel = "", ""
+	l.groups = nil
+	l.shapes = shapecache{} // the cache is not shared between goroutines
+	if c.Recorder != nil {
+		l.Recorder = new(CallLog)
+	}
+	l.layer = op.Record(l.Context.Ops)
+	l.layered = true
+	l.inset = false // the margins are pushed on c's operations
+	return l
+}
+
+// Merge adds the drawing of layers to c, in order. Each layer may be merged once,
+// after drawing on it has finished.
+func (c *Canvas) Merge(layers ...*Canvas) {
+	for _, l := range layers {
+		if l == nil || !l.layered {
+			continue
+		}
+		l.layered = false
+		l.layer.Stop().Add(c.Context.Ops)
+		c.semnodes = append(c.semnodes, l.semnodes...)
+		if log, ok := l.Recorder.(*CallLog); ok && c.Recorder != nil {
+			for _, d := range log.Calls {
+				c.Recorder.Record(d)
+			}
+		}
+	}
+}
+
+// Parallel calls each draw function on its own layer, concurrently,
+// then merges the layers in order.
+func (c *Canvas) Parallel(draw ...func(*Canvas)) {
+	layers := make([]*Canvas, len(draw))
+	var wg sync.WaitGroup
+	for i, f := range draw {
+		layers[i] = c.Layer()
+		wg.Add(1)
+		go func(l *Canvas, f func(*Canvas)) {
+			defer wg.Done()
+			f(l)
+		}(layers[i], f)
+	}
+	wg.Wait()
+	c.Merge(layers...)
+}
@@ -0,0 +1,35 @@
+package giocanvas
+
+import "testing"
+
+func TestParallel(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 1000)
+	c.Parallel(
+		func(l *Canvas) { l.Circle(25, 50, 10, l.TextColor) },
+		func(l *Canvas) { l.Text(75, 50, 3, "right", l.TextColor) },
+	)
+	if len(log.Calls) != 2 || log.Calls[0].Op != "circle" || log.Calls[1].Op != "text" {
+		t.Fatalf("merged calls: %+v", log.Calls)
+	}
+}
+
+func TestParallelShapes(t *testing.T) {
+	// layers draw circles, of the size cached by the canvas and others, at once
+	// (run with -race)
+	c, _ := NewRecordingCanvas(1000, 1000)
+	c.Circle(50, 50, 10, c.TextColor)
+	draw := make([]func(*Canvas), 8)
+	for i := range draw {
+		r := float32(i%2*5 + 10)
+		draw[i] = func(l *Canvas) {
+			for j := 0; j < 20; j++ {
+				l.Circle(50, 50, r+float32(j), l.TextColor)
+				l.CircleGradient(50, 50, r, RadialGradient{Stops: []GradientStop{{0, l.TextColor}}})
+			}
+		}
+	}
+	c.Parallel(draw...)
+	if n := len(c.shapes.paths); n != 1 {
+		t.Errorf("the canvas' cache has %d shapes, want 1", n)
+	}
+}
@@ -0,0 +1,48 @@
+package giocanvas
+
+import (
+	"gioui.org/f32"
+	"gioui.org/op"
+)
+
+// Margins: mapping percentage-based coordinates into a safe area
+
+// SetMargins insets the drawing area of the canvas by the margins (percentages
+// of the full canvas width and height), so that percentage-based coordinates
+// (0-100) cover only the area inside them: for example, the safe area of a TV with overscan.
+// Width and Height become the dimensions of the safe area, and absolute
+// coordinates are relative to its upper left corner.
+// Draw anything that should fill the whole canvas before setting the margins.
+func (c *Canvas) SetMargins(top, right, bottom, left float32) {
+	c.ClearMargins()
+	c.fullw, c.fullh = c.Width, c.Height
+	c.originx, c.originy = pct(left, c.Width), pct(top, c.Height)
+	c.Width -= c.originx + pct(right, c.Width)
+	c.Height -= c.originy + pct(bottom, c.Height)
+	c.margins = op.Affine(f32.Affine2D{}.Offset(f32.Pt(c.originx, c.originy))).Push(c.Context.Ops)
+	c.inset = true
+}
+
+// SafeArea sets equal margins on all sides of the canvas (a percentage of its width and height)
+func (c *Canvas) SafeArea(margin float32) {
+	c.SetMargins(margin, margin, margin, margin)
+}
+
+// ClearMargins restores the full canvas as the drawing area
+func (c *Canvas) ClearMargins() {
+	if !c.inset {
+		return
+	}
+	c.margins.Pop()
+	c.Width, c.Height = c.fullw, c.fullh
+	c.originx, c.originy = 0, 0
+	c.inset = false
+}
+
+// Origin returns the absolute location of the upper left corner of the
+// drawing area within the full canvas, (0, 0) unless margins are set.
+// Subtract it from positions in window coordinates, such as those of pointer
+// events received outside the drawing area, to convert them to canvas coordinates.
+func (c *Canvas) Origin() (float32, float32) {
+	return c.originx, c.originy
+}
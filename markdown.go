@@ -0,0 +1,254 @@
+package giocanvas
+
+import (
+	"image/color"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/text"
+)
+
+// Markdown: a small subset -- headings, bullet lists, paragraphs, and within them
+// bold, italic, inline code, links and <kbd> keys -- laid out and wrapped on the canvas
+
+// kinds of markdown block
+const (
+	mdParagraph = iota
+	mdHeading
+	mdBullet
+)
+
+// mdblock is a heading (level 1-3), bullet (level of indentation from 0) or paragraph
+type mdblock struct {
+	kind, level int
+	text        string
+}
+
+// mdblocks splits markdown into blocks. Paragraphs run until a blank line, heading or
+// bullet; the lines of a bullet continue its text until then too.
+func mdblocks(md string) []mdblock {
+	var blocks []mdblock
+	open := false // whether the last block takes continuation lines
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		switch {
+		case trimmed == "":
+			open = false
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if level > 3 {
+				level = 3
+			}
+			blocks = append(blocks, mdblock{kind: mdHeading, level: level, text: strings.TrimSpace(strings.TrimLeft(trimmed, "#"))})
+			open = false
+		case len(trimmed) > 1 && strings.ContainsRune("-*+", rune(trimmed[0])) && trimmed[1] == ' ':
+			blocks = append(blocks, mdblock{kind: mdBullet, level: indent / 2, text: strings.TrimSpace(trimmed[2:])})
+			open = true
+		case open:
+			blocks[len(blocks)-1].text += " " + trimmed
+		default:
+			blocks = append(blocks, mdblock{kind: mdParagraph, text: trimmed})
+			open = true
+		}
+	}
+	return blocks
+}
+
+// mdspan is a run of text in one style; link is the target of a link,
+// and kbd marks the name of a key
+type mdspan struct {
+	text                    string
+	bold, italic, code, kbd bool
+	link                    string
+}
+
+// mdinline splits the text of a block into styled spans: **bold**, *italic* or _italic_,
+// `code`, [links](target) and <kbd>keys</kbd>; a backslash makes the next character plain
+func mdinline(s string) []mdspan {
+	var spans []mdspan
+	var cur strings.Builder
+	bold, italic := false, false
+	flush := func() {
+		if cur.Len() > 0 {
+			spans = append(spans, mdspan{text: cur.String(), bold: bold, italic: italic})
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\\' && i+1 < len(s):
+			i++
+			cur.WriteByte(s[i])
+		case strings.HasPrefix(s[i:], "**"):
+			flush()
+			bold = !bold
+			i++
+		case ch == '*' || ch == '_' && (i == 0 || s[i-1] == ' ' || italic):
+			flush()
+			italic = !italic
+		case ch == '`':
+			end := strings.IndexByte(s[i+1:], '`')
+			if end < 0 {
+				cur.WriteByte(ch)
+				continue
+			}
+			flush()
+			spans = append(spans, mdspan{text: s[i+1 : i+1+end], code: true})
+			i += end + 1
+		case strings.HasPrefix(s[i:], "<kbd>"):
+			end := strings.Index(s[i:], "</kbd>")
+			if end < 0 {
+				cur.WriteByte(ch)
+				continue
+			}
+			flush()
+			spans = append(spans, mdspan{text: s[i+5 : i+end], kbd: true})
+			i += end + 5
+		case ch == '[':
+			close := strings.Index(s[i:], "](")
+			end := strings.IndexByte(s[i:], ')')
+			if close < 0 || end < close {
+				cur.WriteByte(ch)
+				continue
+			}
+			flush()
+			spans = append(spans, mdspan{text: s[i+1 : i+close], bold: bold, italic: italic, link: s[i+close+2 : i+end]})
+			i += end
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	flush()
+	return spans
+}
+
+// mdword is a word of a span, and whether a space comes before it
+type mdword struct {
+	text  string
+	span  mdspan
+	space bool
+}
+
+// mdwords splits spans into words; words of adjacent spans not separated by
+// a space are kept together, and keys are not split
+func mdwords(spans []mdspan) []mdword {
+	var words []mdword
+	space := false
+	for _, sp := range spans {
+		if sp.kbd {
+			words = append(words, mdword{text: sp.text, span: sp, space: space})
+			space = false
+			continue
+		}
+		for i, w := range strings.Split(sp.text, " ") {
+			if i > 0 {
+				space = true
+			}
+			if w == "" {
+				continue
+			}
+			words = append(words, mdword{text: w, span: sp, space: space})
+			space = false
+		}
+	}
+	return words
+}
+
+// The colors of code and links
+var (
+	mdcodebg    = color.NRGBA{235, 235, 235, 255}
+	mdlinkcolor = color.NRGBA{0, 102, 204, 255}
+)
+
+// AbsMarkdown lays out markdown in the width, with the baseline of the first line at
+// (x, y), returning the baseline of the line after the last
+func (c *Canvas) AbsMarkdown(x, y, size, width float32, md string, textcolor color.NRGBA) float32 {
+	c.record(DrawCall{Op: "markdown", W: width, Size: size, Text: md, Color: textcolor}, x, y)
+	rec, sem, style := c.Recorder, c.Semantic, c.textstyle
+	c.Recorder, c.Semantic = nil, false
+	defer func() { c.Recorder, c.Semantic, c.textstyle = rec, sem, style }()
+
+	top := y - size
+	var plain []string
+	for i, b := range mdblocks(md) {
+		fs, left := size, x
+		switch b.kind {
+		case mdHeading:
+			fs = size * [...]float32{1.8, 1.45, 1.2}[b.level-1]
+			if i > 0 {
+				y += size * 0.5
+			}
+		case mdBullet:
+			left = x + size*1.5*float32(b.level+1)
+			c.textstyle = font.Font{}
+			c.textops(left-size, y+(fs-size), fs, text.Start, "•", textcolor)
+		}
+		if i > 0 || b.kind == mdHeading {
+			y += fs - size
+		}
+		c.textstyle = font.Font{}
+		spacew := float32(c.measuretext("n n", fs).X - c.measuretext("nn", fs).X)
+		lx := left
+		var prevcode bool
+		for _, w := range mdwords(mdinline(b.text)) {
+			f := font.Font{}
+			if w.span.bold || b.kind == mdHeading {
+				f.Weight = font.Bold
+			}
+			if w.span.italic {
+				f.Style = font.Italic
+			}
+			if w.span.code {
+				f.Variant = "Mono"
+			}
+			c.textstyle = f
+			ww := float32(c.measuretext(w.text, fs).X)
+			if w.span.kbd {
+				ww = c.keycapwidth(w.text, fs)
+			}
+			if w.space && lx > left {
+				lx += spacew
+			}
+			if lx+ww > left+width && lx > left {
+				y += fs * 1.4
+				lx = left
+			}
+			if w.span.kbd {
+				c.AbsKeycap(lx, y, fs, w.text, textcolor)
+				lx += ww
+				plain = append(plain, w.text)
+				continue
+			}
+			col := textcolor
+			if w.span.code {
+				bx := lx
+				if prevcode && w.space && lx > left {
+					bx -= spacew
+				}
+				c.AbsRect(bx, y-fs, lx+ww-bx, fs*1.25, mdcodebg)
+			}
+			if w.span.link != "" {
+				col = mdlinkcolor
+				col.A = textcolor.A
+				c.AbsRect(lx, y+fs*0.12, ww, fs*0.06, col)
+			}
+			c.textops(lx, y, fs, text.Start, w.text, col)
+			prevcode = w.span.code
+			lx += ww
+			plain = append(plain, w.text)
+		}
+		y += fs*1.4 + size*0.3
+	}
+	c.Semantic = sem
+	c.semantic("text", strings.Join(plain, " "), x, top, width, y-top)
+	return y
+}
+
+// Markdown lays out markdown using percentage-based measures, as AbsMarkdown,
+// returning the baseline of the line after the last
+func (c *Canvas) Markdown(x, y, size, width float32, md string, textcolor color.NRGBA) float32 {
+	x, y = dimen(x, y, c.Width, c.Height)
+	end := c.AbsMarkdown(x, y, pct(size, c.Width), pct(width, c.Width), md, textcolor)
+	return 100 - 100*end/c.Height
+}
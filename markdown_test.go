@@ -0,0 +1,53 @@
+package giocanvas
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	blocks := mdblocks("# Title\n\nsome\ntext\n- one\n  - two\n  more\n\nend")
+	want := []mdblock{
+		{kind: mdHeading, level: 1, text: "Title"},
+		{kind: mdParagraph, text: "some text"},
+		{kind: mdBullet, text: "one"},
+		{kind: mdBullet, level: 1, text: "two more"},
+		{kind: mdParagraph, text: "end"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("blocks: got %v, want %v", blocks, want)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("block %d: got %+v, want %+v", i, blocks[i], want[i])
+		}
+	}
+
+	spans := mdinline("a **b** *c* `d_e` [f](g) snake_case \\* <kbd>Page Up</kbd>")
+	wantspans := []mdspan{
+		{text: "a "},
+		{text: "b", bold: true},
+		{text: " "},
+		{text: "c", italic: true},
+		{text: " "},
+		{text: "d_e", code: true},
+		{text: " "},
+		{text: "f", link: "g"},
+		{text: " snake_case * "},
+		{text: "Page Up", kbd: true},
+	}
+	if len(spans) != len(wantspans) {
+		t.Fatalf("spans: got %+v, want %+v", spans, wantspans)
+	}
+	for i := range wantspans {
+		if spans[i] != wantspans[i] {
+			t.Errorf("span %d: got %+v, want %+v", i, spans[i], wantspans[i])
+		}
+	}
+
+	c, log := NewRecordingCanvas(400, 400)
+	end := c.AbsMarkdown(10, 20, 12, 100, "# Head\nbody text that wraps over a line or two", c.TextColor)
+	if len(log.Calls) != 1 || log.Find("markdown") == nil {
+		t.Errorf("recorded %v, want one markdown call", log.Calls)
+	}
+	if end <= 20+12*3 {
+		t.Errorf("next baseline %v, want below three lines", end)
+	}
+}
@@ -0,0 +1,134 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+)
+
+// Markers: dots, ticks and arrowheads placed along lines, polylines and curves,
+// at their vertices or at intervals, for directed graphs and route maps
+
+// Marker is the shape of a marker
+type Marker int
+
+// The marker shapes. Ticks cross the path; arrowheads point along it.
+const (
+	MarkerDot Marker = iota
+	MarkerTick
+	MarkerArrow
+)
+
+// markerpoints returns the places of markers along the path through x and y: at the
+// vertices after the first if interval is 0, otherwise every interval along the path,
+// starting half an interval in. The angle at each place is the direction of the path.
+func markerpoints(x, y []float32, interval float32) (px, py, angle []float32) {
+	if len(x) != len(y) || len(x) < 2 {
+		return nil, nil, nil
+	}
+	if interval <= 0 {
+		for i := 1; i < len(x); i++ {
+			px = append(px, x[i])
+			py = append(py, y[i])
+			angle = append(angle, float32(math.Atan2(float64(y[i]-y[i-1]), float64(x[i]-x[i-1]))))
+		}
+		return px, py, angle
+	}
+	next := interval / 2
+	var travelled float32
+	for i := 1; i < len(x); i++ {
+		dx, dy := x[i]-x[i-1], y[i]-y[i-1]
+		length := float32(math.Hypot(float64(dx), float64(dy)))
+		if length == 0 {
+			continue
+		}
+		a := float32(math.Atan2(float64(dy), float64(dx)))
+		for ; next <= travelled+length; next += interval {
+			t := (next - travelled) / length
+			px = append(px, x[i-1]+t*dx)
+			py = append(py, y[i-1]+t*dy)
+			angle = append(angle, a)
+		}
+		travelled += length
+	}
+	return px, py, angle
+}
+
+// AbsMarker draws a marker, size across, at (x, y), facing the angle (radians)
+func (c *Canvas) AbsMarker(x, y float32, m Marker, size float32, angle float32, fillcolor color.NRGBA) {
+	sin, cos := math.Sincos(float64(angle))
+	s, co := float32(sin), float32(cos)
+	// at returns the point (along, across) from (x, y), relative to the angle
+	at := func(along, across float32) (float32, float32) {
+		return x + along*co - across*s, y + along*s + across*co
+	}
+	switch m {
+	case MarkerTick:
+		x0, y0 := at(0, -size/2)
+		x1, y1 := at(0, size/2)
+		c.AbsLine(x0, y0, x1, y1, size/5, fillcolor)
+	case MarkerArrow:
+		x1, y1 := at(-size, -size*0.4)
+		x2, y2 := at(-size, size*0.4)
+		c.AbsPolygon([]float32{x, x1, x2}, []float32{y, y1, y2}, fillcolor)
+	default:
+		c.AbsCircle(x, y, size/2, fillcolor)
+	}
+}
+
+// AbsPathMarkers places markers along the path through x and y: at its vertices
+// (after the first) if interval is 0, otherwise every interval along it
+func (c *Canvas) AbsPathMarkers(x, y []float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	px, py, angle := markerpoints(x, y, interval)
+	for i := range px {
+		c.AbsMarker(px[i], py[i], m, size, angle[i], fillcolor)
+	}
+}
+
+// PathMarkers places markers along a path, as AbsPathMarkers, using percentage-based
+// measures; size and interval are percentages of the width
+func (c *Canvas) PathMarkers(x, y []float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	if len(x) != len(y) {
+		return
+	}
+	nx := make([]float32, len(x))
+	ny := make([]float32, len(y))
+	for i := range x {
+		nx[i], ny[i] = dimen(x[i], y[i], c.Width, c.Height)
+	}
+	c.AbsPathMarkers(nx, ny, m, pct(size, c.Width), pct(interval, c.Width), fillcolor)
+}
+
+// LineMarkers places markers along a line from (x0, y0) to (x1, y1), as PathMarkers
+func (c *Canvas) LineMarkers(x0, y0, x1, y1 float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	c.PathMarkers([]float32{x0, x1}, []float32{y0, y1}, m, size, interval, fillcolor)
+}
+
+// CurveMarkers places markers along a quadratic bezier curve, starting at (x, y), control
+// point at (cx, cy), end point (ex, ey), as PathMarkers; with an interval of 0, a single
+// marker is placed at the end
+func (c *Canvas) CurveMarkers(x, y, cx, cy, ex, ey float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	cx, cy = dimen(cx, cy, c.Width, c.Height)
+	ex, ey = dimen(ex, ey, c.Width, c.Height)
+	px, py := flattenquad(x, y, cx, cy, ex, ey)
+	c.curvemarkers(px, py, m, pct(size, c.Width), pct(interval, c.Width), fillcolor)
+}
+
+// CubeCurveMarkers places markers along a cubic bezier curve, as CurveMarkers
+func (c *Canvas) CubeCurveMarkers(x, y, cx1, cy1, cx2, cy2, ex, ey float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	cx1, cy1 = dimen(cx1, cy1, c.Width, c.Height)
+	cx2, cy2 = dimen(cx2, cy2, c.Width, c.Height)
+	ex, ey = dimen(ex, ey, c.Width, c.Height)
+	px, py := flattencubic(x, y, cx1, cy1, cx2, cy2, ex, ey)
+	c.curvemarkers(px, py, m, pct(size, c.Width), pct(interval, c.Width), fillcolor)
+}
+
+// curvemarkers places markers along a flattened curve (absolute), at intervals, or at its end
+func (c *Canvas) curvemarkers(px, py []float32, m Marker, size, interval float32, fillcolor color.NRGBA) {
+	if interval <= 0 {
+		n := len(px) - 1
+		px, py = px[n-1:], py[n-1:]
+	}
+	c.AbsPathMarkers(px, py, m, size, interval, fillcolor)
+}
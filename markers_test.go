@@ -0,0 +1,30 @@
+package giocanvas
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMarkerPoints(t *testing.T) {
+	x := []float32{0, 10, 10}
+	y := []float32{0, 0, 10}
+	px, py, angle := markerpoints(x, y, 0)
+	if !reflect.DeepEqual(px, []float32{10, 10}) || !reflect.DeepEqual(py, []float32{0, 10}) {
+		t.Errorf("vertices: got (%v, %v)", px, py)
+	}
+	if angle[0] != 0 || math.Abs(float64(angle[1])-math.Pi/2) > 1e-6 {
+		t.Errorf("vertex angles: got %v", angle)
+	}
+
+	px, py, _ = markerpoints(x, y, 4)
+	want := [][2]float32{{2, 0}, {6, 0}, {10, 0}, {10, 4}, {10, 8}}
+	if len(px) != len(want) {
+		t.Fatalf("intervals: got (%v, %v), want %v", px, py, want)
+	}
+	for i, w := range want {
+		if math.Abs(float64(px[i]-w[0])) > 1e-4 || math.Abs(float64(py[i]-w[1])) > 1e-4 {
+			t.Errorf("marker %d at (%v, %v), want %v", i, px[i], py[i], w)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+)
+
+// Morphing: interpolating between shapes
+
+// pathlengths returns the cumulative length of the path at each point, and its total length
+func pathlengths(x, y []float32, closed bool) ([]float64, float64) {
+	n := len(x)
+	cum := make([]float64, n+1)
+	for i := 1; i <= n; i++ {
+		j := i % n
+		if i == n && !closed {
+			cum[i] = cum[i-1]
+			break
+		}
+		cum[i] = cum[i-1] + math.Hypot(float64(x[j]-x[i-1]), float64(y[j]-y[i-1]))
+	}
+	return cum, cum[n]
+}
+
+// Resample returns n points evenly spaced along the path through the points (x, y);
+// a closed path includes the edge from the last point back to the first
+func Resample(x, y []float32, n int, closed bool) ([]float32, []float32) {
+	m := len(x)
+	if len(y) < m {
+		m = len(y)
+	}
+	rx, ry := make([]float32, n), make([]float32, n)
+	if m == 0 || n == 0 {
+		return rx, ry
+	}
+	cum, total := pathlengths(x[:m], y[:m], closed)
+	if total == 0 {
+		for i := range rx {
+			rx[i], ry[i] = x[0], y[0]
+		}
+		return rx, ry
+	}
+	step := total / float64(n)
+	if !closed && n > 1 {
+		step = total / float64(n-1)
+	}
+	nseg := m - 1
+	if closed {
+		nseg = m
+	}
+	seg := 0
+	for i := 0; i < n; i++ {
+		d := float64(i) * step
+		for seg < nseg-1 && cum[seg+1] < d {
+			seg++
+		}
+		next := (seg + 1) % m
+		t := float32(0)
+		if l := cum[seg+1] - cum[seg]; l > 0 {
+			t = float32((d - cum[seg]) / l)
+		}
+		if t > 1 {
+			t = 1
+		}
+		rx[i] = Lerp(x[seg], x[next], t)
+		ry[i] = Lerp(y[seg], y[next], t)
+	}
+	return rx, ry
+}
+
+// Morph interpolates between two shapes, resampled to the same number of points
+type Morph struct {
+	x1, y1, x2, y2 []float32
+}
+
+// NewMorph prepares to morph the path through (x1, y1) into the path through (x2, y2).
+// Paths with different numbers of points are resampled to the larger number.
+// The points of closed paths (polygons) are matched starting from the closest pair,
+// so the shape does not twist as it changes.
+func NewMorph(x1, y1, x2, y2 []float32, closed bool) *Morph {
+	n := len(x1)
+	if len(x2) > n {
+		n = len(x2)
+	}
+	if len(x1) != n || len(y1) != n {
+		x1, y1 = Resample(x1, y1, n, closed)
+	}
+	if len(x2) != n || len(y2) != n {
+		x2, y2 = Resample(x2, y2, n, closed)
+	}
+	if closed && n > 1 {
+		x2, y2 = alignpoints(x1, y1, x2, y2)
+	}
+	return &Morph{x1, y1, x2, y2}
+}
+
+// alignpoints rotates the order of the points of the second shape to best match the first
+func alignpoints(x1, y1, x2, y2 []float32) ([]float32, []float32) {
+	n := len(x1)
+	best, bestd := 0, math.Inf(1)
+	for k := 0; k < n; k++ {
+		d := 0.0
+		for i := 0; i < n && d < bestd; i++ {
+			j := (i + k) % n
+			dx, dy := float64(x2[j]-x1[i]), float64(y2[j]-y1[i])
+			d += dx*dx + dy*dy
+		}
+		if d < bestd {
+			best, bestd = k, d
+		}
+	}
+	ax, ay := make([]float32, n), make([]float32, n)
+	for i := range ax {
+		ax[i], ay[i] = x2[(i+best)%n], y2[(i+best)%n]
+	}
+	return ax, ay
+}
+
+// At returns the shape at t (0: the first shape, 1: the second)
+func (m *Morph) At(t float32) ([]float32, []float32) {
+	x, y := make([]float32, len(m.x1)), make([]float32, len(m.y1))
+	for i := range x {
+		x[i] = Lerp(m.x1[i], m.x2[i], t)
+		y[i] = Lerp(m.y1[i], m.y2[i], t)
+	}
+	return x, y
+}
+
+// MorphPolygon draws the polygon part way (t) from the first shape to the second,
+// using percentage-based measures
+func (c *Canvas) MorphPolygon(m *Morph, t float32, fillcolor color.NRGBA) {
+	x, y := m.At(t)
+	c.Polygon(x, y, fillcolor)
+}
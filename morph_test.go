@@ -0,0 +1,39 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMorph(t *testing.T) {
+	// a square, and a triangle with its points in a different order
+	sx, sy := []float32{0, 10, 10, 0}, []float32{0, 0, 10, 10}
+	tx, ty := []float32{10, 5, 0}, []float32{0, 10, 0}
+	m := NewMorph(sx, sy, tx, ty, true)
+	x, y := m.At(0)
+	if len(x) != 4 || x[1] != 10 || y[2] != 10 {
+		t.Errorf("start: %v %v", x, y)
+	}
+	x, y = m.At(1)
+	for i := range x {
+		if y[i] < 0 || y[i] > 10 || math.Abs(float64(x[i]-5)) > 5 {
+			t.Errorf("end point %d (%v, %v) is off the triangle", i, x[i], y[i])
+		}
+	}
+	rx, _ := Resample([]float32{0, 10}, []float32{0, 0}, 5, false)
+	if rx[0] != 0 || rx[2] != 5 || rx[4] != 10 {
+		t.Errorf("resample: %v", rx)
+	}
+}
+
+func TestTween(t *testing.T) {
+	start := time.Now()
+	tw := &Tween{Start: start, Duration: time.Second, Ease: EaseInOut}
+	if tw.At(start) != 0 || tw.At(start.Add(500*time.Millisecond)) != 0.5 || tw.At(start.Add(2*time.Second)) != 1 {
+		t.Error("tween progress")
+	}
+	if tw.Done(start) || !tw.Done(start.Add(time.Second)) {
+		t.Error("tween done")
+	}
+}
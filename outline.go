@@ -0,0 +1,92 @@
+package giocanvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ajstarks/deck"
+)
+
+// Outlines: the text of a deck, slide by slide
+
+// OutlineLine is a line of a slide's text, at its place on the slide (percentages);
+// list items are at level 1
+type OutlineLine struct {
+	X, Y, Size float64
+	Level      int
+	Text       string
+}
+
+// SlideOutline returns the text and list items of a slide, reading top to bottom, left
+// to right, with the spacing of each collapsed; of code, only the first line is kept
+func SlideOutline(slide deck.Slide) []OutlineLine {
+	var lines []OutlineLine
+	for _, t := range slide.Text {
+		tdata := t.Tdata
+		if t.File != "" {
+			data, err := os.ReadFile(t.File)
+			if err != nil {
+				continue
+			}
+			tdata = string(data)
+		}
+		if t.Type == "code" { // just the first line of code
+			tdata = strings.SplitN(strings.TrimSpace(tdata), "\n", 2)[0]
+		}
+		if s := strings.Join(strings.Fields(tdata), " "); s != "" {
+			lines = append(lines, OutlineLine{X: t.Xp, Y: t.Yp, Size: t.Sp, Text: s})
+		}
+	}
+	for _, l := range slide.List {
+		for i, li := range l.Li {
+			if s := strings.Join(strings.Fields(li.ListText), " "); s != "" {
+				lines = append(lines, OutlineLine{X: l.Xp, Y: l.Yp - float64(i)*0.001, Size: l.Sp, Level: 1, Text: s})
+			}
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i].Y != lines[j].Y {
+			return lines[i].Y > lines[j].Y
+		}
+		return lines[i].X < lines[j].X
+	})
+	return lines
+}
+
+// SlideTitle returns the title of slide n: its largest text, the highest if there
+// are several, or "slide n" if it has no text
+func SlideTitle(slide deck.Slide, n int) string {
+	title, size := "", 0.0
+	for _, l := range SlideOutline(slide) {
+		if l.Level == 0 && l.Size > size {
+			title, size = l.Text, l.Size
+		}
+	}
+	if title == "" {
+		return fmt.Sprintf("slide %d", n+1)
+	}
+	return title
+}
+
+// WriteOutline writes the outline of the deck: the title of each slide,
+// followed by its other text, indented
+func WriteOutline(w io.Writer, d *deck.Deck) error {
+	bw := bufio.NewWriter(w)
+	for n, slide := range d.Slide {
+		title := SlideTitle(slide, n)
+		fmt.Fprintf(bw, "%d. %s\n", n+1, title)
+		for _, l := range SlideOutline(slide) {
+			switch {
+			case l.Level > 0:
+				fmt.Fprintf(bw, "\t- %s\n", l.Text)
+			case l.Text != title:
+				fmt.Fprintf(bw, "\t%s\n", l.Text)
+			}
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,37 @@
+package giocanvas
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ajstarks/deck"
+)
+
+func TestWriteOutline(t *testing.T) {
+	markup := `<deck>
+<slide>
+	<text xp="10" yp="50" sp="2">  left   text </text>
+	<text xp="10" yp="80" sp="5">Title</text>
+	<list xp="60" yp="50" sp="2"><li>one</li><li>two</li></list>
+	<text xp="10" yp="20" sp="1" type="code">x := 1
+y := 2</text>
+</slide>
+<slide><rect xp="50" yp="50" wp="10" hp="10"/></slide>
+</deck>`
+	var d deck.Deck
+	if err := xml.Unmarshal([]byte(markup), &d); err != nil {
+		t.Fatal(err)
+	}
+	if title := SlideTitle(d.Slide[1], 1); title != "slide 2" {
+		t.Errorf("untitled slide: %q", title)
+	}
+	var b strings.Builder
+	if err := WriteOutline(&b, &d); err != nil {
+		t.Fatal(err)
+	}
+	want := "1. Title\n\tleft text\n\t- one\n\t- two\n\tx := 1\n2. slide 2\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
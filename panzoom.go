@@ -0,0 +1,75 @@
+package giocanvas
+
+import (
+	"image"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Pan and zoom: showing part of an image, and moving slowly across it
+// (the "Ken Burns" effect)
+
+// PanZoom moves the view of an image from one part of it to another over the tween.
+// From and To are parts of the image, as Boxes in percentages of its width and
+// height; give them the proportions of the area the image is shown in.
+type PanZoom struct {
+	From, To Box
+	Tween    Tween
+}
+
+// View returns the part of the image shown at now
+func (p PanZoom) View(now time.Time) Box {
+	t := float32(p.Tween.At(now))
+	return Box{
+		X: Lerp(p.From.X, p.To.X, t),
+		Y: Lerp(p.From.Y, p.To.Y, t),
+		W: Lerp(p.From.W, p.To.W, t),
+		H: Lerp(p.From.H, p.To.H, t),
+	}
+}
+
+// AbsImageView shows the part of the image in the named file within view (percentages of
+// the image), stretched across the area with its upper left corner at (x, y), sized (w, h)
+func (c *Canvas) AbsImageView(name string, view Box, x, y, w, h float32) {
+	if view.W <= 0 || view.H <= 0 {
+		return
+	}
+	ci, err := loadimage(name)
+	if err != nil {
+		return
+	}
+	// decode at the resolution the view needs
+	if sc := w / (view.W / 100 * float32(ci.natural.X)); sc > 0 {
+		if ci, err = loadimagefor(name, sc); err != nil {
+			return
+		}
+	}
+	is := ci.imop.Size()
+	if is.X == 0 || is.Y == 0 {
+		return
+	}
+	iw, ih := float32(is.X), float32(is.Y)
+	vx, vy := view.X/100*iw, (100-view.Y-view.H)/100*ih
+	sx, sy := w/(view.W/100*iw), h/(view.H/100*ih)
+	ops := c.Context.Ops
+	stack := clip.Rect{Min: image.Pt(int(x), int(y)), Max: image.Pt(int(x+w+0.5), int(y+h+0.5))}.Push(ops)
+	t := op.Affine(f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(sx, sy)).Offset(f32.Pt(x-vx*sx, y-vy*sy))).Push(ops)
+	ci.imop.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	t.Pop()
+	stack.Pop()
+	c.semantic("image", "", x, y, w, h)
+	c.record(DrawCall{Op: "image", W: w, H: h}, x, y)
+}
+
+// ImageView shows the part of the image in the named file within view, as AbsImageView,
+// across the area centered at (x, y), sized (w, h), using percentage-based measures
+func (c *Canvas) ImageView(name string, view Box, x, y, w, h float32) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	w, h = pct(w, c.Width), pct(h, c.Height)
+	c.AbsImageView(name, view, x-w/2, y-h/2, w, h)
+}
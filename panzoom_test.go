@@ -0,0 +1,27 @@
+package giocanvas
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPanZoom(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	pz := PanZoom{
+		From:  Box{0, 0, 100, 100},
+		To:    Box{40, 20, 50, 50},
+		Tween: Tween{Start: start, Duration: 10 * time.Second},
+	}
+	for _, tc := range []struct {
+		at   time.Duration
+		want Box
+	}{
+		{-time.Second, pz.From},
+		{5 * time.Second, Box{20, 10, 75, 75}},
+		{time.Minute, pz.To},
+	} {
+		if got := pz.View(start.Add(tc.at)); got != tc.want {
+			t.Errorf("view at %v: got %v, want %v", tc.at, got, tc.want)
+		}
+	}
+}
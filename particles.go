@@ -0,0 +1,154 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Particles: many small pieces moving under gravity, for effects like confetti
+
+// Particle is a piece moving at (VX, VY) percent per second, spinning at Spin
+// radians per second, living for Life. Round particles are circles of radius Size,
+// others are rectangles Size wide and half as high.
+type Particle struct {
+	X, Y, VX, VY   float32
+	Rotation, Spin float32
+	Size           float32
+	Round          bool
+	Color          color.NRGBA
+	Age, Life      time.Duration
+}
+
+// Particles is a system of particles. Gravity (percent per second²) pulls them down,
+// and Drag (0-1 per second) slows them.
+type Particles struct {
+	List    []Particle
+	Gravity float32
+	Drag    float32
+	last    time.Time
+}
+
+// Emit adds a particle
+func (ps *Particles) Emit(p Particle) {
+	ps.List = append(ps.List, p)
+}
+
+// Active reports whether any particles remain; while they do, keep drawing frames
+func (ps *Particles) Active() bool {
+	return len(ps.List) > 0
+}
+
+// Step moves the particles to the time now, dropping those that have expired or fallen
+// below the canvas. The first step only records the time.
+func (ps *Particles) Step(now time.Time) {
+	if ps.last.IsZero() {
+		ps.last = now
+		return
+	}
+	dt := now.Sub(ps.last)
+	ps.last = now
+	t := float32(dt.Seconds())
+	drag := float32(math.Pow(float64(1-ps.Drag), float64(t)))
+	live := ps.List[:0]
+	for _, p := range ps.List {
+		p.Age += dt
+		if (p.Life > 0 && p.Age > p.Life) || p.Y < -10 {
+			continue
+		}
+		p.VY -= ps.Gravity * t
+		p.VX *= drag
+		p.VY *= drag
+		p.X += p.VX * t
+		p.Y += p.VY * t
+		p.Rotation += p.Spin * t
+		live = append(live, p)
+	}
+	ps.List = live
+}
+
+// Draw draws the particles, fading them out over the last quarter of their lives
+func (ps *Particles) Draw(c *Canvas) {
+	for _, p := range ps.List {
+		col := p.Color
+		if p.Life > 0 && p.Age > p.Life*3/4 {
+			fade := float32(p.Life-p.Age) / float32(p.Life/4)
+			col.A = uint8(float32(col.A) * fade)
+		}
+		if p.Round {
+			c.Circle(p.X, p.Y, p.Size, col)
+			continue
+		}
+		x, y := dimen(p.X, p.Y, c.Width, c.Height)
+		w, h := pct(p.Size, c.Width)/2, pct(p.Size, c.Width)/4
+		sin, cos := math.Sincos(float64(p.Rotation))
+		s, co := float32(sin), float32(cos)
+		px := []float32{-w, w, w, -w}
+		py := []float32{-h, -h, h, h}
+		for i := range px {
+			px[i], py[i] = x+px[i]*co-py[i]*s, y+px[i]*s+py[i]*co
+		}
+		c.AbsPolygon(px, py, col)
+	}
+}
+
+// Overlay steps and draws the particles in one call, reporting whether any remain
+func (ps *Particles) Overlay(c *Canvas, now time.Time) bool {
+	ps.Step(now)
+	ps.Draw(c)
+	return ps.Active()
+}
+
+// ConfettiColors are the default colors of confetti
+var ConfettiColors = []color.NRGBA{
+	{230, 57, 70, 255}, {255, 183, 3, 255}, {42, 157, 143, 255},
+	{69, 123, 157, 255}, {244, 162, 97, 255}, {155, 93, 229, 255},
+}
+
+// randomcolor picks one of the colors, or a confetti color if there are none
+func randomcolor(colors []color.NRGBA) color.NRGBA {
+	if len(colors) == 0 {
+		colors = ConfettiColors
+	}
+	return colors[rand.Intn(len(colors))]
+}
+
+// Confetti makes n pieces of confetti falling from above the top of the canvas,
+// across its width, in the colors (ConfettiColors if none). Draw it over a
+// slide or dashboard with Overlay each frame, until it returns false.
+func Confetti(n int, colors ...color.NRGBA) *Particles {
+	ps := &Particles{Gravity: 40, Drag: 0.6}
+	for i := 0; i < n; i++ {
+		ps.Emit(Particle{
+			X: rand.Float32() * 100, Y: 100 + rand.Float32()*30,
+			VX: (rand.Float32() - 0.5) * 20, VY: -rand.Float32() * 10,
+			Spin: (rand.Float32() - 0.5) * 12, Rotation: rand.Float32() * math.Pi,
+			Size:  0.8 + rand.Float32()*0.8,
+			Color: randomcolor(colors),
+			Life:  time.Duration(4+rand.Intn(3)) * time.Second,
+		})
+	}
+	return ps
+}
+
+// Fireworks makes bursts of n sparks from each of the points (x, y pairs,
+// percentage-based), in the colors (ConfettiColors if none)
+func Fireworks(n int, points []float32, colors ...color.NRGBA) *Particles {
+	ps := &Particles{Gravity: 15, Drag: 0.8}
+	for i := 0; i+1 < len(points); i += 2 {
+		col := randomcolor(colors)
+		for j := 0; j < n; j++ {
+			a := rand.Float64() * 2 * math.Pi
+			speed := 10 + rand.Float32()*25
+			sin, cos := math.Sincos(a)
+			ps.Emit(Particle{
+				X: points[i], Y: points[i+1],
+				VX: speed * float32(cos), VY: speed * float32(sin),
+				Size: 0.3 + rand.Float32()*0.3, Round: true, Color: col,
+				Life: time.Duration(1500+rand.Intn(1000)) * time.Millisecond,
+			})
+		}
+	}
+	return ps
+}
@@ -0,0 +1,270 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Paths: outlines of lines, curves and arcs, built up in percentage-based measures,
+// then filled or stroked
+
+// Path is a sequence of subpaths, each begun by MoveTo, in percentage-based measures
+type Path struct {
+	segs []pathseg
+}
+
+// kinds of path segment
+const (
+	segMove = iota
+	segLine
+	segQuad
+	segCubic
+	segArc
+	segEllipse
+	segClose
+)
+
+// pathseg is a segment of a path: its kind and points (for arcs, the center,
+// radius and angles; for elliptical arcs, the radii, end, rotation and flags)
+type pathseg struct {
+	kind         int
+	p            [6]float32
+	a            [2]float64
+	large, sweep bool
+}
+
+// MoveTo begins a new subpath at (x, y)
+func (p *Path) MoveTo(x, y float32) {
+	p.segs = append(p.segs, pathseg{kind: segMove, p: [6]float32{x, y}})
+}
+
+// LineTo adds a line to (x, y)
+func (p *Path) LineTo(x, y float32) {
+	p.segs = append(p.segs, pathseg{kind: segLine, p: [6]float32{x, y}})
+}
+
+// QuadTo adds a quadratic bezier curve, control point at (cx, cy), to (x, y)
+func (p *Path) QuadTo(cx, cy, x, y float32) {
+	p.segs = append(p.segs, pathseg{kind: segQuad, p: [6]float32{cx, cy, x, y}})
+}
+
+// CubicTo adds a cubic bezier curve, control points at (cx1, cy1) and (cx2, cy2), to (x, y)
+func (p *Path) CubicTo(cx1, cy1, cx2, cy2, x, y float32) {
+	p.segs = append(p.segs, pathseg{kind: segCubic, p: [6]float32{cx1, cy1, cx2, cy2, x, y}})
+}
+
+// ArcTo adds a line to the start of a circular arc, centered at (x, y), radius r (a percentage
+// of the width), and the arc, from angle a1 to a2 (radians, clockwise, as Canvas.Arc; counter-clockwise if a2 is less)
+func (p *Path) ArcTo(x, y, r float32, a1, a2 float64) {
+	p.segs = append(p.segs, pathseg{kind: segArc, p: [6]float32{x, y, r}, a: [2]float64{a1, a2}})
+}
+
+// EllipticalArcTo adds an elliptical arc, as in SVG paths, to (x, y): the ellipse has radii
+// (rx, ry) (percentages of the width and height) and is turned by rotation (radians,
+// clockwise); large chooses the arc of more than 180 degrees, and sweep the arc drawn clockwise
+func (p *Path) EllipticalArcTo(rx, ry float32, rotation float64, large, sweep bool, x, y float32) {
+	p.segs = append(p.segs, pathseg{kind: segEllipse, p: [6]float32{rx, ry, x, y}, a: [2]float64{rotation}, large: large, sweep: sweep})
+}
+
+// ellipsecurves returns the curves of an elliptical arc segment from the absolute point from
+func (s pathseg) ellipsecurves(c *Canvas, from f32.Point) [][6]float32 {
+	to := c.abspoint(s.p[2], s.p[3])
+	return ellipticalarc(from.X, from.Y, pct(s.p[0], c.Width), pct(s.p[1], c.Height), s.a[0], s.large, s.sweep, to.X, to.Y)
+}
+
+// Close ends the subpath with a line back to its start
+func (p *Path) Close() {
+	p.segs = append(p.segs, pathseg{kind: segClose})
+}
+
+// Fill fills the inside of the path (every subpath closed) on the canvas
+func (p *Path) Fill(c *Canvas, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "fillpath", Color: fillcolor}, p.endpoints(c)...)
+	ops := c.Context.Ops
+	stack := clip.Outline{Path: p.build(c, true)}.Op().Push(ops)
+	paint.ColorOp{Color: fillcolor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
+
+// FillGradient fills the inside of the path (every subpath closed) with a gradient across its bounds
+func (p *Path) FillGradient(c *Canvas, g Gradient) {
+	c.record(DrawCall{Op: "fillpath"}, p.endpoints(c)...)
+	var x, y []float32
+	for _, sub := range p.flatten(c) {
+		x, y = append(x, sub[0]...), append(y, sub[1]...)
+	}
+	if len(x) == 0 {
+		return
+	}
+	minx, miny, maxx, maxy := bounds(x, y)
+	stack := clip.Outline{Path: p.build(c, true)}.Op().Push(c.Context.Ops)
+	g.paint(c, minx, miny, maxx-minx, maxy-miny)
+	stack.Pop()
+}
+
+// Stroke strokes the path on the canvas, size wide (a percentage of the width)
+func (p *Path) Stroke(c *Canvas, size float32, strokecolor color.NRGBA) {
+	p.StyledStroke(c, size, StrokeStyle{}, strokecolor)
+}
+
+// StyledStroke strokes the path on the canvas, as Stroke, in the stroke style
+func (p *Path) StyledStroke(c *Canvas, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	c.record(DrawCall{Op: "strokepath", Size: pct(size, c.Width), Color: strokecolor}, p.endpoints(c)...)
+	size, style = pct(size, c.Width), pctstyle(style, c.Width)
+	if style.dashed() || style.styled() {
+		rec := c.Recorder
+		c.Recorder = nil
+		for _, sub := range p.flatten(c) {
+			c.strokepoints(sub[0], sub[1], size, style, strokecolor)
+		}
+		c.Recorder = rec
+		return
+	}
+	ops := c.Context.Ops
+	stack := clip.Stroke{Path: p.build(c, false), Width: size}.Op().Push(ops)
+	paint.Fill(ops, strokecolor)
+	stack.Pop()
+}
+
+// abspoint returns the absolute location of a point
+func (c *Canvas) abspoint(x, y float32) f32.Point {
+	x, y = dimen(x, y, c.Width, c.Height)
+	return f32.Pt(x, y)
+}
+
+// arcends returns the absolute center, radius and start of an arc segment
+func (s pathseg) arcends(c *Canvas) (f32.Point, float32, f32.Point) {
+	center := c.abspoint(s.p[0], s.p[1])
+	r := pct(s.p[2], c.Width)
+	return center, r, arcpoint(center.X, center.Y, r, s.a[0])
+}
+
+// build makes the Gio path, closing every subpath if closeall is set
+func (p *Path) build(c *Canvas, closeall bool) clip.PathSpec {
+	path := new(clip.Path)
+	path.Begin(c.Context.Ops)
+	open := false
+	for _, s := range p.segs {
+		switch s.kind {
+		case segMove:
+			if open && closeall {
+				path.Close()
+			}
+			path.MoveTo(c.abspoint(s.p[0], s.p[1]))
+			open = true
+		case segLine:
+			path.LineTo(c.abspoint(s.p[0], s.p[1]))
+		case segQuad:
+			path.QuadTo(c.abspoint(s.p[0], s.p[1]), c.abspoint(s.p[2], s.p[3]))
+		case segCubic:
+			path.CubeTo(c.abspoint(s.p[0], s.p[1]), c.abspoint(s.p[2], s.p[3]), c.abspoint(s.p[4], s.p[5]))
+		case segArc:
+			center, r, start := s.arcends(c)
+			path.LineTo(start)
+			arcto(path, center.X, center.Y, r, -s.a[0], -s.a[1])
+		case segEllipse:
+			for _, b := range s.ellipsecurves(c, path.Pos()) {
+				path.CubeTo(f32.Pt(b[0], b[1]), f32.Pt(b[2], b[3]), f32.Pt(b[4], b[5]))
+			}
+		case segClose:
+			path.Close()
+			open = false
+		}
+	}
+	if open && closeall {
+		path.Close()
+	}
+	return path.End()
+}
+
+// flatten returns the subpaths as lists of absolute points (x, then y)
+func (p *Path) flatten(c *Canvas) [][2][]float32 {
+	var subs [][2][]float32
+	var x, y []float32
+	var last f32.Point
+	add := func(px, py []float32) {
+		x, y = append(x, px...), append(y, py...)
+		last = f32.Pt(x[len(x)-1], y[len(y)-1])
+	}
+	end := func() {
+		if len(x) > 1 {
+			subs = append(subs, [2][]float32{x, y})
+		}
+		x, y = nil, nil
+	}
+	for _, s := range p.segs {
+		switch s.kind {
+		case segMove:
+			end()
+			pt := c.abspoint(s.p[0], s.p[1])
+			add([]float32{pt.X}, []float32{pt.Y})
+		case segLine:
+			pt := c.abspoint(s.p[0], s.p[1])
+			add([]float32{pt.X}, []float32{pt.Y})
+		case segQuad:
+			cp, pt := c.abspoint(s.p[0], s.p[1]), c.abspoint(s.p[2], s.p[3])
+			px, py := flattenquad(last.X, last.Y, cp.X, cp.Y, pt.X, pt.Y)
+			add(px[1:], py[1:])
+		case segCubic:
+			c1, c2, pt := c.abspoint(s.p[0], s.p[1]), c.abspoint(s.p[2], s.p[3]), c.abspoint(s.p[4], s.p[5])
+			px, py := flattencubic(last.X, last.Y, c1.X, c1.Y, c2.X, c2.Y, pt.X, pt.Y)
+			add(px[1:], py[1:])
+		case segArc:
+			center, r, _ := s.arcends(c)
+			n := int(math.Ceil(math.Abs(s.a[1]-s.a[0]) * float64(r) / 4))
+			if n < 8 {
+				n = 8
+			}
+			px, py := make([]float32, n+1), make([]float32, n+1)
+			for i := 0; i <= n; i++ {
+				pt := arcpoint(center.X, center.Y, r, s.a[0]+(s.a[1]-s.a[0])*float64(i)/float64(n))
+				px[i], py[i] = pt.X, pt.Y
+			}
+			add(px, py)
+		case segEllipse:
+			sx, sy := last.X, last.Y
+			for _, b := range s.ellipsecurves(c, last) {
+				px, py := flattencubic(sx, sy, b[0], b[1], b[2], b[3], b[4], b[5])
+				add(px[1:], py[1:])
+				sx, sy = b[4], b[5]
+			}
+		case segClose:
+			if len(x) > 0 {
+				add([]float32{x[0]}, []float32{y[0]})
+			}
+			end()
+		}
+	}
+	end()
+	return subs
+}
+
+// endpoints returns the absolute ends of the path's segments, for recording
+func (p *Path) endpoints(c *Canvas) []float32 {
+	if c.Recorder == nil {
+		return nil
+	}
+	var points []float32
+	for _, s := range p.segs {
+		var pt f32.Point
+		switch s.kind {
+		case segMove, segLine:
+			pt = c.abspoint(s.p[0], s.p[1])
+		case segQuad:
+			pt = c.abspoint(s.p[2], s.p[3])
+		case segCubic:
+			pt = c.abspoint(s.p[4], s.p[5])
+		case segEllipse:
+			pt = c.abspoint(s.p[2], s.p[3])
+		default:
+			continue
+		}
+		points = append(points, pt.X, pt.Y)
+	}
+	return points
+}
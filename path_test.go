@@ -0,0 +1,55 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathFlatten(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	var p Path
+	p.MoveTo(10, 10)
+	p.LineTo(20, 10)
+	p.ArcTo(20, 20, 1, math.Pi/2, 0)
+	p.Close()
+	subs := p.flatten(c)
+	if len(subs) != 1 {
+		t.Fatalf("got %d subpaths, want 1", len(subs))
+	}
+	x, y := subs[0][0], subs[0][1]
+	n := len(x) - 1
+	// the arc begins below its center, and ends to its right, and the path closes
+	want := [][2]float32{{100, 450}, {200, 450}, {200, 410}, {210, 400}, {100, 450}}
+	got := [][2]float32{{x[0], y[0]}, {x[1], y[1]}, {x[2], y[2]}, {x[n-1], y[n-1]}, {x[n], y[n]}}
+	for i := range want {
+		if math.Abs(float64(got[i][0]-want[i][0])) > 1e-3 || math.Abs(float64(got[i][1]-want[i][1])) > 1e-3 {
+			t.Errorf("point %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+	p.Fill(c, c.TextColor)
+	if f := log.Find("fillpath"); len(f) != 1 || f[0].Points[0] != 10 || f[0].Points[1] != 10 {
+		t.Errorf("fillpath: %+v", f)
+	}
+}
+
+func TestArcDirection(t *testing.T) {
+	// the angles of arcs, paths and conic gradients all increase clockwise on the screen
+	want := [][2]float32{{110, 100}, {100, 110}, {90, 100}, {100, 90}}
+	for i, w := range want {
+		pt := arcpoint(100, 100, 10, float64(i)*math.Pi/2)
+		if math.Abs(float64(pt.X-w[0])) > 1e-3 || math.Abs(float64(pt.Y-w[1])) > 1e-3 {
+			t.Errorf("arcpoint at %d quarter turns: got %v, want %v", i, pt, w)
+		}
+	}
+	c, _ := NewRecordingCanvas(1000, 500)
+	var p Path
+	p.ArcTo(50, 50, 10, 0, math.Pi/2)
+	sub := p.flatten(c)[0]
+	x, y := sub[0], sub[1]
+	n := len(x) - 1
+	// from the right of the center, (600, 250), to below it, as Canvas.Arc sweeps
+	if math.Abs(float64(x[0]-600)) > 1e-3 || math.Abs(float64(y[0]-250)) > 1e-3 ||
+		math.Abs(float64(x[n]-500)) > 1e-3 || math.Abs(float64(y[n]-350)) > 1e-3 {
+		t.Errorf("arc from (%v, %v) to (%v, %v), want (600, 250) to (500, 350)", x[0], y[0], x[n], y[n])
+	}
+}
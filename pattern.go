@@ -0,0 +1,65 @@
+package giocanvas
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/paint"
+)
+
+// Pattern fills a shape with an image, stretched across the shape's box, or tiled.
+// Tiles are laid from the canvas' upper left, so those of neighboring shapes line up;
+// Scale sizes them (a percentage of the image's size, 0 taken as 100).
+// A Pattern may be used wherever a Gradient is.
+type Pattern struct {
+	Image   image.Image
+	Stretch bool
+	Scale   float32
+}
+
+// tilerange returns the first and last tiles, size apart from 0, that cover from x to x+w
+func tilerange(x, w, size float32) (int, int) {
+	return int(math.Floor(float64(x / size))), int(math.Ceil(float64((x+w)/size))) - 1
+}
+
+func (p Pattern) paint(c *Canvas, x, y, w, h float32) {
+	if p.Image == nil || w <= 0 || h <= 0 {
+		return
+	}
+	b := p.Image.Bounds()
+	iw, ih := float32(b.Dx()), float32(b.Dy())
+	if iw == 0 || ih == 0 {
+		return
+	}
+	ops := c.Context.Ops
+	imop := paint.NewImageOp(p.Image)
+	if p.Stretch {
+		m := f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(w/iw, h/ih)).Offset(f32.Pt(x, y))
+		stack := op.Affine(m).Push(ops)
+		imop.Add(ops)
+		paint.PaintOp{}.Add(ops)
+		stack.Pop()
+		return
+	}
+	scale := p.Scale / 100
+	if scale <= 0 {
+		scale = 1
+	}
+	tw, th := iw*scale, ih*scale
+	if tw < 1 || th < 1 {
+		return
+	}
+	i0, i1 := tilerange(x, w, tw)
+	j0, j1 := tilerange(y, h, th)
+	for j := j0; j <= j1; j++ {
+		for i := i0; i <= i1; i++ {
+			m := f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(scale, scale)).Offset(f32.Pt(float32(i)*tw, float32(j)*th))
+			stack := op.Affine(m).Push(ops)
+			imop.Add(ops)
+			paint.PaintOp{}.Add(ops)
+			stack.Pop()
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package giocanvas
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPattern(t *testing.T) {
+	for _, tc := range []struct {
+		x, w, size  float32
+		first, last int
+	}{
+		{0, 100, 25, 0, 3},
+		{10, 40, 25, 0, 1},
+		{-10, 20, 25, -1, 0},
+		{30, 10, 25, 1, 1},
+	} {
+		if first, last := tilerange(tc.x, tc.w, tc.size); first != tc.first || last != tc.last {
+			t.Errorf("tilerange(%v, %v, %v) = %d, %d, want %d, %d", tc.x, tc.w, tc.size, first, last, tc.first, tc.last)
+		}
+	}
+
+	// patterns fill wherever gradients do
+	c, log := NewRecordingCanvas(200, 100)
+	p := Pattern{Image: image.NewNRGBA(image.Rect(0, 0, 8, 8)), Scale: 50}
+	c.CenterRectGradient(50, 50, 20, 20, p)
+	c.CircleGradient(50, 50, 10, Pattern{Image: p.Image, Stretch: true})
+	if len(log.Calls) != 2 {
+		t.Errorf("recorded %v, want a rect and an ellipse", log.Calls)
+	}
+}
@@ -21,6 +21,13 @@ func (c *Canvas) Line(x0, y0, x1, y1, size float32, strokecolor color.NRGBA) {
 	c.AbsLine(x0, y0, x1, y1, size, strokecolor)
 }
 
+// StyledLine makes a stroked line, as Line, in the stroke style
+func (c *Canvas) StyledLine(x0, y0, x1, y1, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	x0, y0 = dimen(x0, y0, c.Width, c.Height)
+	x1, y1 = dimen(x1, y1, c.Width, c.Height)
+	c.AbsStyledLine(x0, y0, x1, y1, pct(size, c.Width), pctstyle(style, c.Width), strokecolor)
+}
+
 // VLine makes a vertical line beginning at (x,y) with dimension (w, h)
 // the line begins at (x,y) and moves upward by linewidth
 func (c *Canvas) VLine(x, y, lineheight, size float32, linecolor color.NRGBA) {
@@ -33,6 +40,16 @@ func (c *Canvas) HLine(x, y, linewidth, size float32, linecolor color.NRGBA) {
 	c.Line(x, y, x+linewidth, y, size, linecolor)
 }
 
+// StyledVLine makes a vertical line, as VLine, in the stroke style
+func (c *Canvas) StyledVLine(x, y, lineheight, size float32, style StrokeStyle, linecolor color.NRGBA) {
+	c.StyledLine(x, y, x, y+lineheight, size, style, linecolor)
+}
+
+// StyledHLine makes a horizontal line, as HLine, in the stroke style
+func (c *Canvas) StyledHLine(x, y, linewidth, size float32, style StrokeStyle, linecolor color.NRGBA) {
+	c.StyledLine(x, y, x+linewidth, y, size, style, linecolor)
+}
+
 // Polygon makes a filled polygon using percentage-based measures
 // vertices in x and y,
 func (c *Canvas) Polygon(x, y []float32, fillcolor color.NRGBA) {
@@ -77,6 +94,14 @@ func (c *Canvas) StrokedCurve(x, y, cx, cy, ex, ey, size float32, fillcolor colo
 	c.QuadStrokedCurve(x, y, cx, cy, ex, ey, size, fillcolor)
 }
 
+// StyledCurve makes a stroked quadradic bezier curve, as StrokedCurve, in the stroke style
+func (c *Canvas) StyledCurve(x, y, cx, cy, ex, ey, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	cx, cy = dimen(cx, cy, c.Width, c.Height)
+	ex, ey = dimen(ex, ey, c.Width, c.Height)
+	c.AbsStyledQuadBezier(x, y, cx, cy, ex, ey, pct(size, c.Width), pctstyle(style, c.Width), strokecolor)
+}
+
 // CubeCurve makes a cubic Bezier curve, using percentage-based measures
 // starting at (x, y), control points at (cx1, cy1), (cx2, cy2), end point (ex, ey)
 func (c *Canvas) CubeCurve(x, y, cx1, cy1, cx2, cy2, ex, ey float32, fillcolor color.NRGBA) {
@@ -101,6 +126,15 @@ func (c *Canvas) StrokedCubeCurve(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32
 	c.CubeStrokedCurve(x, y, cx1, cy1, cx2, cy2, ex, ey, size, strokecolor)
 }
 
+// StyledCubeCurve makes a stroked cubic bezier curve, as StrokedCubeCurve, in the stroke style
+func (c *Canvas) StyledCubeCurve(x, y, cx1, cy1, cx2, cy2, ex, ey, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	cx1, cy1 = dimen(cx1, cy1, c.Width, c.Height)
+	cx2, cy2 = dimen(cx2, cy2, c.Width, c.Height)
+	ex, ey = dimen(ex, ey, c.Width, c.Height)
+	c.AbsStyledCubicBezier(x, y, cx1, cy1, cx2, cy2, ex, ey, pct(size, c.Width), pctstyle(style, c.Width), strokecolor)
+}
+
 // Circle makes a filled circle, using percentage-based measures
 // center is (x,y), radius r
 func (c *Canvas) Circle(x, y, r float32, fillcolor color.NRGBA) {
@@ -118,8 +152,22 @@ func (c *Canvas) Ellipse(x, y, w, h float32, fillcolor color.NRGBA) {
 	c.AbsEllipse(x, y, w, h, fillcolor)
 }
 
+// StrokedCircle outlines a circle, using percentage-based measures
+// center is (x,y), radius r, stroked size wide
+func (c *Canvas) StrokedCircle(x, y, r, size float32, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsStrokedCircle(x, y, pct(r, c.Width), pct(size, c.Width), strokecolor)
+}
+
+// StrokedEllipse outlines an ellipse, using percentage-based measures
+// center is (x,y), radii (w, h), stroked size wide
+func (c *Canvas) StrokedEllipse(x, y, w, h, size float32, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsStrokedEllipse(x, y, pct(w, c.Width), pct(h, c.Height), pct(size, c.Width), strokecolor)
+}
+
 // Arc makes a filled arc, using percentage-based measures
-// center is (x, y) the arc begins at angle a1, and ends at a2, with radius r.
+// center is (x, y) the arc begins at angle a1, and ends at a2 (radians, as AbsArc), with radius r.
 // The arc is filled with the specified color.
 func (c *Canvas) Arc(x, y, r float32, a1, a2 float64, fillcolor color.NRGBA) {
 	x, y = dimen(x, y, c.Width, c.Height)
@@ -127,20 +175,45 @@ func (c *Canvas) Arc(x, y, r float32, a1, a2 float64, fillcolor color.NRGBA) {
 	c.AbsArc(x, y, pr, a1, a2, fillcolor)
 }
 
+// AnnularArc makes a band between radii r1 and r2, centered at (x, y), from angle a1 to a2,
+// as AbsAnnularArc, using percentage-based measures
+func (c *Canvas) AnnularArc(x, y, r1, r2 float32, a1, a2 float64, fillcolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsAnnularArc(x, y, pct(r1, c.Width), pct(r2, c.Width), a1, a2, fillcolor)
+}
+
+// EllipticalArc strokes an arc of the ellipse centered at (x, y), radii (w, h), turned by rotation,
+// from angle a1 to a2, as AbsEllipticalArc, using percentage-based measures
+func (c *Canvas) EllipticalArc(x, y, w, h float32, rotation, a1, a2 float64, size float32, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	c.AbsEllipticalArc(x, y, pct(w, c.Width), pct(h, c.Height), rotation, a1, a2, pct(size, c.Width), strokecolor)
+}
+
 // ArcLine makes a stroked arc, using percentage-based measures
 // center is (x, y), the arc begins at angle a1, and ends at a2, with radius r.
 // The arc is stroked with the specified stroke size and color
 func (c *Canvas) ArcLine(x, y, r float32, a1, a2 float64, size float32, fillcolor color.NRGBA) {
 	step := (a2 - a1) / 100
 	x1, y1 := c.Polar(x, y, r, float32(a1))
-	for t := a1 + step; t <= a2; t += step {
-		x2, y2 := c.Polar(x, y, r, float32(t))
+	for i := 1; i <= 100; i++ {
+		x2, y2 := c.Polar(x, y, r, float32(a1+step*float64(i)))
 		c.Line(x1, y1, x2, y2, size, fillcolor)
 		x1 = x2
 		y1 = y2
 	}
 }
 
+// StyledArcLine makes a stroked arc, as ArcLine, in the stroke style: one polyline,
+// so that its segments are joined, not capped, and dashes continue along it
+func (c *Canvas) StyledArcLine(x, y, r float32, a1, a2 float64, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	step := (a2 - a1) / 100
+	px, py := make([]float32, 101), make([]float32, 101)
+	for i := range px {
+		px[i], py[i] = c.Polar(x, y, r, float32(a1+step*float64(i)))
+	}
+	c.StyledPolyline(px, py, size, style, strokecolor)
+}
+
 // Text methods
 
 // Text places text using percentage-based measures
@@ -195,6 +268,15 @@ func (c *Canvas) Rect(x, y, w, h float32, fillcolor color.NRGBA) {
 	c.AbsCenterRect(x, y, w, h, fillcolor)
 }
 
+// StrokedRect outlines a rectangle using percentage-based measures,
+// centered at (x,y), sized at (w,h), stroked size wide
+func (c *Canvas) StrokedRect(x, y, w, h, size float32, strokecolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	w = pct(w, c.Width)
+	h = pct(h, c.Height)
+	c.AbsStrokedRect(x-w/2, y-h/2, w, h, pct(size, c.Width), strokecolor)
+}
+
 // CornerRect makes a rectangle using percentage-based measures
 // upper left corner at (x,y), with sized at (w,h)
 func (c *Canvas) CornerRect(x, y, w, h float32, fillcolor color.NRGBA) {
@@ -204,6 +286,18 @@ func (c *Canvas) CornerRect(x, y, w, h float32, fillcolor color.NRGBA) {
 	c.AbsRect(x, y, w, h, fillcolor)
 }
 
+// AnchorRect makes a rectangle using percentage-based measures,
+// sized at (w,h), with the anchor point at (x,y)
+func (c *Canvas) AnchorRect(x, y, w, h float32, anchor Anchor, fillcolor color.NRGBA) {
+	x, y = anchor.Origin(x, y, w, h)
+	c.CornerRect(x, y+h, w, h, fillcolor)
+}
+
+// BoxRect fills a box, using percentage-based measures
+func (c *Canvas) BoxRect(b Box, fillcolor color.NRGBA) {
+	c.CornerRect(b.X, b.Y+b.H, b.W, b.H, fillcolor)
+}
+
 // Square makes a square shape, using percentage based measures
 // centered at (x, y), sides are w. Accounts for screen aspect
 func (c *Canvas) Square(x, y, w float32, fillcolor color.NRGBA) {
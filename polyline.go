@@ -0,0 +1,257 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Polylines and stroke styles: connected series of lines, and the joins and caps of
+// strokes. The outline of a styled stroke is built up from pieces -- segments, joins
+// and caps -- all wound the same way, so that they fill once where they overlap.
+
+// Cap is the shape of the ends of a stroke
+type Cap int
+
+// The caps: butt ends stop at the end points; round and square ends extend past them
+// by half the stroke width
+const (
+	RoundCap Cap = iota
+	ButtCap
+	SquareCap
+)
+
+// Join is the shape of the corners of a stroke
+type Join int
+
+// The joins. Miters longer than the miter limit (times the stroke width) are beveled.
+const (
+	RoundJoin Join = iota
+	MiterJoin
+	BevelJoin
+)
+
+// StrokeStyle is how strokes end, turn corners and break into dashes, for the Styled
+// variants of the stroking functions. The zero value, solid with round caps and joins,
+// is the style of Gio's own strokes, which the other stroking functions draw. Dash
+// lengths are measured as the stroke width is: absolute for the Abs functions, and
+// percentages of the width for the others.
+type StrokeStyle struct {
+	Cap        Cap
+	Join       Join
+	MiterLimit float32   // longest miter, in stroke widths, before it is beveled (0: 4)
+	Dashes     []float32 // on and off lengths of dashes; nil for solid
+	DashOffset float32   // distance into the dash pattern that strokes begin
+}
+
+// styled reports whether the caps or joins of the style are other than the default
+func (s StrokeStyle) styled() bool {
+	return s.Cap != RoundCap || s.Join != RoundJoin || s.MiterLimit != 0
+}
+
+// dashed reports whether the style has dashes
+func (s StrokeStyle) dashed() bool {
+	return len(s.Dashes) > 0
+}
+
+// pctstyle returns the style with its dash lengths, percentages of m, made absolute
+func pctstyle(s StrokeStyle, m float32) StrokeStyle {
+	if !s.dashed() {
+		return s
+	}
+	dashes := make([]float32, len(s.Dashes))
+	for i, d := range s.Dashes {
+		dashes[i] = pct(d, m)
+	}
+	s.Dashes, s.DashOffset = dashes, pct(s.DashOffset, m)
+	return s
+}
+
+// strokestyled strokes the lines through the points (x, y), size wide, in the style
+func (c *Canvas) strokestyled(x, y []float32, size float32, s StrokeStyle, strokecolor color.NRGBA) {
+	points := make([]f32.Point, len(x))
+	for i := range x {
+		points[i] = f32.Pt(x[i], y[i])
+	}
+	c.fillpieces(strokeoutline(points, size, s.Cap, s.Join, s.MiterLimit), size/2, strokecolor)
+}
+
+// defaultMiterLimit is the miter limit used when none is set, as in SVG
+const defaultMiterLimit = 4
+
+// strokepiece is a closed polygon of the stroke outline, or if round, a circle
+// centered at its only point
+type strokepiece struct {
+	points []f32.Point
+	round  bool
+}
+
+// unitnormal returns the unit vector across the direction from a to b (zero if they coincide)
+func unitnormal(a, b f32.Point) f32.Point {
+	d := b.Sub(a)
+	l := float32(math.Hypot(float64(d.X), float64(d.Y)))
+	if l == 0 {
+		return f32.Point{}
+	}
+	return f32.Pt(-d.Y/l, d.X/l)
+}
+
+// strokeoutline returns the pieces of the outline of the polyline through points,
+// stroked size wide
+func strokeoutline(points []f32.Point, size float32, linecap Cap, join Join, miterlimit float32) []strokepiece {
+	// drop repeated points, which have no direction
+	pts := make([]f32.Point, 0, len(points))
+	for _, p := range points {
+		if len(pts) == 0 || p != pts[len(pts)-1] {
+			pts = append(pts, p)
+		}
+	}
+	if len(pts) < 2 || size <= 0 {
+		return nil
+	}
+	if miterlimit <= 0 {
+		miterlimit = defaultMiterLimit
+	}
+	hw := size / 2
+	var pieces []strokepiece
+	for i := 1; i < len(pts); i++ {
+		n := unitnormal(pts[i-1], pts[i]).Mul(hw)
+		pieces = append(pieces, strokepiece{points: []f32.Point{pts[i-1].Add(n), pts[i].Add(n), pts[i].Sub(n), pts[i-1].Sub(n)}})
+	}
+	// joins
+	for i := 1; i < len(pts)-1; i++ {
+		p := pts[i]
+		da, db := pts[i].Sub(pts[i-1]), pts[i+1].Sub(pts[i])
+		cross := da.X*db.Y - da.Y*db.X
+		if cross == 0 {
+			continue
+		}
+		if join == RoundJoin {
+			pieces = append(pieces, strokepiece{points: []f32.Point{p}, round: true})
+			continue
+		}
+		// the outer side of the turn
+		na, nb := unitnormal(pts[i-1], p), unitnormal(p, pts[i+1])
+		if cross > 0 {
+			na, nb = na.Mul(-1), nb.Mul(-1)
+		}
+		oa, ob := p.Add(na.Mul(hw)), p.Add(nb.Mul(hw))
+		if join == MiterJoin {
+			// the miter extends 1/cos(θ/2) half widths along the bisector of the normals
+			bis := na.Add(nb)
+			cos := float32(math.Hypot(float64(bis.X), float64(bis.Y))) / 2
+			if cos > 0 && 1/cos <= miterlimit {
+				tip := p.Add(bis.Mul(hw / (2 * cos * cos)))
+				pieces = append(pieces, strokepiece{points: []f32.Point{p, oa, tip, ob}})
+				continue
+			}
+		}
+		pieces = append(pieces, strokepiece{points: []f32.Point{p, oa, ob}})
+	}
+	// caps
+	switch linecap {
+	case RoundCap:
+		pieces = append(pieces, strokepiece{points: []f32.Point{pts[0]}, round: true}, strokepiece{points: []f32.Point{pts[len(pts)-1]}, round: true})
+	case SquareCap:
+		for _, end := range [][2]f32.Point{{pts[0], pts[1]}, {pts[len(pts)-1], pts[len(pts)-2]}} {
+			p, n := end[0], unitnormal(end[1], end[0]).Mul(hw)
+			out := f32.Pt(n.Y, -n.X) // half a width beyond the end
+			pieces = append(pieces, strokepiece{points: []f32.Point{p.Add(n), p.Add(n).Add(out), p.Sub(n).Add(out), p.Sub(n)}})
+		}
+	}
+	return pieces
+}
+
+// area returns the signed area of a polygon
+func area(points []f32.Point) float32 {
+	var a float32
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		a += p.X*q.Y - q.X*p.Y
+	}
+	return a / 2
+}
+
+// fillpieces fills the pieces of a stroke outline, winding each the same way
+func (c *Canvas) fillpieces(pieces []strokepiece, hw float32, fillcolor color.NRGBA) {
+	if len(pieces) == 0 {
+		return
+	}
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	for _, piece := range pieces {
+		if piece.round {
+			p := piece.points[0]
+			path.MoveTo(f32.Pt(p.X+hw, p.Y))
+			arcto(path, p.X, p.Y, hw, 0, 2*math.Pi)
+			path.Close()
+			continue
+		}
+		pts := piece.points
+		if area(pts) < 0 {
+			rev := make([]f32.Point, len(pts))
+			for i, p := range pts {
+				rev[len(pts)-1-i] = p
+			}
+			pts = rev
+		}
+		path.MoveTo(pts[0])
+		for _, p := range pts[1:] {
+			path.LineTo(p)
+		}
+		path.Close()
+	}
+	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
+	paint.ColorOp{Color: fillcolor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
+
+// AbsPolyline strokes the connected lines through the points (x, y), size wide
+func (c *Canvas) AbsPolyline(x, y []float32, size float32, strokecolor color.NRGBA) {
+	c.AbsStyledPolyline(x, y, size, StrokeStyle{}, strokecolor)
+}
+
+// AbsStyledPolyline strokes the connected lines through the points (x, y), as AbsPolyline,
+// in the stroke style
+func (c *Canvas) AbsStyledPolyline(x, y []float32, size float32, style StrokeStyle, strokecolor color.NRGBA) {
+	if len(x) != len(y) {
+		return
+	}
+	if c.Recorder != nil {
+		points := make([]float32, 0, len(x)*2)
+		for i := range x {
+			points = append(points, x[i], y[i])
+		}
+		c.record(DrawCall{Op: "polyline", Size: size, Color: strokecolor}, points...)
+	}
+	if style.dashed() {
+		c.strokepoints(x, y, size, style, strokecolor)
+		return
+	}
+	c.strokestyled(x, y, size, style, strokecolor)
+}
+
+// Polyline strokes the connected lines through the points (x, y), as AbsPolyline,
+// using percentage-based measures; sw is a percentage of the width
+func (c *Canvas) Polyline(x, y []float32, sw float32, strokecolor color.NRGBA) {
+	c.StyledPolyline(x, y, sw, StrokeStyle{}, strokecolor)
+}
+
+// StyledPolyline strokes the connected lines through the points (x, y) in the stroke style,
+// as AbsStyledPolyline, using percentage-based measures
+func (c *Canvas) StyledPolyline(x, y []float32, sw float32, style StrokeStyle, strokecolor color.NRGBA) {
+	if len(x) != len(y) {
+		return
+	}
+	nx := make([]float32, len(x))
+	ny := make([]float32, len(y))
+	for i := range x {
+		nx[i], ny[i] = dimen(x[i], y[i], c.Width, c.Height)
+	}
+	c.AbsStyledPolyline(nx, ny, pct(sw, c.Width), pctstyle(style, c.Width), strokecolor)
+}
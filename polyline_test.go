@@ -0,0 +1,56 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+
+	"gioui.org/f32"
+)
+
+func TestStrokeOutline(t *testing.T) {
+	corner := []f32.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}
+
+	pieces := strokeoutline(corner, 2, ButtCap, MiterJoin, 0)
+	if len(pieces) != 3 {
+		t.Fatalf("miter: got %d pieces, want 3", len(pieces))
+	}
+	miter := pieces[2].points
+	if len(miter) != 4 || !nearpoint(miter[2], f32.Pt(11, -1)) {
+		t.Errorf("miter: %v", miter)
+	}
+
+	// a right angle's miter is √2 widths long, over a limit of 1
+	pieces = strokeoutline(corner, 2, ButtCap, MiterJoin, 1)
+	if bevel := pieces[2].points; len(bevel) != 3 {
+		t.Errorf("bevel: %v", bevel)
+	}
+
+	pieces = strokeoutline(corner, 2, SquareCap, RoundJoin, 0)
+	if len(pieces) != 5 || !pieces[2].round {
+		t.Fatalf("square caps: %v", pieces)
+	}
+	if start := pieces[3].points; !nearpoint(start[1], f32.Pt(-1, 1)) && !nearpoint(start[1], f32.Pt(-1, -1)) {
+		t.Errorf("start cap: %v", start)
+	}
+}
+
+func nearpoint(a, b f32.Point) bool {
+	d := a.Sub(b)
+	return d.X*d.X+d.Y*d.Y < 1e-6
+}
+
+func TestStrokeStyle(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 1000)
+	red := color.NRGBA{255, 0, 0, 255}
+	style := StrokeStyle{Cap: ButtCap, Join: MiterJoin}
+	c.Line(10, 10, 90, 90, 1, red)
+	c.StyledLine(10, 10, 90, 90, 1, style, red)
+	c.StyledArcLine(50, 50, 10, 0, 3, 1, style, red)
+	if n := len(log.Find("line")); n != 2 {
+		t.Errorf("got %d lines, want 2", n)
+	}
+	// a styled arc is a single polyline
+	if p := log.Find("polyline"); len(p) != 1 || len(p[0].Points) != 202 {
+		t.Errorf("arc: %v", p)
+	}
+}
@@ -0,0 +1,77 @@
+package giocanvas
+
+import (
+	"image/color"
+
+	"gioui.org/io/system"
+)
+
+// Recording drawing calls, for testing layouts without rendering
+
+// DrawCall is a drawing operation made on a canvas.
+// Points are (x, y) pairs using percentage-based coordinates; W and Size are
+// percentages of the canvas width, H is a percentage of the canvas height.
+// A1 and A2 are the angles (radians) of arcs. Radii are the corner radii of rounded
+// rectangles (top left, clockwise), percentages of the canvas width.
+type DrawCall struct {
+	Op         string
+	Points     []float32
+	W, H, Size float32
+	A1, A2     float64
+	Radii      []float32
+	Text       string
+	Color      color.NRGBA
+}
+
+// Recorder receives the drawing operations made on a canvas
+type Recorder interface {
+	Record(DrawCall)
+}
+
+// CallLog is a Recorder that keeps every call, in order
+type CallLog struct {
+	Calls []DrawCall
+}
+
+// Record appends a call to the log
+func (l *CallLog) Record(d DrawCall) {
+	l.Calls = append(l.Calls, d)
+}
+
+// Find returns the logged calls for the named operation (for example "text", "circle")
+func (l *CallLog) Find(op string) []DrawCall {
+	var calls []DrawCall
+	for _, d := range l.Calls {
+		if d.Op == op {
+			calls = append(calls, d)
+		}
+	}
+	return calls
+}
+
+// NewRecordingCanvas makes a canvas that logs its drawing calls
+func NewRecordingCanvas(width, height float32) (*Canvas, *CallLog) {
+	c := NewCanvas(width, height, system.FrameEvent{})
+	log := new(CallLog)
+	c.Recorder = log
+	return c, log
+}
+
+// record passes a call to the canvas recorder, converting absolute points, and
+// the absolute width, height, size and radii to percentages
+func (c *Canvas) record(d DrawCall, points ...float32) {
+	if c.Recorder == nil {
+		return
+	}
+	d.Points = make([]float32, len(points))
+	for i := 0; i+1 < len(points); i += 2 {
+		d.Points[i], d.Points[i+1] = c.PctCoord(points[i], points[i+1])
+	}
+	d.W = 100 * (d.W / c.Width)
+	d.H = 100 * (d.H / c.Height)
+	d.Size = 100 * (d.Size / c.Width)
+	for i := range d.Radii {
+		d.Radii[i] = 100 * (d.Radii[i] / c.Width)
+	}
+	c.Recorder.Record(d)
+}
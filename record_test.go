@@ -0,0 +1,64 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	red := color.NRGBA{255, 0, 0, 255}
+	c.CenterRect(50, 50, 20, 10, red)
+	c.Circle(25, 75, 5, red)
+	c.Text(10, 90, 2, "hello", red)
+
+	if n := len(log.Calls); n != 3 {
+		t.Fatalf("got %d calls, want 3: %v", n, log.Calls)
+	}
+	r := log.Find("rect")
+	if len(r) != 1 || r[0].Points[0] != 40 || r[0].Points[1] != 55 || r[0].W != 20 || r[0].H != 10 {
+		t.Errorf("rect: %+v", r)
+	}
+	ci := log.Find("circle")
+	if len(ci) != 1 || ci[0].Points[0] != 25 || ci[0].Points[1] != 75 || ci[0].Size != 5 {
+		t.Errorf("circle: %+v", ci)
+	}
+	tx := log.Find("text")
+	if len(tx) != 1 || tx[0].Text != "hello" || tx[0].Points[0] != 10 || tx[0].Points[1] != 90 {
+		t.Errorf("text: %+v", tx)
+	}
+}
+
+func TestMargins(t *testing.T) {
+	c, _ := NewRecordingCanvas(1000, 500)
+	c.SetMargins(10, 5, 10, 5)
+	if c.Width != 900 || c.Height != 400 {
+		t.Errorf("safe area %v x %v, want 900 x 400", c.Width, c.Height)
+	}
+	if x, y := c.Origin(); x != 50 || y != 50 {
+		t.Errorf("origin (%v, %v), want (50, 50)", x, y)
+	}
+	c.ClearMargins()
+	if c.Width != 1000 || c.Height != 500 {
+		t.Errorf("cleared: %v x %v, want 1000 x 500", c.Width, c.Height)
+	}
+}
+
+func TestStrokedShapes(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	red := color.NRGBA{255, 0, 0, 255}
+	c.StrokedRect(50, 50, 20, 10, 0.5, red)
+	c.StrokedEllipse(25, 75, 5, 10, 0.5, red)
+
+	if n := len(log.Calls); n != 2 {
+		t.Fatalf("got %d calls, want 2: %v", n, log.Calls)
+	}
+	r := log.Find("strokedrect")
+	if len(r) != 1 || r[0].Points[0] != 40 || r[0].Points[1] != 55 || r[0].W != 20 || r[0].H != 10 || r[0].Size != 0.5 {
+		t.Errorf("strokedrect: %+v", r)
+	}
+	e := log.Find("strokedellipse")
+	if len(e) != 1 || e[0].Points[0] != 25 || e[0].Points[1] != 75 || e[0].W != 5 || e[0].H != 10 {
+		t.Errorf("strokedellipse: %+v", e)
+	}
+}
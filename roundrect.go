@@ -0,0 +1,65 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Rounded rectangles, with the same or different radii at each corner
+
+// AbsRoundedRectCorners makes a filled rectangle, upper left corner at (x, y), with dimensions
+// (w, h), with corners rounded by the radii tl, tr, br and bl (top left, clockwise).
+// Radii are limited to half the shorter side. It is recorded with the top left radius
+// as the Size, and all four as the Radii.
+func (c *Canvas) AbsRoundedRectCorners(x, y, w, h, tl, tr, br, bl float32, fillcolor color.NRGBA) {
+	c.record(DrawCall{Op: "roundedrect", W: w, H: h, Size: tl, Radii: []float32{tl, tr, br, bl}, Color: fillcolor}, x, y)
+	limit := min32(w, h) / 2
+	r := [4]float32{tl, tr, br, bl}
+	for i := range r {
+		r[i] = max32(0, min32(r[i], limit))
+	}
+	ops := c.Context.Ops
+	path := new(clip.Path)
+	path.Begin(ops)
+	path.MoveTo(f32.Pt(x+r[0], y))
+	path.LineTo(f32.Pt(x+w-r[1], y))
+	arcto(path, x+w-r[1], y+r[1], r[1], -math.Pi/2, 0)
+	path.LineTo(f32.Pt(x+w, y+h-r[2]))
+	arcto(path, x+w-r[2], y+h-r[2], r[2], 0, math.Pi/2)
+	path.LineTo(f32.Pt(x+r[3], y+h))
+	arcto(path, x+r[3], y+h-r[3], r[3], math.Pi/2, math.Pi)
+	path.LineTo(f32.Pt(x, y+r[0]))
+	arcto(path, x+r[0], y+r[0], r[0], math.Pi, 3*math.Pi/2)
+	path.Close()
+	stack := clip.Outline{Path: path.End()}.Op().Push(ops)
+	paint.ColorOp{Color: fillcolor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
+
+// AbsRoundedRect makes a filled rectangle, upper left corner at (x, y), with dimensions
+// (w, h), with its corners rounded by the radius
+func (c *Canvas) AbsRoundedRect(x, y, w, h, radius float32, fillcolor color.NRGBA) {
+	c.AbsRoundedRectCorners(x, y, w, h, radius, radius, radius, radius, fillcolor)
+}
+
+// RoundedRect makes a filled rectangle using percentage-based measures,
+// centered at (x,y), sized at (w,h), with its corners rounded by the radius
+// (a percentage of the width)
+func (c *Canvas) RoundedRect(x, y, w, h, radius float32, fillcolor color.NRGBA) {
+	c.RoundedRectCorners(x, y, w, h, radius, radius, radius, radius, fillcolor)
+}
+
+// RoundedRectCorners makes a filled rectangle using percentage-based measures,
+// centered at (x,y), sized at (w,h), with its corners rounded by the radii tl, tr, br
+// and bl (top left, clockwise; percentages of the width)
+func (c *Canvas) RoundedRectCorners(x, y, w, h, tl, tr, br, bl float32, fillcolor color.NRGBA) {
+	x, y = dimen(x, y, c.Width, c.Height)
+	w = pct(w, c.Width)
+	h = pct(h, c.Height)
+	c.AbsRoundedRectCorners(x-w/2, y-h/2, w, h, pct(tl, c.Width), pct(tr, c.Width), pct(br, c.Width), pct(bl, c.Width), fillcolor)
+}
@@ -0,0 +1,32 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundedRectCorners(t *testing.T) {
+	c, log := NewRecordingCanvas(1000, 500)
+	c.RoundedRectCorners(50, 50, 20, 10, 1, 2, 3, 0, c.TextColor)
+	c.RoundedRect(50, 50, 20, 10, 2, c.TextColor)
+	want := [][]float32{{1, 2, 3, 0}, {2, 2, 2, 2}}
+	r := log.Find("roundedrect")
+	if len(r) != len(want) {
+		t.Fatalf("got %d calls, want %d", len(r), len(want))
+	}
+	for i, call := range r {
+		if len(call.Radii) != 4 {
+			t.Errorf("call %d: radii %v", i, call.Radii)
+			continue
+		}
+		for j, v := range want[i] {
+			if math.Abs(float64(call.Radii[j]-v)) > 1e-4 {
+				t.Errorf("call %d: radii %v, want %v", i, call.Radii, want[i])
+				break
+			}
+		}
+		if math.Abs(float64(call.Size-want[i][0])) > 1e-4 {
+			t.Errorf("call %d: size %v, want %v", i, call.Size, want[i][0])
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+)
+
+// Retained scenes: shapes kept as objects that can be edited, hit tested and redrawn
+
+// Transform moves, scales and rotates a node about its center.
+// Rotate is in degrees (counter-clockwise), Scale 0 is the same as 1,
+// and (DX, DY) is a percentage-based offset.
+type Transform struct {
+	DX     float32 `json:"dx,omitempty"`
+	DY     float32 `json:"dy,omitempty"`
+	Rotate float32 `json:"rotate,omitempty"`
+	Scale  float32 `json:"scale,omitempty"`
+}
+
+// Node is an object in a scene, using percentage-based measures:
+//
+//	"rect":    centered at (X, Y), sized (W, H)
+//	"ellipse": centered at (X, Y), radii (W, H)
+//	"circle":  centered at (X, Y), radius W
+//	"line":    from (Points[0], Points[1]) to (Points[2], Points[3])
+//	"polygon": with vertices (x, y) in Points
+//	"text":    beginning at (X, Y), sized H
+//	"group":   the Children, centered at (X, Y)
+//
+// Shapes are filled with Fill; lines, and the outlines of shapes with a
+// StrokeWidth, use Stroke.
+type Node struct {
+	ID          int
+	Kind        string
+	X, Y, W, H  float32
+	Points      []float32
+	Text        string
+	Fill        color.NRGBA
+	Stroke      color.NRGBA
+	StrokeWidth float32
+	Transform   Transform
+	Children    []*Node
+}
+
+// Scene is an ordered list of nodes, drawn first to last
+type Scene struct {
+	Nodes  []*Node
+	lastID int
+}
+
+// NewScene makes an empty scene
+func NewScene() *Scene {
+	return new(Scene)
+}
+
+// Add appends a node (and its children) to the scene, assigning IDs to those without one,
+// and returns the node's ID
+func (s *Scene) Add(n *Node) int {
+	return s.Insert(0, -1, n)
+}
+
+// Insert places a node at position i among the children of the group with the ID
+// parent, or of the scene itself if parent is 0, and returns the node's ID.
+// A position out of range appends the node.
+func (s *Scene) Insert(parent, i int, n *Node) int {
+	list := &s.Nodes
+	if parent != 0 {
+		p := s.Find(parent)
+		if p == nil {
+			return 0
+		}
+		list = &p.Children
+	}
+	s.assign(n)
+	if i < 0 || i > len(*list) {
+		i = len(*list)
+	}
+	*list = append(*list, nil)
+	copy((*list)[i+1:], (*list)[i:])
+	(*list)[i] = n
+	return n.ID
+}
+
+// assign gives IDs to a node and its children
+func (s *Scene) assign(n *Node) {
+	if n.ID == 0 {
+		s.lastID++
+		n.ID = s.lastID
+	} else if n.ID > s.lastID {
+		s.lastID = n.ID
+	}
+	for _, ch := range n.Children {
+		s.assign(ch)
+	}
+}
+
+// locate returns the list holding the node with the specified ID, its position,
+// and the ID of the group holding the list (0 for the top level)
+func locate(nodes *[]*Node, id, parent int) (*[]*Node, int, int) {
+	for i, n := range *nodes {
+		if n.ID == id {
+			return nodes, i, parent
+		}
+		if l, j, p := locate(&n.Children, id, n.ID); l != nil {
+			return l, j, p
+		}
+	}
+	return nil, -1, 0
+}
+
+// Find returns the node with the specified ID, or nil
+func (s *Scene) Find(id int) *Node {
+	l, i, _ := locate(&s.Nodes, id, 0)
+	if l == nil {
+		return nil
+	}
+	return (*l)[i]
+}
+
+// Remove takes the node with the specified ID out of the scene, returning it, the ID of
+// the group that held it (0 for the top level) and its position there
+func (s *Scene) Remove(id int) (n *Node, parent, index int) {
+	l, i, p := locate(&s.Nodes, id, 0)
+	if l == nil {
+		return nil, 0, -1
+	}
+	n = (*l)[i]
+	*l = append((*l)[:i], (*l)[i+1:]...)
+	return n, p, i
+}
+
+// Draw draws the scene on the canvas
+func (s *Scene) Draw(c *Canvas) {
+	for _, n := range s.Nodes {
+		n.Draw(c)
+	}
+}
+
+// Bounds returns the extent of the node, before its transform: the center (x, y) and size (w, h)
+func (n *Node) Bounds() (x, y, w, h float32) {
+	switch n.Kind {
+	case "rect":
+		return n.X, n.Y, n.W, n.H
+	case "ellipse":
+		return n.X, n.Y, 2 * n.W, 2 * n.H
+	case "circle":
+		return n.X, n.Y, 2 * n.W, 2 * n.W
+	case "text":
+		w := float32(len([]rune(n.Text))) * n.H * 0.55
+		return n.X + w/2, n.Y + n.H/2, w, n.H
+	}
+	if len(n.Points) < 2 {
+		return n.X, n.Y, 0, 0
+	}
+	x1, y1, x2, y2 := n.Points[0], n.Points[1], n.Points[0], n.Points[1]
+	for i := 2; i+1 < len(n.Points); i += 2 {
+		x1, x2 = min32(x1, n.Points[i]), max32(x2, n.Points[i])
+		y1, y2 = min32(y1, n.Points[i+1]), max32(y2, n.Points[i+1])
+	}
+	return (x1 + x2) / 2, (y1 + y2) / 2, x2 - x1, y2 - y1
+}
+
+// transform returns the absolute transform of the node on the canvas
+func (n *Node) transform(c *Canvas) f32.Affine2D {
+	t := n.Transform
+	x, y, _, _ := n.Bounds()
+	cx, cy := dimen(x, y, c.Width, c.Height)
+	a := f32.Affine2D{}
+	if t.Scale != 0 && t.Scale != 1 {
+		a = a.Scale(f32.Pt(cx, cy), f32.Pt(t.Scale, t.Scale))
+	}
+	if t.Rotate != 0 {
+		a = a.Rotate(f32.Pt(cx, cy), -t.Rotate*math.Pi/180)
+	}
+	return a.Offset(f32.Pt(pct(t.DX, c.Width), -pct(t.DY, c.Height)))
+}
+
+// Draw draws the node, and its children, on the canvas
+func (n *Node) Draw(c *Canvas) {
+	stack := op.Affine(n.transform(c)).Push(c.Context.Ops)
+	defer stack.Pop()
+	sw := n.StrokeWidth
+	switch n.Kind {
+	case "rect":
+		c.CenterRect(n.X, n.Y, n.W, n.H, n.Fill)
+		if sw > 0 {
+			l, r, t, b := n.X-n.W/2, n.X+n.W/2, n.Y+n.H/2, n.Y-n.H/2
+			outline(c, []float32{l, r, r, l}, []float32{t, t, b, b}, sw, n.Stroke)
+		}
+	case "ellipse":
+		c.Ellipse(n.X, n.Y, n.W, n.H, n.Fill)
+	case "circle":
+		c.Circle(n.X, n.Y, n.W, n.Fill)
+	case "line":
+		if len(n.Points) >= 4 {
+			c.Line(n.Points[0], n.Points[1], n.Points[2], n.Points[3], sw, n.Stroke)
+		}
+	case "polygon":
+		x, y := splitpoints(n.Points)
+		c.Polygon(x, y, n.Fill)
+		if sw > 0 {
+			outline(c, x, y, sw, n.Stroke)
+		}
+	case "text":
+		c.Text(n.X, n.Y, n.H, n.Text, n.Fill)
+	}
+	for _, ch := range n.Children {
+		ch.Draw(c)
+	}
+}
+
+// outline strokes the closed shape with vertices in x and y
+func outline(c *Canvas, x, y []float32, size float32, strokecolor color.NRGBA) {
+	for i := range x {
+		j := (i + 1) % len(x)
+		c.Line(x[i], y[i], x[j], y[j], size, strokecolor)
+	}
+}
+
+// splitpoints separates (x, y) pairs into lists of x and y
+func splitpoints(points []float32) ([]float32, []float32) {
+	x := make([]float32, 0, len(points)/2)
+	y := make([]float32, 0, len(points)/2)
+	for i := 0; i+1 < len(points); i += 2 {
+		x = append(x, points[i])
+		y = append(y, points[i+1])
+	}
+	return x, y
+}
+
+// Hit returns the topmost node whose bounds (moved by its offset) contain (x, y), or nil
+func (s *Scene) Hit(x, y float32) *Node {
+	for i := len(s.Nodes) - 1; i >= 0; i-- {
+		n := s.Nodes[i]
+		bx, by, bw, bh := n.Bounds()
+		bx += n.Transform.DX
+		by += n.Transform.DY
+		if sc := n.Transform.Scale; sc != 0 {
+			bw *= sc
+			bh *= sc
+		}
+		if x >= bx-bw/2 && x <= bx+bw/2 && y >= by-bh/2 && y <= by+bh/2 {
+			return n
+		}
+	}
+	return nil
+}
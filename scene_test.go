@@ -0,0 +1,30 @@
+package giocanvas
+
+import (
+	"bytes"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestSceneJSON(t *testing.T) {
+	s := NewScene()
+	s.Add(&Node{Kind: "group", Transform: Transform{Rotate: 45}, Children: []*Node{
+		{Kind: "text", X: 10, Y: 10, H: 3, Text: "hi", Fill: color.NRGBA{0, 0, 255, 255}},
+	}})
+	s.Add(&Node{Kind: "polygon", Points: []float32{0, 0, 10, 0, 5, 10}, Stroke: color.NRGBA{1, 2, 3, 4}, StrokeWidth: 0.5})
+	var b bytes.Buffer
+	if err := s.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ReadScene(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.Nodes, s.Nodes) {
+		t.Errorf("read %+v, want %+v", r.Nodes, s.Nodes)
+	}
+	if id := r.Add(&Node{Kind: "circle"}); id != 4 {
+		t.Errorf("new ID %d after loading, want 4", id)
+	}
+}
@@ -0,0 +1,124 @@
+package giocanvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// Saving and loading scenes as JSON. Colors are written as "#rrggbbaa",
+// and read as any color understood by ColorLookup.
+
+// jsonnode is the JSON form of a node
+type jsonnode struct {
+	ID          int         `json:"id,omitempty"`
+	Kind        string      `json:"kind"`
+	X           float32     `json:"x,omitempty"`
+	Y           float32     `json:"y,omitempty"`
+	W           float32     `json:"w,omitempty"`
+	H           float32     `json:"h,omitempty"`
+	Points      []float32   `json:"points,omitempty"`
+	Text        string      `json:"text,omitempty"`
+	Fill        string      `json:"fill,omitempty"`
+	Stroke      string      `json:"stroke,omitempty"`
+	StrokeWidth float32     `json:"strokewidth,omitempty"`
+	Transform   *Transform  `json:"transform,omitempty"`
+	Children    []*jsonnode `json:"children,omitempty"`
+}
+
+// jsonscene is the JSON form of a scene
+type jsonscene struct {
+	Nodes []*jsonnode `json:"nodes"`
+}
+
+// hexcolor returns a color as "#rrggbbaa", or "" for the zero color
+func hexcolor(c color.NRGBA) string {
+	if c == (color.NRGBA{}) {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// parsecolor returns the color named by s, or the zero color for ""
+func parsecolor(s string) color.NRGBA {
+	if s == "" {
+		return color.NRGBA{}
+	}
+	return ColorLookup(s)
+}
+
+func tojson(n *Node) *jsonnode {
+	j := &jsonnode{
+		ID: n.ID, Kind: n.Kind, X: n.X, Y: n.Y, W: n.W, H: n.H,
+		Points: n.Points, Text: n.Text,
+		Fill: hexcolor(n.Fill), Stroke: hexcolor(n.Stroke), StrokeWidth: n.StrokeWidth,
+	}
+	if n.Transform != (Transform{}) {
+		t := n.Transform
+		j.Transform = &t
+	}
+	for _, ch := range n.Children {
+		j.Children = append(j.Children, tojson(ch))
+	}
+	return j
+}
+
+func fromjson(j *jsonnode) *Node {
+	n := &Node{
+		ID: j.ID, Kind: j.Kind, X: j.X, Y: j.Y, W: j.W, H: j.H,
+		Points: j.Points, Text: j.Text,
+		Fill: parsecolor(j.Fill), Stroke: parsecolor(j.Stroke), StrokeWidth: j.StrokeWidth,
+	}
+	if j.Transform != nil {
+		n.Transform = *j.Transform
+	}
+	for _, ch := range j.Children {
+		if ch != nil {
+			n.Children = append(n.Children, fromjson(ch))
+		}
+	}
+	return n
+}
+
+// MarshalJSON encodes a node, with its children
+func (n *Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tojson(n))
+}
+
+// UnmarshalJSON decodes a node, with its children
+func (n *Node) UnmarshalJSON(data []byte) error {
+	var j jsonnode
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*n = *fromjson(&j)
+	return nil
+}
+
+// WriteJSON writes the scene as JSON
+func (s *Scene) WriteJSON(w io.Writer) error {
+	var js jsonscene
+	js.Nodes = make([]*jsonnode, 0, len(s.Nodes))
+	for _, n := range s.Nodes {
+		js.Nodes = append(js.Nodes, tojson(n))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(js)
+}
+
+// ReadScene reads a scene written by WriteJSON. Nodes without IDs are given new ones.
+func ReadScene(r io.Reader) (*Scene, error) {
+	var js jsonscene
+	if err := json.NewDecoder(r).Decode(&js); err != nil {
+		return nil, err
+	}
+	s := NewScene()
+	for _, j := range js.Nodes {
+		if j != nil {
+			s.Add(fromjson(j))
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,78 @@
+package giocanvas
+
+import (
+	"image"
+	"strings"
+	"unicode/utf8"
+
+	"gioui.org/io/semantic"
+	"gioui.org/op/clip"
+)
+
+// Accessibility: semantic descriptions of canvas content
+
+// SemanticNode describes an item of canvas content for accessibility.
+// The bounds (X, Y, Width, Height) use percentage-based measures,
+// (X, Y) being the upper left corner.
+type SemanticNode struct {
+	Role, Label         string
+	X, Y, Width, Height float32
+}
+
+// Describe sets the role (for example "heading", "image", "caption") and label
+// (the alt text) for the next text or image placed on the canvas.
+// An empty label keeps the text itself as the label.
+func (c *Canvas) Describe(role, label string) {
+	c.semrole, c.semlabel = role, label
+}
+
+// SemanticNodes returns the semantic nodes recorded so far, in reading order
+func (c *Canvas) SemanticNodes() []SemanticNode {
+	return c.semnodes
+}
+
+// SemanticText returns the semantic nodes as structured text, one per line
+func (c *Canvas) SemanticText() string {
+	var b strings.Builder
+	for _, n := range c.semnodes {
+		b.WriteString("[" + n.Role + "] " + n.Label + "\n")
+	}
+	return b.String()
+}
+
+// semantic records a node with absolute bounds, and adds the label to the
+// platform accessibility tree, if semantics are enabled for the canvas.
+func (c *Canvas) semantic(role, label string, x, y, w, h float32) {
+	if !c.Semantic {
+		return
+	}
+	if len(c.semrole) > 0 {
+		role = c.semrole
+	}
+	if len(c.semlabel) > 0 {
+		label = c.semlabel
+	}
+	c.semrole, c.semlabel = "", ""
+	if len(label) == 0 {
+		return
+	}
+	px, py := c.PctCoord(x, y)
+	c.semnodes = append(c.semnodes, SemanticNode{
+		Role:   role,
+		Label:  label,
+		X:      px,
+		Y:      py,
+		Width:  100 * (w / c.Width),
+		Height: 100 * (h / c.Height),
+	})
+	ops := c.Context.Ops
+	stack := clip.Rect(image.Rect(int(x), int(y), int(x+w), int(y+h))).Push(ops)
+	semantic.LabelOp(label).Add(ops)
+	semantic.DescriptionOp(role).Add(ops)
+	stack.Pop()
+}
+
+// textextent estimates the width of a string at the specified size
+func textextent(s string, size float32) float32 {
+	return float32(utf8.RuneCountInString(s)) * size * 0.55
+}
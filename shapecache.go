@@ -0,0 +1,73 @@
+package giocanvas
+
+import (
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// Shape cache: the paths of circles and ellipses are built once for each size,
+// centered at the origin, and reused (translated) across the frames of a canvas.
+// Each canvas has its own cache, as the operations of a frame may be read (on another
+// goroutine, by another window) while a different canvas adds to a cache.
+
+// shapekey identifies a cached shape by its radii
+type shapekey struct {
+	w, h float32
+}
+
+// maxshapes bounds the number of cached shapes
+const maxshapes = 4096
+
+// shapecache holds the paths, which are built in their own operation list.
+// When the cache is full a new list is started; the old one stays alive as
+// long as frames refer to it.
+type shapecache struct {
+	ops   *op.Ops
+	paths map[shapekey]clip.PathSpec
+}
+
+// ClearShapeCache drops the cached shapes of the canvas
+func (c *Canvas) ClearShapeCache() {
+	c.shapes = shapecache{}
+}
+
+// ellipsepath returns the path of an ellipse centered at the origin with radii (w, h)
+func (c *Canvas) ellipsepath(w, h float32) clip.PathSpec {
+	key := shapekey{w, h}
+	cache := &c.shapes
+	if cache.paths == nil || len(cache.paths) > maxshapes {
+		cache.ops = new(op.Ops)
+		cache.paths = make(map[shapekey]clip.PathSpec)
+	}
+	if spec, ok := cache.paths[key]; ok {
+		return spec
+	}
+	const k = 0.551915024494 // http://spencermortensen.com/articles/bezier-circle/
+	path := new(clip.Path)
+	path.Begin(cache.ops)
+	path.Move(f32.Point{X: w, Y: 0})
+	path.Cube(f32.Point{X: 0, Y: h * k}, f32.Point{X: -w + w*k, Y: h}, f32.Point{X: -w, Y: h})    // SE
+	path.Cube(f32.Point{X: -w * k, Y: 0}, f32.Point{X: -w, Y: -h + h*k}, f32.Point{X: -w, Y: -h}) // SW
+	path.Cube(f32.Point{X: 0, Y: -h * k}, f32.Point{X: w - w*k, Y: -h}, f32.Point{X: w, Y: -h})   // NW
+	path.Cube(f32.Point{X: w * k, Y: 0}, f32.Point{X: w, Y: h - h*k}, f32.Point{X: w, Y: h})      // NE
+	path.Close()
+	spec := path.End()
+	cache.paths[key] = spec
+	return spec
+}
+
+// fillellipse fills the cached ellipse with radii (w, h), centered at (x, y)
+func (c *Canvas) fillellipse(x, y, w, h float32, fillcolor color.NRGBA) {
+	spec := c.ellipsepath(w, h)
+	ops := c.Context.Ops
+	t := op.Affine(f32.Affine2D{}.Offset(f32.Pt(x, y))).Push(ops)
+	stack := clip.Outline{Path: spec}.Op().Push(ops)
+	paint.ColorOp{Color: fillcolor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+	t.Pop()
+}
@@ -0,0 +1,164 @@
+package giocanvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+
+	"gioui.org/io/pointer"
+)
+
+// Sketch: capturing, editing and saving freehand drawing
+
+// Stroke is a freehand stroke, with its color and size (percentage-based)
+type Stroke struct {
+	Color  color.NRGBA   `json:"color"`
+	Size   float32       `json:"size"`
+	Points []StrokePoint `json:"points"`
+}
+
+// Sketch is a freehand drawing made from pointer input, with undo and redo.
+// Color and Size are used for new strokes; Smoothing (0-1) evens out the
+// jitter of new points, 0 for none.
+type Sketch struct {
+	Strokes   []Stroke    `json:"strokes"`
+	Color     color.NRGBA `json:"-"`
+	Size      float32     `json:"-"`
+	Smoothing float32     `json:"-"`
+
+	undone   []Stroke
+	drawing  bool
+	revision int
+}
+
+// NewSketch makes an empty sketch, drawing with the specified color and size
+func NewSketch(strokecolor color.NRGBA, size float32) *Sketch {
+	return &Sketch{Color: strokecolor, Size: size, Smoothing: 0.5}
+}
+
+// Input adds a pointer event to the sketch: a press starts a stroke, drags extend it,
+// and a release ends it. Input reports whether the drawing changed.
+func (s *Sketch) Input(p PointerEvent) bool {
+	pt := StrokePoint{X: p.X, Y: p.Y, Pressure: p.Pressure}
+	switch p.Type {
+	case pointer.Press:
+		s.Strokes = append(s.Strokes, Stroke{Color: s.Color, Size: s.Size, Points: []StrokePoint{pt}})
+		s.undone = nil
+		s.drawing = true
+	case pointer.Drag:
+		if !s.drawing || len(s.Strokes) == 0 {
+			return false
+		}
+		st := &s.Strokes[len(s.Strokes)-1]
+		if n := len(st.Points); n > 0 && s.Smoothing > 0 {
+			prev := st.Points[n-1]
+			k := 1 - s.Smoothing
+			pt.X = prev.X + (pt.X-prev.X)*k
+			pt.Y = prev.Y + (pt.Y-prev.Y)*k
+		}
+		st.Points = append(st.Points, pt)
+	case pointer.Release, pointer.Cancel:
+		s.drawing = false
+		return false
+	default:
+		return false
+	}
+	s.revision++
+	return true
+}
+
+// Drawing reports whether a stroke is in progress
+func (s *Sketch) Drawing() bool {
+	return s.drawing
+}
+
+// Revision is a count of the changes to the sketch, for detecting when to redraw
+func (s *Sketch) Revision() int {
+	return s.revision
+}
+
+// Undo removes the last stroke, reporting whether there was one
+func (s *Sketch) Undo() bool {
+	n := len(s.Strokes)
+	if n == 0 {
+		return false
+	}
+	s.undone = append(s.undone, s.Strokes[n-1])
+	s.Strokes = s.Strokes[:n-1]
+	s.drawing = false
+	s.revision++
+	return true
+}
+
+// Redo restores the last stroke removed by Undo, reporting whether there was one
+func (s *Sketch) Redo() bool {
+	n := len(s.undone)
+	if n == 0 {
+		return false
+	}
+	s.Strokes = append(s.Strokes, s.undone[n-1])
+	s.undone = s.undone[:n-1]
+	s.revision++
+	return true
+}
+
+// Clear removes all the strokes
+func (s *Sketch) Clear() {
+	s.Strokes = nil
+	s.undone = nil
+	s.drawing = false
+	s.revision++
+}
+
+// Draw draws the sketch on the canvas
+func (s *Sketch) Draw(c *Canvas) {
+	for _, st := range s.Strokes {
+		c.Freehand(st.Points, st.Size, st.Color)
+	}
+}
+
+// WriteJSON writes the strokes of the sketch as JSON
+func (s *Sketch) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadJSON replaces the strokes of the sketch with those read as JSON
+func (s *Sketch) ReadJSON(r io.Reader) error {
+	var in Sketch
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return err
+	}
+	s.Strokes = in.Strokes
+	s.undone = nil
+	s.drawing = false
+	s.revision++
+	return nil
+}
+
+// svgcolor returns the SVG color and opacity attributes for a color
+func svgcolor(attr string, c color.NRGBA) string {
+	return fmt.Sprintf(`%s="rgb(%d,%d,%d)" %s-opacity="%.3f"`, attr, c.R, c.G, c.B, attr, float64(c.A)/255)
+}
+
+// WriteSVG writes the sketch as an SVG document sized (width, height)
+func (s *Sketch) WriteSVG(w io.Writer, width, height float32) error {
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n", width, height, width, height)
+	for _, st := range s.Strokes {
+		size := pct(st.Size, width)
+		for i, p := range st.Points {
+			x, y := dimen(p.X, p.Y, width, height)
+			if i == 0 {
+				fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" %s/>\n", x, y, pressurewidth(size, p.Pressure)/2, svgcolor("fill", st.Color))
+				continue
+			}
+			q := st.Points[i-1]
+			px, py := dimen(q.X, q.Y, width, height)
+			sw := pressurewidth(size, (p.Pressure+q.Pressure)/2)
+			fmt.Fprintf(w, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke-width=\"%.2f\" stroke-linecap=\"round\" %s/>\n",
+				px, py, x, y, sw, svgcolor("stroke", st.Color))
+		}
+	}
+	_, err := io.WriteString(w, "</svg>\n")
+	return err
+}
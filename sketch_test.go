@@ -0,0 +1,41 @@
+package giocanvas
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"gioui.org/io/pointer"
+)
+
+func TestSketch(t *testing.T) {
+	s := NewSketch(color.NRGBA{255, 0, 0, 255}, 1)
+	s.Input(PointerEvent{Type: pointer.Press, X: 10, Y: 10, Pressure: 1})
+	s.Input(PointerEvent{Type: pointer.Drag, X: 20, Y: 20, Pressure: 1})
+	s.Input(PointerEvent{Type: pointer.Release, X: 20, Y: 20})
+	if len(s.Strokes) != 1 || len(s.Strokes[0].Points) != 2 {
+		t.Fatalf("strokes: %+v", s.Strokes)
+	}
+	if p := s.Strokes[0].Points[1]; p.X != 15 || p.Y != 15 {
+		t.Errorf("smoothed point: %+v", p)
+	}
+	if !s.Undo() || len(s.Strokes) != 0 || !s.Redo() || len(s.Strokes) != 1 {
+		t.Errorf("undo/redo: %+v", s.Strokes)
+	}
+
+	var b bytes.Buffer
+	if err := s.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	r := NewSketch(color.NRGBA{}, 2)
+	if err := r.ReadJSON(&b); err != nil || len(r.Strokes) != 1 || r.Strokes[0].Color != s.Color {
+		t.Errorf("read JSON: %v %+v", err, r.Strokes)
+	}
+
+	b.Reset()
+	s.WriteSVG(&b, 100, 100)
+	if !strings.Contains(b.String(), "<line") {
+		t.Errorf("SVG: %s", b.String())
+	}
+}
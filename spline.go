@@ -0,0 +1,97 @@
+package giocanvas
+
+import (
+	"image/color"
+	"math"
+)
+
+// Splines: smooth curves through points, without working out control points
+
+// splinecurves returns the cubic bezier curves of a centripetal Catmull-Rom spline through the
+// points: for each pair of neighboring points, its control points and end (cx1, cy1, cx2, cy2,
+// x, y). The centripetal form neither overshoots nor loops where points are unevenly spaced.
+// The ends are continued by reflecting their neighbors.
+func splinecurves(x, y []float32) [][6]float32 {
+	n := len(x)
+	if n < 2 || len(y) != n {
+		return nil
+	}
+	point := func(i int) (float64, float64) {
+		switch {
+		case i < 0:
+			return float64(2*x[0] - x[1]), float64(2*y[0] - y[1])
+		case i >= n:
+			return float64(2*x[n-1] - x[n-2]), float64(2*y[n-1] - y[n-2])
+		}
+		return float64(x[i]), float64(y[i])
+	}
+	// distance to the power 1/2, and its square
+	dist := func(ax, ay, bx, by float64) (float64, float64) {
+		d := math.Hypot(bx-ax, by-ay)
+		return math.Sqrt(d), d
+	}
+	curves := make([][6]float32, 0, n-1)
+	for i := 0; i < n-1; i++ {
+		x0, y0 := point(i - 1)
+		x1, y1 := point(i)
+		x2, y2 := point(i + 1)
+		x3, y3 := point(i + 2)
+		d1, d1sq := dist(x0, y0, x1, y1)
+		d2, d2sq := dist(x1, y1, x2, y2)
+		d3, d3sq := dist(x2, y2, x3, y3)
+		c1x, c1y, c2x, c2y := x1, y1, x2, y2
+		if d1 > 1e-6 && d2 > 1e-6 {
+			k := 3 * d1 * (d1 + d2)
+			c1x = (d1sq*x2 - d2sq*x0 + (2*d1sq+3*d1*d2+d2sq)*x1) / k
+			c1y = (d1sq*y2 - d2sq*y0 + (2*d1sq+3*d1*d2+d2sq)*y1) / k
+		}
+		if d3 > 1e-6 && d2 > 1e-6 {
+			k := 3 * d3 * (d3 + d2)
+			c2x = (d3sq*x1 - d2sq*x3 + (2*d3sq+3*d3*d2+d2sq)*x2) / k
+			c2y = (d3sq*y1 - d2sq*y3 + (2*d3sq+3*d3*d2+d2sq)*y2) / k
+		}
+		curves = append(curves, [6]float32{float32(c1x), float32(c1y), float32(c2x), float32(c2y), float32(x2), float32(y2)})
+	}
+	return curves
+}
+
+// AbsSpline strokes a smooth curve through the points (x, y), size wide,
+// dashed and capped as polylines are
+func (c *Canvas) AbsSpline(x, y []float32, size float32, strokecolor color.NRGBA) {
+	curves := splinecurves(x, y)
+	if curves == nil {
+		return
+	}
+	if c.Recorder != nil {
+		points := make([]float32, 0, len(x)*2)
+		for i := range x {
+			points = append(points, x[i], y[i])
+		}
+		c.record(DrawCall{Op: "spline", Size: size, Color: strokecolor}, points...)
+	}
+	px, py := []float32{x[0]}, []float32{y[0]}
+	sx, sy := x[0], y[0]
+	for _, b := range curves {
+		fx, fy := flattencubic(sx, sy, b[0], b[1], b[2], b[3], b[4], b[5])
+		px, py = append(px, fx[1:]...), append(py, fy[1:]...)
+		sx, sy = b[4], b[5]
+	}
+	rec := c.Recorder
+	c.Recorder = nil
+	c.AbsPolyline(px, py, size, strokecolor)
+	c.Recorder = rec
+}
+
+// Spline strokes a smooth curve through the points (x, y), as AbsSpline,
+// using percentage-based measures; size is a percentage of the width
+func (c *Canvas) Spline(x, y []float32, size float32, strokecolor color.NRGBA) {
+	if len(x) != len(y) {
+		return
+	}
+	nx := make([]float32, len(x))
+	ny := make([]float32, len(y))
+	for i := range x {
+		nx[i], ny[i] = dimen(x[i], y[i], c.Width, c.Height)
+	}
+	c.AbsSpline(nx, ny, pct(size, c.Width), strokecolor)
+}
@@ -0,0 +1,31 @@
+package giocanvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpline(t *testing.T) {
+	// points on a line make a straight curve, controls a third of the way between
+	curves := splinecurves([]float32{0, 30, 60}, []float32{0, 0, 0})
+	if len(curves) != 2 {
+		t.Fatalf("%d curves, want 2", len(curves))
+	}
+	if c := curves[0]; math.Abs(float64(c[0]-10)) > 1e-3 || math.Abs(float64(c[2]-20)) > 1e-3 || c[4] != 30 || c[1] != 0 {
+		t.Errorf("first curve %v, want controls at 10 and 20, ending at 30", c)
+	}
+	// the curve through a peak is level there
+	curves = splinecurves([]float32{0, 50, 100}, []float32{0, 40, 0})
+	if a, b := curves[0], curves[1]; a[3] != 40 || b[1] != 40 {
+		t.Errorf("curves %v and %v, want controls level with the peak", a, b)
+	}
+	if splinecurves([]float32{1}, []float32{1}) != nil {
+		t.Error("curves through one point")
+	}
+
+	c, log := NewRecordingCanvas(200, 100)
+	c.Spline([]float32{10, 30, 50, 70}, []float32{20, 60, 40, 80}, 0.5, c.TextColor)
+	if len(log.Calls) != 1 || len(log.Find("spline")) != 1 {
+		t.Errorf("recorded %v, want one spline", log.Calls)
+	}
+}
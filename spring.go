@@ -0,0 +1,80 @@
+package giocanvas
+
+import (
+	"math"
+	"time"
+)
+
+// Springs: critically damped motion towards a target
+
+// Spring moves Value towards Target without overshoot, as a critically damped spring.
+// Omega (radians/second) sets the stiffness: larger values settle faster;
+// the value is within 1% of the target after about 6.6/Omega seconds.
+type Spring struct {
+	Value, Velocity, Target float64
+	Omega                   float64
+	last                    time.Time
+}
+
+// NewSpring makes a spring at rest at value
+func NewSpring(value, omega float64) *Spring {
+	return &Spring{Value: value, Target: value, Omega: omega}
+}
+
+// Update advances the spring by dt, and returns its value
+func (s *Spring) Update(dt time.Duration) float64 {
+	t := dt.Seconds()
+	if t <= 0 {
+		return s.Value
+	}
+	w := s.Omega
+	x := s.Value - s.Target
+	k := s.Velocity + w*x
+	e := math.Exp(-w * t)
+	s.Value = s.Target + (x+k*t)*e
+	s.Velocity = (s.Velocity - w*k*t) * e
+	return s.Value
+}
+
+// Step advances the spring to the time now (typically the frame time), and returns its value.
+// The first step only records the time.
+func (s *Spring) Step(now time.Time) float64 {
+	if s.last.IsZero() {
+		s.last = now
+		return s.Value
+	}
+	dt := now.Sub(s.last)
+	s.last = now
+	return s.Update(dt)
+}
+
+// Settled reports whether the spring is at rest at its target, within tolerance;
+// until it is, keep drawing frames
+func (s *Spring) Settled(tolerance float64) bool {
+	return math.Abs(s.Value-s.Target) <= tolerance && math.Abs(s.Velocity) <= tolerance
+}
+
+// Spring2 is a spring for a position (x, y)
+type Spring2 struct {
+	X, Y Spring
+}
+
+// NewSpring2 makes a spring at rest at (x, y)
+func NewSpring2(x, y, omega float64) *Spring2 {
+	return &Spring2{X: *NewSpring(x, omega), Y: *NewSpring(y, omega)}
+}
+
+// SetTarget sets the position the spring moves to
+func (s *Spring2) SetTarget(x, y float64) {
+	s.X.Target, s.Y.Target = x, y
+}
+
+// Step advances the spring to the time now, and returns its position
+func (s *Spring2) Step(now time.Time) (float32, float32) {
+	return float32(s.X.Step(now)), float32(s.Y.Step(now))
+}
+
+// Settled reports whether the spring is at rest at its target, within tolerance
+func (s *Spring2) Settled(tolerance float64) bool {
+	return s.X.Settled(tolerance) && s.Y.Settled(tolerance)
+}
@@ -0,0 +1,19 @@
+package giocanvas
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpring(t *testing.T) {
+	s := NewSpring(0, 10)
+	s.Target = 100
+	for i := 0; i < 200; i++ {
+		if v := s.Update(10 * time.Millisecond); v > 100 {
+			t.Fatalf("overshoot: %v", v)
+		}
+	}
+	if !s.Settled(0.1) {
+		t.Errorf("not settled after 2s: %v %v", s.Value, s.Velocity)
+	}
+}
@@ -0,0 +1,234 @@
+package giocanvas
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/text"
+)
+
+// Terminals: command output, colored by ANSI escape sequences (SGR: colors and bold),
+// shown in a console window
+
+// termspan is a run of terminal text in one style, starting at a column;
+// colors with zero alpha are the defaults
+type termspan struct {
+	col    int
+	text   string
+	fg, bg color.NRGBA
+	bold   bool
+}
+
+// termpalette are the 16 basic terminal colors: black, red, green, yellow, blue,
+// magenta, cyan and white, then their bright versions
+var termpalette = [16]color.NRGBA{
+	{0, 0, 0, 255}, {205, 49, 49, 255}, {13, 188, 121, 255}, {229, 229, 16, 255},
+	{36, 114, 200, 255}, {188, 63, 188, 255}, {17, 168, 205, 255}, {229, 229, 229, 255},
+	{102, 102, 102, 255}, {241, 76, 76, 255}, {35, 209, 139, 255}, {245, 245, 67, 255},
+	{59, 142, 234, 255}, {214, 112, 214, 255}, {41, 184, 219, 255}, {255, 255, 255, 255},
+}
+
+// termcolor returns color n of the 256-color palette: the basic colors,
+// a 6x6x6 color cube, then 24 grays
+func termcolor(n int) color.NRGBA {
+	switch {
+	case n < 0 || n > 255:
+		return color.NRGBA{}
+	case n < 16:
+		return termpalette[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + 40*v)
+		}
+		return color.NRGBA{level(n / 36), level(n / 6 % 6), level(n % 6), 255}
+	}
+	g := uint8(8 + 10*(n-232))
+	return color.NRGBA{g, g, g, 255}
+}
+
+// termsgr applies the parameters of an SGR sequence to the colors and weight
+func termsgr(params string, fg, bg *color.NRGBA, bold *bool) {
+	var p []int
+	for _, s := range strings.Split(params, ";") {
+		v, _ := strconv.Atoi(s) // an empty parameter is 0
+		p = append(p, v)
+	}
+	for i := 0; i < len(p); i++ {
+		switch v := p[i]; {
+		case v == 0:
+			*fg, *bg, *bold = color.NRGBA{}, color.NRGBA{}, false
+		case v == 1:
+			*bold = true
+		case v == 22:
+			*bold = false
+		case v >= 30 && v <= 37:
+			*fg = termpalette[v-30]
+		case v >= 90 && v <= 97:
+			*fg = termpalette[v-90+8]
+		case v == 39:
+			*fg = color.NRGBA{}
+		case v >= 40 && v <= 47:
+			*bg = termpalette[v-40]
+		case v >= 100 && v <= 107:
+			*bg = termpalette[v-100+8]
+		case v == 49:
+			*bg = color.NRGBA{}
+		case v == 38 || v == 48:
+			var col color.NRGBA
+			switch {
+			case i+2 < len(p) && p[i+1] == 5:
+				col = termcolor(p[i+2])
+				i += 2
+			case i+4 < len(p) && p[i+1] == 2:
+				col = color.NRGBA{uint8(p[i+2]), uint8(p[i+3]), uint8(p[i+4]), 255}
+				i += 4
+			default:
+				i = len(p)
+				continue
+			}
+			if v == 38 {
+				*fg = col
+			} else {
+				*bg = col
+			}
+		}
+	}
+}
+
+// termescapes are escape characters as written out in text that cannot hold them
+var termescapes = strings.NewReplacer(`\e`, "\x1b", `\033`, "\x1b", `\x1b`, "\x1b", `\u001b`, "\x1b")
+
+// termlines splits terminal output into lines of styled spans. Escape sequences other
+// than SGR are dropped; tabs are expanded to every eighth column.
+func termlines(output string) [][]termspan {
+	output = termescapes.Replace(output)
+	var lines [][]termspan
+	var fg, bg color.NRGBA
+	bold := false
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		var spans []termspan
+		var cur strings.Builder
+		col, start := 0, 0
+		flush := func() {
+			if cur.Len() > 0 {
+				spans = append(spans, termspan{col: start, text: cur.String(), fg: fg, bg: bg, bold: bold})
+				cur.Reset()
+			}
+			start = col
+		}
+		for i := 0; i < len(line); i++ {
+			switch ch := line[i]; ch {
+			case '\x1b':
+				if i+1 >= len(line) || line[i+1] != '[' {
+					continue
+				}
+				end := i + 2
+				for end < len(line) && (line[end] < 0x40 || line[end] > 0x7e) {
+					end++
+				}
+				if end == len(line) {
+					i = end
+					continue
+				}
+				if line[end] == 'm' {
+					flush()
+					termsgr(line[i+2:end], &fg, &bg, &bold)
+				}
+				i = end
+			case '\r':
+			case '\t':
+				n := 8 - col%8
+				cur.WriteString(strings.Repeat(" ", n))
+				col += n
+			default:
+				cur.WriteByte(ch)
+				if ch < 0x80 || ch >= 0xc0 { // count runes, not continuation bytes
+					col++
+				}
+			}
+		}
+		flush()
+		lines = append(lines, spans)
+	}
+	return lines
+}
+
+// The colors of terminal windows
+var (
+	termbackground = color.NRGBA{30, 30, 30, 255}
+	termtitlebar   = color.NRGBA{60, 60, 60, 255}
+	termbuttons    = [3]color.NRGBA{{255, 95, 86, 255}, {255, 189, 46, 255}, {39, 201, 63, 255}}
+)
+
+// AbsTerminal shows terminal output, colored by its ANSI escape sequences, in a console
+// window: the baseline of the first line is at (x, y), and the window is width wide, lines
+// too long for it being cut off. Text without a color of its own is in textcolor; the alpha
+// of textcolor applies to every color. Returns the baseline of the line after the last.
+func (c *Canvas) AbsTerminal(x, y, size, width float32, output string, textcolor color.NRGBA) float32 {
+	c.record(DrawCall{Op: "terminal", W: width, Size: size, Text: output, Color: textcolor}, x, y)
+	rec, sem, style := c.Recorder, c.Semantic, c.textstyle
+	c.Recorder, c.Semantic = nil, false
+	defer func() { c.Recorder, c.Semantic, c.textstyle = rec, sem, style }()
+
+	alpha := func(col color.NRGBA) color.NRGBA {
+		col.A = uint8(uint32(col.A) * uint32(textcolor.A) / 255)
+		return col
+	}
+	lines := termlines(output)
+	lh := size * 1.4
+	pad := size
+	bar := size * 1.8
+	top := y - size*1.1 - pad - bar
+	end := y + lh*float32(len(lines))
+	height := end - size*1.1 + pad - top
+	c.AbsRoundedRect(x-pad, top, width+2*pad, height, size*0.6, alpha(termbackground))
+	c.AbsRoundedRectCorners(x-pad, top, width+2*pad, bar, size*0.6, size*0.6, 0, 0, alpha(termtitlebar))
+	for i, b := range termbuttons {
+		c.AbsCircle(x-pad+size*(1.2+float32(i)*1.1), top+bar/2, size*0.35, alpha(b))
+	}
+
+	c.textstyle = font.Font{Variant: "Mono"}
+	cw := float32(c.measuretext("0000000000", size).X) / 10
+	var plain []string
+	clip := c.AbsClipRect(x, top+bar, width, height-bar)
+	for i, spans := range lines {
+		base := y + lh*float32(i)
+		var line strings.Builder
+		for _, s := range spans {
+			n := len([]rune(s.text))
+			left := x + float32(s.col)*cw
+			if s.bg.A > 0 {
+				c.AbsRect(left, base-size*1.05, float32(n)*cw, lh, alpha(s.bg))
+			}
+			fg := textcolor
+			if s.fg.A > 0 {
+				fg = alpha(s.fg)
+			}
+			c.textstyle.Weight = font.Normal
+			if s.bold {
+				c.textstyle.Weight = font.Bold
+			}
+			c.textops(left, base, size, text.Start, s.text, fg)
+			line.WriteString(s.text)
+		}
+		plain = append(plain, line.String())
+	}
+	EndClip(clip)
+	c.Semantic = sem
+	c.semantic("text", strings.Join(plain, "\n"), x-pad, top, width+2*pad, height)
+	return end
+}
+
+// Terminal shows terminal output in a console window using percentage-based measures,
+// as AbsTerminal, returning the baseline of the line after the last
+func (c *Canvas) Terminal(x, y, size, width float32, output string, textcolor color.NRGBA) float32 {
+	x, y = dimen(x, y, c.Width, c.Height)
+	end := c.AbsTerminal(x, y, pct(size, c.Width), pct(width, c.Width), output, textcolor)
+	return 100 - 100*end/c.Height
+}
@@ -0,0 +1,41 @@
+package giocanvas
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestTermLines(t *testing.T) {
+	red, green := termpalette[1], termpalette[2]
+	lines := termlines("\x1b[32mok\x1b[0m\tpkg\n\\e[1;31mFAIL\\e[m x\x1b[2K\n\x1b[38;5;196;48;2;1;2;3mhi")
+	want := [][]termspan{
+		{{col: 0, text: "ok", fg: green}, {col: 2, text: "      pkg"}},
+		{{col: 0, text: "FAIL", fg: red, bold: true}, {col: 4, text: " x"}},
+		{{col: 0, text: "hi", fg: color.NRGBA{255, 0, 0, 255}, bg: color.NRGBA{1, 2, 3, 255}}},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %+v, want %+v", lines, want)
+	}
+	for i := range want {
+		if len(lines[i]) != len(want[i]) {
+			t.Errorf("line %d: got %+v, want %+v", i, lines[i], want[i])
+			continue
+		}
+		for j := range want[i] {
+			if lines[i][j] != want[i][j] {
+				t.Errorf("line %d span %d: got %+v, want %+v", i, j, lines[i][j], want[i][j])
+			}
+		}
+	}
+	if got, want := termcolor(232), (color.NRGBA{8, 8, 8, 255}); got != want {
+		t.Errorf("termcolor(232) = %v, want %v", got, want)
+	}
+
+	c, log := NewRecordingCanvas(400, 400)
+	if end := c.AbsTerminal(20, 50, 10, 300, "a\nb", c.TextColor); end != 50+2*14 {
+		t.Errorf("next baseline %v, want %v", end, 50+2*14)
+	}
+	if len(log.Calls) != 1 || len(log.Find("terminal")) != 1 {
+		t.Errorf("recorded %v, want one terminal call", log.Calls)
+	}
+}
@@ -0,0 +1,116 @@
+package giocanvas
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"gioui.org/font"
+	"gioui.org/font/gofont"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+)
+
+// Text layout cache: text is shaped once, and its operations reused, by the content,
+// size, color, canvas dimensions and scale, so that canvases of different sizes and
+// densities (in different windows, or offscreen) share the cache.
+
+// textkey identifies a text layout
+type textkey struct {
+	s           string
+	size, width float32
+	alignment   text.Alignment
+	dir         system.TextDirection
+	color       color.NRGBA
+	font        font.Font
+	cw, ch      float32     // the canvas dimensions
+	metric      unit.Metric // the scale of sp and dp, which text sizes are in
+}
+
+// maxtextlayouts bounds the number of cached layouts
+const maxtextlayouts = 4096
+
+// textcache holds the shared theme (and its shaper), and the cached layouts
+var textcache struct {
+	sync.Mutex
+	theme   *material.Theme
+	layouts map[textkey]textentry
+}
+
+// textentry is a cached text layout: its operations and size
+type textentry struct {
+	call op.CallOp
+	size image.Point
+}
+
+// ClearTextCache drops all cached text layouts
+func ClearTextCache() {
+	textcache.Lock()
+	textcache.layouts = nil
+	textcache.Unlock()
+}
+
+// layouttext adds the operations for s, laid out at size within width, using the
+// cached layout if there is one. The caller sets the offset.
+func (c *Canvas) layouttext(s string, size, width float32, alignment text.Alignment, fillcolor color.NRGBA) {
+	gtx := c.Context
+	gtx.Constraints.Max.X = int(width)
+	key := textkey{s: s, size: size, width: width, alignment: alignment, dir: c.direction(s), color: fillcolor, font: c.font()}
+	c.cachedtext(key, gtx).call.Add(c.Context.Ops)
+}
+
+// measuretext returns the size of s laid out at size on a single line
+func (c *Canvas) measuretext(s string, size float32) image.Point {
+	gtx := c.Context
+	gtx.Constraints = layout.Constraints{Max: image.Pt(unwrapped, c.Context.Constraints.Max.Y)}
+	key := textkey{s: s, size: size, width: -1, dir: c.direction(s), font: c.font()}
+	return c.cachedtext(key, gtx).size
+}
+
+// font returns the font text is drawn in: the canvas' typeface, in its text style
+func (c *Canvas) font() font.Font {
+	f := c.textstyle
+	f.Typeface = font.Typeface(c.Typeface)
+	return f
+}
+
+// unwrapped is the layout width for text kept on one line
+const unwrapped = 1 << 24
+
+// TextWidth returns the width of s at size, on one line, using percentage-based measures
+func (c *Canvas) TextWidth(s string, size float32) float32 {
+	w := c.measuretext(s, pct(size, c.Width)).X
+	return 100 * float32(w) / c.Width
+}
+
+// cachedtext returns the layout of the text described by key, laid out within
+// the constraints of gtx, making it if it is not in the cache
+func (c *Canvas) cachedtext(key textkey, gtx layout.Context) textentry {
+	textcache.Lock()
+	defer textcache.Unlock()
+	if textcache.theme == nil {
+		textcache.theme = material.NewTheme(append(gofont.Collection(), fonts...))
+	}
+	if textcache.layouts == nil || len(textcache.layouts) > maxtextlayouts {
+		textcache.layouts = make(map[textkey]textentry)
+	}
+	key.cw, key.ch, key.metric = c.Width, c.Height, gtx.Metric
+	if e, ok := textcache.layouts[key]; ok {
+		return e
+	}
+	gtx.Ops = new(op.Ops)
+	gtx.Locale.Direction = key.dir
+	l := material.Label(textcache.theme, unit.Sp(key.size), key.s)
+	l.Color = key.color
+	l.Font = key.font
+	l.Alignment = bidialign(key.alignment, key.dir)
+	m := op.Record(gtx.Ops)
+	dims := l.Layout(gtx)
+	e := textentry{call: m.Stop(), size: dims.Size}
+	textcache.layouts[key] = e
+	return e
+}
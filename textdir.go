@@ -0,0 +1,62 @@
+package giocanvas
+
+import (
+	"unicode"
+
+	"gioui.org/io/system"
+	"gioui.org/text"
+)
+
+// Text direction for complex scripts
+
+// TextDirection is the base direction of text on the canvas
+type TextDirection int
+
+// Text directions. TextAuto uses the first strongly directional character of each string.
+const (
+	TextAuto TextDirection = iota
+	TextLTR
+	TextRTL
+)
+
+// rtlscripts are the scripts written right to left
+var rtlscripts = []*unicode.RangeTable{unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko}
+
+// rtl reports whether s begins (in its first letter) with a right-to-left script
+func rtl(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return unicode.In(r, rtlscripts...)
+		}
+	}
+	return false
+}
+
+// direction returns the system direction for placing s
+func (c *Canvas) direction(s string) system.TextDirection {
+	switch c.TextDirection {
+	case TextRTL:
+		return system.RTL
+	case TextLTR:
+		return system.LTR
+	}
+	if rtl(s) {
+		return system.RTL
+	}
+	return system.LTR
+}
+
+// bidialign maps canvas alignment (Start is always the left edge)
+// to the alignment for text laid out in direction dir
+func bidialign(alignment text.Alignment, dir system.TextDirection) text.Alignment {
+	if dir != system.RTL {
+		return alignment
+	}
+	switch alignment {
+	case text.Start:
+		return text.End
+	case text.End:
+		return text.Start
+	}
+	return alignment
+}
@@ -0,0 +1,85 @@
+package giocanvas
+
+import (
+	"math"
+	"time"
+)
+
+// Tweening: easing functions, and values changing over time
+
+// Easing maps the fraction of time elapsed (0-1) to the fraction of change
+type Easing func(t float64) float64
+
+// Linear is constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseIn starts slowly
+func EaseIn(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOut ends slowly
+func EaseOut(t float64) float64 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut starts and ends slowly
+func EaseInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = -2*t + 2
+	return 1 - t*t*t/2
+}
+
+// EaseOutBack overshoots the end, then settles
+func EaseOutBack(t float64) float64 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	return 1 + c3*math.Pow(t-1, 3) + c1*math.Pow(t-1, 2)
+}
+
+// Tween is a change over Duration, beginning at Start, with an easing function
+// (Linear if nil)
+type Tween struct {
+	Start    time.Time
+	Duration time.Duration
+	Ease     Easing
+}
+
+// NewTween makes a tween starting now
+func NewTween(d time.Duration, ease Easing) *Tween {
+	return &Tween{Start: time.Now(), Duration: d, Ease: ease}
+}
+
+// At returns the eased progress of the tween at time now: 0 before the start, 1 after the end
+func (tw *Tween) At(now time.Time) float64 {
+	if tw.Duration <= 0 {
+		return 1
+	}
+	t := float64(now.Sub(tw.Start)) / float64(tw.Duration)
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	if tw.Ease == nil {
+		return t
+	}
+	return tw.Ease(t)
+}
+
+// Done reports whether the tween has finished at time now;
+// until it has, keep asking for frames (for example with Window.Invalidate)
+func (tw *Tween) Done(now time.Time) bool {
+	return now.Sub(tw.Start) >= tw.Duration
+}
+
+// Lerp interpolates between a and b, t between 0 and 1
+func Lerp(a, b, t float32) float32 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,188 @@
+package widgets
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// segments are the lit segments of seven-segment characters:
+// bits 0-6 are the top, upper right, lower right, bottom, lower left, upper left and middle
+var segments = map[rune]uint8{
+	'0': 0x3f, '1': 0x06, '2': 0x5b, '3': 0x4f, '4': 0x66,
+	'5': 0x6d, '6': 0x7d, '7': 0x07, '8': 0x7f, '9': 0x6f,
+	'-': 0x40, ' ': 0x00,
+}
+
+// Segments draws s with seven-segment digits, beginning at (x, y) (the lower left corner),
+// with digits size high (a percentage of the canvas width). Unlit segments are drawn
+// in dim, if it is not transparent. Digits, '-', ' ', ':' and '.' are shown.
+// Segments returns the x coordinate after the last character.
+func Segments(c *gc.Canvas, x, y, size float32, s string, lit, dim color.NRGBA) float32 {
+	h := size * c.Width / 100
+	w := h * 0.5
+	t := h * 0.1
+	ax, ay := c.AbsCoord(x, y)
+	for _, r := range s {
+		switch r {
+		case ':':
+			c.AbsRect(ax+t, ay-h*0.7, t, t, lit)
+			c.AbsRect(ax+t, ay-h*0.3, t, t, lit)
+			ax += t * 4
+			continue
+		case '.':
+			c.AbsRect(ax+t/2, ay-t, t, t, lit)
+			ax += t * 3
+			continue
+		}
+		bits, ok := segments[r]
+		if !ok {
+			bits = 0
+		}
+		mid := ay - h/2
+		// x, y, width, height of each segment, in order
+		seg := [7][4]float32{
+			{ax + t, ay - h, w - 2*t, t},
+			{ax + w - t, ay - h + t, t, h/2 - t*1.5},
+			{ax + w - t, mid + t/2, t, h/2 - t*1.5},
+			{ax + t, ay - t, w - 2*t, t},
+			{ax, mid + t/2, t, h/2 - t*1.5},
+			{ax, ay - h + t, t, h/2 - t*1.5},
+			{ax + t, mid - t/2, w - 2*t, t},
+		}
+		for i, sg := range seg {
+			col := dim
+			if bits&(1<<i) != 0 {
+				col = lit
+			}
+			if col.A > 0 {
+				c.AbsRect(sg[0], sg[1], sg[2], sg[3], col)
+			}
+		}
+		ax += w + t*1.5
+	}
+	px, _ := c.PctCoord(ax, ay)
+	return px
+}
+
+// SegmentsWidth returns the width of s drawn by Segments with digits size high
+func SegmentsWidth(size float32, s string) float32 {
+	var w float32
+	for _, r := range s {
+		switch r {
+		case ':':
+			w += 0.4
+		case '.':
+			w += 0.3
+		default:
+			w += 0.65
+		}
+	}
+	return w * size
+}
+
+// DigitalClock shows the time with seven-segment digits, beginning at (X, Y)
+type DigitalClock struct {
+	X, Y, Size float32
+	Color, Dim color.NRGBA
+	Seconds    bool
+}
+
+// Draw draws the clock showing time t
+func (d *DigitalClock) Draw(c *gc.Canvas, t time.Time) {
+	layout := "15:04"
+	if d.Seconds {
+		layout = "15:04:05"
+	}
+	Segments(c, d.X, d.Y, d.Size, t.Format(layout), d.Color, d.Dim)
+}
+
+// Countdown shows the time remaining until End, as minutes and seconds (or
+// hours, minutes and seconds), with seven-segment digits beginning at (X, Y).
+// In the last Warn of the countdown, it is drawn in WarnColor.
+type Countdown struct {
+	End        time.Time
+	X, Y, Size float32
+	Color, Dim color.NRGBA
+	Warn       time.Duration
+	WarnColor  color.NRGBA
+}
+
+// NewCountdown makes a countdown of d, starting now
+func NewCountdown(d time.Duration, x, y, size float32, fg color.NRGBA) *Countdown {
+	return &Countdown{End: time.Now().Add(d), X: x, Y: y, Size: size, Color: fg,
+		Warn: d / 10, WarnColor: color.NRGBA{200, 0, 0, 255}}
+}
+
+// Remaining returns the time left at now, rounded up to a second
+func (cd *Countdown) Remaining(now time.Time) time.Duration {
+	r := cd.End.Sub(now)
+	if r < 0 {
+		return 0
+	}
+	return (r + time.Second - 1).Truncate(time.Second)
+}
+
+// Done reports whether the countdown has finished
+func (cd *Countdown) Done(now time.Time) bool {
+	return !now.Before(cd.End)
+}
+
+// Text returns the time remaining at now as the countdown shows it
+func (cd *Countdown) Text(now time.Time) string {
+	s := int(cd.Remaining(now) / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, (s/60)%60, s%60)
+	}
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
+
+// Draw draws the countdown at now
+func (cd *Countdown) Draw(c *gc.Canvas, now time.Time) {
+	col := cd.Color
+	if cd.Remaining(now) <= cd.Warn {
+		col = cd.WarnColor
+	}
+	Segments(c, cd.X, cd.Y, cd.Size, cd.Text(now), col, cd.Dim)
+}
+
+// AnalogClock is a clock face centered at (X, Y), radius R, with hour and minute
+// hands, and a second hand if Second is not transparent
+type AnalogClock struct {
+	X, Y, R            float32
+	Face, Ticks, Hands color.NRGBA
+	Second             color.NRGBA
+}
+
+// Draw draws the clock showing time t
+func (a *AnalogClock) Draw(c *gc.Canvas, t time.Time) {
+	c.Circle(a.X, a.Y, a.R, a.Face)
+	for i := 0; i < 60; i++ {
+		angle := float32(math.Pi/2 - float64(i)*math.Pi/30)
+		inner, size := a.R*0.92, a.R*0.01
+		if i%5 == 0 {
+			inner, size = a.R*0.82, a.R*0.03
+		}
+		x1, y1 := c.Polar(a.X, a.Y, inner, angle)
+		x2, y2 := c.Polar(a.X, a.Y, a.R*0.97, angle)
+		c.Line(x1, y1, x2, y2, size, a.Ticks)
+	}
+	h, m, s := t.Clock()
+	sec := float64(s) + float64(t.Nanosecond())/1e9
+	min := float64(m) + sec/60
+	hour := float64(h%12) + min/60
+	hand := func(turns float64, length, size float32, col color.NRGBA) {
+		angle := float32(math.Pi/2 - turns*2*math.Pi)
+		x, y := c.Polar(a.X, a.Y, length, angle)
+		c.Line(a.X, a.Y, x, y, size, col)
+	}
+	hand(hour/12, a.R*0.5, a.R*0.06, a.Hands)
+	hand(min/60, a.R*0.75, a.R*0.04, a.Hands)
+	if a.Second.A > 0 {
+		hand(sec/60, a.R*0.85, a.R*0.015, a.Second)
+		c.Circle(a.X, a.Y, a.R*0.04, a.Second)
+	}
+}
@@ -0,0 +1,99 @@
+package widgets
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestCountdownText(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{90 * time.Second, "01:30"},
+		{89*time.Second + 200*time.Millisecond, "01:30"}, // rounded up
+		{time.Hour + time.Minute + time.Second, "1:01:01"},
+		{-time.Second, "00:00"},
+	}
+	for _, tc := range tests {
+		cd := &Countdown{End: now.Add(tc.left)}
+		if got := cd.Text(now); got != tc.want {
+			t.Errorf("%v left: got %q, want %q", tc.left, got, tc.want)
+		}
+	}
+}
+
+func TestCountdownWarn(t *testing.T) {
+	now := time.Now()
+	fg := color.NRGBA{0, 200, 0, 255}
+	cd := NewCountdown(100*time.Second, 10, 50, 10, fg)
+	colors := func(now time.Time) map[color.NRGBA]bool {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		cd.Draw(c, now)
+		m := make(map[color.NRGBA]bool)
+		for _, r := range log.Find("rect") {
+			m[r.Color] = true
+		}
+		return m
+	}
+	if m := colors(now); !m[fg] || m[cd.WarnColor] {
+		t.Errorf("with time left, drawn in %v, want %v", m, fg)
+	}
+	if m := colors(cd.End.Add(-5 * time.Second)); m[fg] || !m[cd.WarnColor] {
+		t.Errorf("in the last tenth, drawn in %v, want %v", m, cd.WarnColor)
+	}
+	if !cd.Done(cd.End) || cd.Done(cd.End.Add(-time.Millisecond)) {
+		t.Error("the countdown should be done at its end, and not before")
+	}
+}
+
+func TestSegments(t *testing.T) {
+	lit := color.NRGBA{255, 0, 0, 255}
+	tests := []struct {
+		s     string
+		rects int
+	}{
+		{"8", 7},
+		{"1", 2},
+		{"-", 1},
+		{"1:2.", 2 + 2 + 5 + 1},
+	}
+	for _, tc := range tests {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		end := Segments(c, 10, 50, 10, tc.s, lit, color.NRGBA{})
+		if n := len(log.Find("rect")); n != tc.rects {
+			t.Errorf("%q: %d segments lit, want %d", tc.s, n, tc.rects)
+		}
+		if w := SegmentsWidth(10, tc.s); math.Abs(float64(end-10-w)) > 1e-3 {
+			t.Errorf("%q: ends at %v, SegmentsWidth %v", tc.s, end, w)
+		}
+	}
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	Segments(c, 10, 50, 10, "1", lit, color.NRGBA{60, 0, 0, 255})
+	if n := len(log.Find("rect")); n != 7 {
+		t.Errorf("with unlit segments shown, %d drawn, want 7", n)
+	}
+}
+
+func TestAnalogClock(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	a := AnalogClock{X: 50, Y: 50, R: 20, Hands: color.NRGBA{0, 0, 0, 255}}
+	a.Draw(c, time.Date(2023, 6, 1, 15, 0, 0, 0, time.UTC))
+	lines := log.Find("line")
+	if len(lines) != 62 {
+		t.Fatalf("%d lines, want 60 ticks and 2 hands", len(lines))
+	}
+	near := func(a, b float32) bool { return math.Abs(float64(a-b)) < 0.01 }
+	// at three, the hour hand points right, and the minute hand up
+	if h := lines[60].Points; !near(h[2], 60) || !near(h[3], 50) {
+		t.Errorf("hour hand to (%v, %v), want (60, 50)", h[2], h[3])
+	}
+	if m := lines[61].Points; !near(m[2], 50) || !near(m[3], 65) {
+		t.Errorf("minute hand to (%v, %v), want (50, 65)", m[2], m[3])
+	}
+}
@@ -0,0 +1,154 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// ColorPicker chooses a color with a hue and saturation wheel centered at (X, Y),
+// radius R, with value and alpha sliders to its right. The color is Hue (0-360),
+// Saturation and Value (0-100) and Alpha.
+type ColorPicker struct {
+	X, Y, R                float32
+	Hue, Saturation, Value float64
+	Alpha                  uint8
+	dragging               int
+}
+
+// parts of the picker
+const (
+	pickNone = iota
+	pickWheel
+	pickValue
+	pickAlpha
+)
+
+// wheelrings is the number of saturation steps drawn on the wheel
+const wheelrings = 12
+
+// NewColorPicker makes a picker showing the color
+func NewColorPicker(x, y, r float32, col color.NRGBA) *ColorPicker {
+	p := &ColorPicker{X: x, Y: y, R: r}
+	p.SetColor(col)
+	return p
+}
+
+// Color returns the chosen color
+func (p *ColorPicker) Color() color.NRGBA {
+	return gc.HSV(p.Hue, p.Saturation, p.Value, p.Alpha)
+}
+
+// SetColor sets the chosen color
+func (p *ColorPicker) SetColor(col color.NRGBA) {
+	p.Hue, p.Saturation, p.Value = gc.ToHSV(col)
+	p.Alpha = col.A
+}
+
+// slider returns the box of the value (i = 0) or alpha (i = 1) slider
+func (p *ColorPicker) slider(c *gc.Canvas, i int) gc.Box {
+	h := 2 * p.R * c.Width / c.Height
+	return gc.Box{X: p.X + p.R*(1.25+0.35*float32(i)), Y: p.Y - h/2, W: p.R * 0.2, H: h}
+}
+
+// Draw draws the picker
+func (p *ColorPicker) Draw(c *gc.Canvas) {
+	// the wheel: rings of decreasing saturation toward the center
+	v := p.Value
+	for i := wheelrings; i > 0; i-- {
+		r := p.R * float32(i) / wheelrings
+		c.ConicGradient(p.X, p.Y, r, 0, gc.HueStops(100*float64(i)/wheelrings, v, 255))
+	}
+	// the hues sweep clockwise, as the canvas' angles do, and Polar's turn the other way
+	a := p.Hue * math.Pi / 180
+	cr := p.R * float32(p.Saturation/100)
+	mx, my := c.Polar(p.X, p.Y, cr, -float32(a))
+	c.Circle(mx, my, p.R*0.08, color.NRGBA{255, 255, 255, 255})
+	c.Circle(mx, my, p.R*0.05, p.Color())
+
+	full := gc.HSV(p.Hue, p.Saturation, 100, 255)
+	opaque := p.Color()
+	opaque.A = 255
+	clear := opaque
+	clear.A = 0
+	vb, ab := p.slider(c, 0), p.slider(c, 1)
+	c.BoxRect(ab, color.NRGBA{200, 200, 200, 255})
+	gradientbox(c, vb, color.NRGBA{0, 0, 0, 255}, full)
+	gradientbox(c, ab, clear, opaque)
+	p.marker(c, vb, float32(p.Value/100))
+	p.marker(c, ab, float32(p.Alpha)/255)
+}
+
+// marker shows the position (0-1, bottom to top) on a slider
+func (p *ColorPicker) marker(c *gc.Canvas, b gc.Box, at float32) {
+	h := 0.4 * c.Width / c.Height
+	frame(c, gc.Box{X: b.X - 0.3, Y: b.Y + at*b.H - h, W: b.W + 0.6, H: 2 * h}, 0.2, color.NRGBA{40, 40, 40, 255})
+}
+
+// gradientbox fills a box with a vertical gradient, from bottom to top
+func gradientbox(c *gc.Canvas, b gc.Box, bottom, top color.NRGBA) {
+	x0, y0 := c.AbsCoord(b.X, b.Y+b.H)
+	x1, y1 := c.AbsCoord(b.X+b.W, b.Y)
+	ops := c.Context.Ops
+	stack := clip.Rect(image.Rect(int(x0), int(y0), int(x1), int(y1))).Push(ops)
+	paint.LinearGradientOp{Stop1: f32.Pt(x0, y1), Color1: bottom, Stop2: f32.Pt(x0, y0), Color2: top}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+	stack.Pop()
+}
+
+// Input changes the color with presses and drags on the wheel or sliders,
+// reporting whether it changed
+func (p *ColorPicker) Input(c *gc.Canvas, e gc.PointerEvent) bool {
+	switch e.Type {
+	case pointer.Press:
+		p.dragging = p.part(c, e.X, e.Y)
+	case pointer.Drag:
+	case pointer.Release, pointer.Cancel:
+		p.dragging = pickNone
+		return false
+	default:
+		return false
+	}
+	switch p.dragging {
+	case pickWheel:
+		dx := float64(e.X - p.X)
+		dy := float64(e.Y-p.Y) * float64(c.Height/c.Width)
+		h := math.Atan2(-dy, dx) * 180 / math.Pi
+		if h < 0 {
+			h += 360
+		}
+		p.Hue = h
+		p.Saturation = math.Min(100, 100*math.Hypot(dx, dy)/float64(p.R))
+	case pickValue:
+		b := p.slider(c, 0)
+		p.Value = 100 * float64(clamp01((e.Y-b.Y)/b.H))
+	case pickAlpha:
+		b := p.slider(c, 1)
+		p.Alpha = uint8(255 * clamp01((e.Y-b.Y)/b.H))
+	default:
+		return false
+	}
+	return true
+}
+
+// part returns the part of the picker at (x, y)
+func (p *ColorPicker) part(c *gc.Canvas, x, y float32) int {
+	dx := float64(x - p.X)
+	dy := float64(y-p.Y) * float64(c.Height/c.Width)
+	if math.Hypot(dx, dy) <= float64(p.R) {
+		return pickWheel
+	}
+	for i, part := range []int{pickValue, pickAlpha} {
+		b := p.slider(c, i)
+		if x >= b.X && x <= b.X+b.W && y >= b.Y && y <= b.Y+b.H {
+			return part
+		}
+	}
+	return pickNone
+}
@@ -0,0 +1,70 @@
+package widgets
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestColorPickerWheel(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	p := NewColorPicker(30, 50, 20, color.NRGBA{255, 0, 0, 255})
+	tests := []struct {
+		x, y     float32
+		hue, sat float64
+	}{
+		{40, 50, 0, 50},    // right of the center, halfway out
+		{30, 30, 90, 50},   // below it: the hues turn clockwise, as the wheel's gradient does
+		{20, 50, 180, 50},  // left
+		{30, 90, 270, 100}, // above, at the edge
+	}
+	for _, tc := range tests {
+		if !p.Input(c, gc.PointerEvent{Type: pointer.Press, X: tc.x, Y: tc.y}) {
+			t.Errorf("press at (%v, %v) did not change the color", tc.x, tc.y)
+		}
+		if math.Abs(p.Hue-tc.hue) > 1e-3 || math.Abs(p.Saturation-tc.sat) > 1e-3 {
+			t.Errorf("press at (%v, %v): hue %v, saturation %v; want %v, %v", tc.x, tc.y, p.Hue, p.Saturation, tc.hue, tc.sat)
+		}
+		p.Input(c, gc.PointerEvent{Type: pointer.Release, X: tc.x, Y: tc.y})
+	}
+
+	// the marker is drawn where the wheel was pressed
+	p.Input(c, gc.PointerEvent{Type: pointer.Press, X: 30, Y: 30})
+	p.Draw(c)
+	if n := len(log.Find("conic")); n != wheelrings {
+		t.Errorf("%d rings of the wheel drawn, want %d", n, wheelrings)
+	}
+	m := log.Find("circle")
+	if len(m) != 2 || math.Abs(float64(m[0].Points[0]-30)) > 1e-3 || math.Abs(float64(m[0].Points[1]-30)) > 1e-3 {
+		t.Errorf("marker %v, want it at (30, 30)", m)
+	}
+}
+
+func TestColorPickerSliders(t *testing.T) {
+	c, _ := gc.NewRecordingCanvas(1000, 500)
+	p := NewColorPicker(30, 50, 20, color.NRGBA{255, 0, 0, 255})
+	v, a := p.slider(c, 0), p.slider(c, 1)
+	p.Input(c, gc.PointerEvent{Type: pointer.Press, X: v.X + v.W/2, Y: v.Y + v.H/4})
+	p.Input(c, gc.PointerEvent{Type: pointer.Drag, X: v.X + v.W/2, Y: v.Y + v.H*2}) // above the slider
+	if p.Value != 100 {
+		t.Errorf("value dragged above the slider: %v, want 100", p.Value)
+	}
+	p.Input(c, gc.PointerEvent{Type: pointer.Release})
+	p.Input(c, gc.PointerEvent{Type: pointer.Press, X: a.X + a.W/2, Y: a.Y})
+	if p.Alpha != 0 || p.Value != 100 {
+		t.Errorf("alpha pressed at the bottom: %v (value %v), want 0 (100)", p.Alpha, p.Value)
+	}
+	p.Input(c, gc.PointerEvent{Type: pointer.Release})
+	if p.Input(c, gc.PointerEvent{Type: pointer.Drag, X: a.X, Y: a.Y + a.H}) {
+		t.Error("a drag after the release changed the color")
+	}
+	if p.Input(c, gc.PointerEvent{Type: pointer.Press, X: 95, Y: 5}) {
+		t.Error("a press outside the picker changed the color")
+	}
+	if got := p.Color(); got != (color.NRGBA{255, 0, 0, 0}) {
+		t.Errorf("color %v, want transparent red", got)
+	}
+}
@@ -0,0 +1,92 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/io/pointer"
+	"gioui.org/op/clip"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Compare shows two images of the same scene, Before and After, centered at (X, Y) and
+// scaled by Scale (percent): Before is shown left of a divider at Position (0-1 across
+// the image), After to its right. Dragging the divider moves it.
+type Compare struct {
+	Before, After string
+	X, Y, Scale   float32
+	Position      float32
+	Color         color.NRGBA
+	bounds        gc.Box
+	dragging      bool
+}
+
+// NewCompare makes a comparison of two named images, with the divider in the middle
+func NewCompare(before, after string, x, y, scale float32) *Compare {
+	return &Compare{Before: before, After: after, X: x, Y: y, Scale: scale, Position: 0.5, Color: color.NRGBA{255, 255, 255, 255}}
+}
+
+// Draw draws the images and the divider
+func (cm *Compare) Draw(c *gc.Canvas) {
+	size, err := gc.ImageSize(cm.Before)
+	if err != nil {
+		return
+	}
+	w := float32(size.X) * cm.Scale / 100
+	h := float32(size.Y) * cm.Scale / 100
+	cx, cy := c.AbsCoord(cm.X, cm.Y)
+	left, top := cx-w/2, cy-h/2
+	pw, ph := w*100/c.Width, h*100/c.Height
+	cm.bounds = gc.Box{X: cm.X - pw/2, Y: cm.Y - ph/2, W: pw, H: ph}
+
+	divx := left + w*clamp01(cm.Position)
+	half := func(name string, r image.Rectangle) {
+		stack := clip.Rect(r).Push(c.Context.Ops)
+		c.AbsCenterImage(name, cx, cy, 0, 0, cm.Scale)
+		stack.Pop()
+	}
+	half(cm.Before, image.Rect(int(left), int(top), int(divx), int(top+h)))
+	half(cm.After, image.Rect(int(divx), int(top), int(left+w), int(top+h)))
+
+	lw := w * 0.004
+	if lw < 2 {
+		lw = 2
+	}
+	c.AbsRect(divx-lw/2, top, lw, h, cm.Color)
+	c.AbsCircle(divx, cy, lw*5, cm.Color)
+}
+
+// Input moves the divider with pointer presses and drags on the images,
+// reporting whether it moved
+func (cm *Compare) Input(p gc.PointerEvent) bool {
+	b := cm.bounds
+	switch p.Type {
+	case pointer.Press:
+		if b.W <= 0 || p.X < b.X || p.X > b.X+b.W || p.Y < b.Y || p.Y > b.Y+b.H {
+			return false
+		}
+		cm.dragging = true
+	case pointer.Drag:
+		if !cm.dragging {
+			return false
+		}
+	case pointer.Release, pointer.Cancel:
+		cm.dragging = false
+		return false
+	default:
+		return false
+	}
+	cm.Position = clamp01((p.X - b.X) / b.W)
+	return true
+}
+
+// clamp01 limits v to the range 0-1
+func clamp01(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// writeimage writes a png file, w by h, of one color, in the test's temporary directory
+func writeimage(t *testing.T, name string, w, h int, col color.NRGBA) string {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = col.R, col.G, col.B, col.A
+	}
+	name = filepath.Join(t.TempDir(), name)
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestCompare(t *testing.T) {
+	before := writeimage(t, "before.png", 400, 200, color.NRGBA{255, 0, 0, 255})
+	after := writeimage(t, "after.png", 400, 200, color.NRGBA{0, 0, 255, 255})
+	cm := NewCompare(before, after, 50, 50, 100)
+	if cm.Input(gc.PointerEvent{Type: pointer.Press, X: 50, Y: 50}) {
+		t.Error("the divider moved before the images were drawn")
+	}
+
+	// 400 by 200 pixels on a 1000 by 500 canvas: 40 by 40 percent, from 30 to 70 across
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	cm.Draw(c)
+	if n := len(log.Find("image")); n != 2 {
+		t.Errorf("%d images drawn, want before and after", n)
+	}
+	if d := log.Find("circle"); len(d) != 1 || d[0].Points[0] != 50 {
+		t.Errorf("divider %v, want it in the middle, at 50", d)
+	}
+
+	tests := []struct {
+		typ   pointer.Type
+		x, y  float32
+		moved bool
+		at    float32
+	}{
+		{pointer.Press, 80, 50, false, 0.5}, // beside the images
+		{pointer.Drag, 80, 50, false, 0.5},
+		{pointer.Press, 40, 50, true, 0.25},
+		{pointer.Drag, 90, 10, true, 1}, // dragged past the images, to the edge
+		{pointer.Release, 60, 50, false, 1},
+		{pointer.Drag, 60, 50, false, 1},
+	}
+	for _, tc := range tests {
+		moved := cm.Input(gc.PointerEvent{Type: tc.typ, X: tc.x, Y: tc.y})
+		if moved != tc.moved || cm.Position != tc.at {
+			t.Errorf("%v at (%v, %v): moved %v, to %v; want %v, %v", tc.typ, tc.x, tc.y, moved, cm.Position, tc.moved, tc.at)
+		}
+	}
+
+	cm.Position = 0.25
+	c, log = gc.NewRecordingCanvas(1000, 500)
+	cm.Draw(c)
+	if d := log.Find("circle"); len(d) != 1 || d[0].Points[0] != 40 {
+		t.Errorf("divider %v, want it a quarter across, at 40", d)
+	}
+}
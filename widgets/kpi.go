@@ -0,0 +1,113 @@
+package widgets
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// The colors of changes for the better and worse
+var (
+	kpigood = color.NRGBA{0, 150, 80, 255}
+	kpibad  = color.NRGBA{210, 50, 50, 255}
+)
+
+// KPI is a dashboard card for a key figure: its Label, its Value in large type, its change
+// (Delta) with an arrow, green for the better and red for the worse, and a sparkline of its
+// History. A rise is for the better unless LowerBetter. Change is the change as shown,
+// such as "+4.2%"; if empty, Delta is shown.
+type KPI struct {
+	Label, Value      string
+	Delta             float64
+	Change            string
+	LowerBetter       bool
+	History           []float64
+	Color, Background color.NRGBA
+}
+
+// Draw draws the card centered at (x, y), w by h, with percentage-based measures
+func (k KPI) Draw(c *gc.Canvas, x, y, w, h float32) {
+	cx, cy := c.AbsCoord(x, y)
+	w, h = w*c.Width/100, h*c.Height/100
+	left, top := cx-w/2, cy-h/2
+	if k.Background.A > 0 {
+		c.AbsRoundedRect(left, top, w, h, h*0.06, k.Background)
+	}
+	pad := h * 0.08
+	inner := w - 2*pad
+	// absolute text width, and a size at which s fits the card
+	width := func(s string, size float32) float32 {
+		return c.TextWidth(s, size*100/c.Width) * c.Width / 100
+	}
+	fit := func(s string, size float32) float32 {
+		if tw := width(s, size); tw > inner {
+			size *= inner / tw
+		}
+		return size
+	}
+
+	muted := k.Color
+	muted.A = uint8(uint32(k.Color.A) * 160 / 255)
+	ls := fit(k.Label, h*0.1)
+	base := top + pad + ls
+	c.AbsText(left+pad, base, ls, k.Label, muted)
+	vs := fit(k.Value, h*0.28)
+	base += vs*1.2 + ls*0.2
+	c.AbsText(left+pad, base, vs, k.Value, k.Color)
+
+	change := k.Change
+	if change == "" {
+		change = fmt.Sprintf("%+g", k.Delta)
+	}
+	col := muted
+	switch {
+	case k.Delta == 0:
+	case (k.Delta > 0) != k.LowerBetter:
+		col = kpigood
+		col.A = k.Color.A
+	default:
+		col = kpibad
+		col.A = k.Color.A
+	}
+	ds := h * 0.1
+	base += ds * 1.6
+	tx := left + pad
+	if k.Delta != 0 {
+		aw := ds * 0.9
+		ay := []float32{base - ds*0.8, base, base - ds*0.8}
+		if k.Delta > 0 {
+			ay = []float32{base, base - ds*0.8, base}
+		}
+		c.AbsPolygon([]float32{tx, tx + aw/2, tx + aw}, ay, col)
+		tx += aw + ds*0.4
+	}
+	c.AbsText(tx, base, ds, change, col)
+
+	k.sparkline(c, left+pad, base+ds*0.8, inner, top+h-pad-(base+ds*0.8), h*0.02, col)
+}
+
+// sparkline draws the history in the box with upper left corner at (x, y), w by h,
+// scaled to the range of the values, marking the latest
+func (k KPI) sparkline(c *gc.Canvas, x, y, w, h, size float32, col color.NRGBA) {
+	n := len(k.History)
+	if n < 2 || h <= 0 {
+		return
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range k.History {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	span := hi - lo
+	if span == 0 {
+		span = 1
+	}
+	px, py := make([]float32, n), make([]float32, n)
+	for i, v := range k.History {
+		px[i] = x + w*float32(i)/float32(n-1)
+		py[i] = y + h - h*float32((v-lo)/span)
+	}
+	c.AbsPolyline(px, py, size, col)
+	c.AbsCircle(px[n-1], py[n-1], size*1.5, col)
+}
@@ -0,0 +1,77 @@
+package widgets
+
+import (
+	"image/color"
+	"testing"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestKPIChange(t *testing.T) {
+	white := color.NRGBA{255, 255, 255, 255}
+	tests := []struct {
+		name   string
+		k      KPI
+		change string
+		col    color.NRGBA
+		arrow  bool
+	}{
+		{"rise", KPI{Delta: 4.2}, "+4.2", kpigood, true},
+		{"fall", KPI{Delta: -3, Change: "-3%"}, "-3%", kpibad, true},
+		{"rise, lower better", KPI{Delta: 2, LowerBetter: true}, "+2", kpibad, true},
+		{"fall, lower better", KPI{Delta: -2, LowerBetter: true}, "-2", kpigood, true},
+		{"no change", KPI{}, "+0", color.NRGBA{255, 255, 255, 160}, false},
+	}
+	for _, tc := range tests {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		tc.k.Label, tc.k.Value, tc.k.Color = "Revenue", "$1.2M", white
+		tc.k.Draw(c, 50, 50, 30, 30)
+		text := log.Find("text")
+		if len(text) != 3 || text[0].Text != "Revenue" || text[1].Text != "$1.2M" {
+			t.Errorf("%s: texts %v, want the label, value and change", tc.name, text)
+			continue
+		}
+		if ch := text[2]; ch.Text != tc.change || ch.Color != tc.col {
+			t.Errorf("%s: change %q in %v, want %q in %v", tc.name, ch.Text, ch.Color, tc.change, tc.col)
+		}
+		if arrow := len(log.Find("polygon")) == 1; arrow != tc.arrow {
+			t.Errorf("%s: arrow drawn: %v, want %v", tc.name, arrow, tc.arrow)
+		}
+	}
+}
+
+func TestKPIFit(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	k := KPI{Label: "Monthly recurring revenue, all regions", Value: "$123,456,789", Color: color.NRGBA{255, 255, 255, 255}}
+	k.Draw(c, 50, 50, 20, 30)
+	for _, tx := range log.Find("text") {
+		if right := tx.Points[0] + c.TextWidth(tx.Text, tx.Size); right > 60 {
+			t.Errorf("%q runs to %v, past the card's right side at 60", tx.Text, right)
+		}
+	}
+}
+
+func TestKPISparkline(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	k := KPI{Label: "Users", Value: "1,024", Delta: 24, History: []float64{3, 1, 4, 1, 5}, Color: color.NRGBA{255, 255, 255, 255}}
+	k.Draw(c, 50, 50, 30, 30)
+	lines, marks := log.Find("polyline"), log.Find("circle")
+	if len(lines) != 1 || len(lines[0].Points) != 10 {
+		t.Fatalf("sparkline %v, want one of 5 points", lines)
+	}
+	pts := lines[0].Points
+	// the highest value is highest, the lowest lowest, and the latest marked
+	if pts[9] <= pts[5] || pts[3] != pts[7] || pts[3] >= pts[1] {
+		t.Errorf("sparkline points %v are not scaled to the values", pts)
+	}
+	if len(marks) != 1 || marks[0].Points[0] != pts[8] || marks[0].Points[1] != pts[9] {
+		t.Errorf("marks %v, want the latest point", marks)
+	}
+
+	c, log = gc.NewRecordingCanvas(1000, 500)
+	k.History = k.History[:1]
+	k.Draw(c, 50, 50, 30, 30)
+	if n := len(log.Find("polyline")); n != 0 {
+		t.Errorf("%d sparklines of a single value, want none", n)
+	}
+}
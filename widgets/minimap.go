@@ -0,0 +1,77 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Minimap is an overview inset: the whole scene drawn small in Box, with a frame
+// around View, the part of the scene shown on the canvas (both percentage-based,
+// View in the coordinates of the scene). Pressing or dragging on the minimap
+// centers the view there.
+type Minimap struct {
+	Box        gc.Box
+	View       gc.Box
+	Background color.NRGBA
+	Frame      color.NRGBA
+	dragging   bool
+}
+
+// NewMinimap makes a minimap in box, with the view covering the whole scene
+func NewMinimap(box gc.Box) *Minimap {
+	return &Minimap{Box: box, View: gc.Page, Background: color.NRGBA{255, 255, 255, 200}, Frame: color.NRGBA{200, 0, 0, 255}}
+}
+
+// Draw draws the minimap, calling scene to draw the scene scaled into the inset
+func (m *Minimap) Draw(c *gc.Canvas, scene func(*gc.Canvas)) {
+	b := m.Box
+	c.BoxRect(b, m.Background)
+	left, top := c.AbsCoord(b.X, b.Y+b.H)
+	right, bottom := c.AbsCoord(b.X+b.W, b.Y)
+	cl := clip.Rect(image.Rect(int(left), int(top), int(right), int(bottom))).Push(c.Context.Ops)
+	tr := f32.Affine2D{}.Scale(f32.Pt(0, 0), f32.Pt(b.W/100, b.H/100)).Offset(f32.Pt(left, top))
+	stack := op.Affine(tr).Push(c.Context.Ops)
+	scene(c)
+	stack.Pop()
+	cl.Pop()
+
+	// the frame around the view, in the minimap's coordinates
+	v := m.View
+	frame(c, gc.Box{X: b.X + v.X*b.W/100, Y: b.Y + v.Y*b.H/100, W: v.W * b.W / 100, H: v.H * b.H / 100}, 0.2, m.Frame)
+}
+
+// Input centers the view where the minimap is pressed or dragged, reporting whether it moved
+func (m *Minimap) Input(p gc.PointerEvent) bool {
+	b := m.Box
+	switch p.Type {
+	case pointer.Press:
+		if p.X < b.X || p.X > b.X+b.W || p.Y < b.Y || p.Y > b.Y+b.H {
+			return false
+		}
+		m.dragging = true
+	case pointer.Drag:
+		if !m.dragging {
+			return false
+		}
+	case pointer.Release, pointer.Cancel:
+		m.dragging = false
+		return false
+	default:
+		return false
+	}
+	x, y := m.At(p.X, p.Y)
+	m.View.X, m.View.Y = x-m.View.W/2, y-m.View.H/2
+	return true
+}
+
+// At converts a point on the minimap to the coordinates of the scene
+func (m *Minimap) At(x, y float32) (float32, float32) {
+	b := m.Box
+	return (x - b.X) * 100 / b.W, (y - b.Y) * 100 / b.H
+}
@@ -0,0 +1,59 @@
+package widgets
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestMinimap(t *testing.T) {
+	m := NewMinimap(gc.Box{X: 70, Y: 5, W: 25, H: 20})
+	if x, y := m.At(82.5, 15); x != 50 || y != 50 {
+		t.Errorf("the middle of the minimap is (%v, %v) in the scene, want (50, 50)", x, y)
+	}
+	m.View = gc.Box{X: 50, Y: 50, W: 50, H: 50}
+
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	scenes := 0
+	m.Draw(c, func(c *gc.Canvas) {
+		scenes++
+		c.Circle(50, 50, 10, color.NRGBA{0, 0, 0, 255})
+	})
+	if scenes != 1 {
+		t.Errorf("the scene was drawn %d times, want once", scenes)
+	}
+	// the background, then the frame around the view: the upper right quarter of the minimap
+	rects := log.Find("rect")
+	if len(rects) != 5 || rects[0].Points[0] != 70 || rects[0].W != 25 {
+		t.Fatalf("rects %v, want the background and four sides of the frame", rects)
+	}
+	if top := rects[1]; math.Abs(float64(top.Points[0]-82.5)) > 1e-3 || math.Abs(float64(top.W-12.5)) > 1e-3 {
+		t.Errorf("the frame's side %v, want from 82.5, 12.5 wide", top)
+	}
+
+	tests := []struct {
+		typ   pointer.Type
+		x, y  float32
+		moved bool
+		view  gc.Box
+	}{
+		{pointer.Press, 50, 50, false, gc.Box{X: 50, Y: 50, W: 50, H: 50}}, // off the minimap
+		{pointer.Drag, 50, 50, false, gc.Box{X: 50, Y: 50, W: 50, H: 50}},
+		{pointer.Press, 75, 10, true, gc.Box{X: -5, Y: 0, W: 50, H: 50}},
+		{pointer.Drag, 82.5, 15, true, gc.Box{X: 25, Y: 25, W: 50, H: 50}},
+		{pointer.Release, 90, 20, false, gc.Box{X: 25, Y: 25, W: 50, H: 50}},
+		{pointer.Drag, 90, 20, false, gc.Box{X: 25, Y: 25, W: 50, H: 50}},
+	}
+	near := func(a, b gc.Box) bool {
+		return math.Abs(float64(a.X-b.X)) < 1e-3 && math.Abs(float64(a.Y-b.Y)) < 1e-3 && a.W == b.W && a.H == b.H
+	}
+	for _, tc := range tests {
+		moved := m.Input(gc.PointerEvent{Type: tc.typ, X: tc.x, Y: tc.y})
+		if moved != tc.moved || !near(m.View, tc.view) {
+			t.Errorf("%v at (%v, %v): moved %v, the view to %v; want %v, %v", tc.typ, tc.x, tc.y, moved, m.View, tc.moved, tc.view)
+		}
+	}
+}
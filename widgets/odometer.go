@@ -0,0 +1,149 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Odometer shows a whole number whose digits roll to a new value when it is Set, as the
+// wheels of an odometer, or, with Flaps, flip through the digits between, as a split-flap
+// display. The lower left of the display is at (X, Y); digits are Size high (a percentage
+// of the canvas width), at least Digits of them, each in a cell of Background.
+// A change takes Duration.
+type Odometer struct {
+	X, Y, Size        float32
+	Digits            int
+	Color, Background color.NRGBA
+	Flaps             bool
+	Duration          time.Duration
+	from, to          int64
+	changed           time.Time
+}
+
+// NewOdometer makes an odometer showing value
+func NewOdometer(x, y, size float32, value int64, textcolor, background color.NRGBA) *Odometer {
+	return &Odometer{X: x, Y: y, Size: size, Color: textcolor, Background: background, Duration: time.Second, from: value, to: value}
+}
+
+// Value returns the value the odometer shows, or is changing to
+func (o *Odometer) Value() int64 {
+	return o.to
+}
+
+// Set changes the value shown, from the value shown at now; values below zero are shown as zero
+func (o *Odometer) Set(value int64, now time.Time) {
+	if value < 0 {
+		value = 0
+	}
+	if value == o.to {
+		return
+	}
+	o.from, o.to, o.changed = o.to, value, now
+}
+
+// Active reports whether the digits are moving at now; while they are, keep drawing frames
+func (o *Odometer) Active(now time.Time) bool {
+	return o.from != o.to && now.Sub(o.changed) < o.Duration
+}
+
+// columns returns the digits of the old and new values, most significant first,
+// padded with zeros to the same length, at least Digits long
+func (o *Odometer) columns() ([]byte, []byte) {
+	a, b := strconv.FormatInt(o.from, 10), strconv.FormatInt(o.to, 10)
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	if o.Digits > n {
+		n = o.Digits
+	}
+	pad := func(s string) []byte {
+		p := make([]byte, n)
+		for i := range p {
+			p[i] = '0'
+		}
+		copy(p[n-len(s):], s)
+		return p
+	}
+	return pad(a), pad(b)
+}
+
+// Draw draws the odometer at the time now, returning the x coordinate after the last digit
+func (o *Odometer) Draw(c *gc.Canvas, now time.Time) float32 {
+	p := 1.0
+	if o.Active(now) {
+		tw := gc.Tween{Start: o.changed, Duration: o.Duration, Ease: gc.EaseInOut}
+		p = tw.At(now)
+	}
+	size := o.Size * c.Width / 100
+	cw, ch := size*0.8, size*1.4
+	gap := size * 0.1
+	ax, bottom := c.AbsCoord(o.X, o.Y)
+	from, to := o.columns()
+	for i := range to {
+		// the column's position, in digits: up through the digits when the value rises, down when it falls
+		df, dt := int(from[i]-'0'), int(to[i]-'0')
+		delta := (dt - df + 10) % 10
+		if o.to < o.from {
+			delta = -((df - dt + 10) % 10)
+		}
+		q := float64(df) + float64(delta)*p
+		lower := math.Floor(q)
+		a, b := int(lower+10)%10, int(lower+11)%10
+		cell := image.Rect(int(ax), int(bottom-ch), int(ax+cw), int(bottom))
+		if o.Background.A > 0 {
+			c.AbsRoundedRect(ax, bottom-ch, cw, ch, size*0.1, o.Background)
+		}
+		stack := clip.Rect(cell).Push(c.Context.Ops)
+		if o.Flaps {
+			o.flap(c, ax, bottom, cw, ch, size, a, b, float32(q-lower))
+		} else {
+			shift := float32(q-lower) * ch
+			base := bottom - (ch-size)/2 - size*0.1
+			c.AbsTextMid(ax+cw/2, base-shift, size, strconv.Itoa(a), o.Color)
+			c.AbsTextMid(ax+cw/2, base-shift+ch, size, strconv.Itoa(b), o.Color)
+		}
+		stack.Pop()
+		ax += cw + gap
+	}
+	return ax * 100 / c.Width
+}
+
+// flap draws a split-flap cell flipping from digit a to b, f of the way (0-1): the top
+// flap, showing a, falls about the middle, revealing b, and lands showing the bottom of b
+func (o *Odometer) flap(c *gc.Canvas, x, bottom, w, h, size float32, a, b int, f float32) {
+	ops := c.Context.Ops
+	mid := bottom - h/2
+	base := bottom - (h-size)/2 - size*0.1
+	half := func(digit int, top bool, scale float32) {
+		r := image.Rect(int(x), int(mid), int(x+w), int(bottom))
+		if top {
+			r = image.Rect(int(x), int(bottom-h), int(x+w), int(mid))
+		}
+		stack := clip.Rect(r).Push(ops)
+		t := op.Affine(f32.Affine2D{}.Scale(f32.Pt(x, mid), f32.Pt(1, scale))).Push(ops)
+		c.AbsTextMid(x+w/2, base, size, strconv.Itoa(digit), o.Color)
+		t.Pop()
+		stack.Pop()
+	}
+	half(b, true, 1)
+	half(a, false, 1)
+	if f < 0.5 {
+		half(a, true, 1-2*f)
+	} else {
+		half(b, false, 2*f-1)
+	}
+	// the split
+	line := o.Background
+	line.R, line.G, line.B = line.R/2, line.G/2, line.B/2
+	line.A = 255
+	c.AbsRect(x, mid-size*0.02, w, size*0.04, line)
+}
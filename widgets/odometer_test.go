@@ -0,0 +1,91 @@
+package widgets
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// odometerdigits draws the odometer at now, returning the two digits drawn in each column,
+// the upper first
+func odometerdigits(o *Odometer, now time.Time) [][2]gc.DrawCall {
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	o.Draw(c, now)
+	calls := log.Find("textmid")
+	cols := make([][2]gc.DrawCall, len(calls)/2)
+	for i := range cols {
+		cols[i] = [2]gc.DrawCall{calls[2*i], calls[2*i+1]}
+	}
+	return cols
+}
+
+func TestOdometerPadding(t *testing.T) {
+	now := time.Now()
+	o := NewOdometer(10, 50, 5, 42, color.NRGBA{255, 255, 255, 255}, color.NRGBA{})
+	o.Digits = 4
+	var shown string
+	for _, col := range odometerdigits(o, now) {
+		shown += col[0].Text
+	}
+	if shown != "0042" {
+		t.Errorf("showing %q, want 0042", shown)
+	}
+	o.Set(-5, now)
+	if o.Value() != 0 {
+		t.Errorf("set below zero: value %d, want 0", o.Value())
+	}
+	if !o.Active(now) || o.Active(now.Add(o.Duration)) {
+		t.Error("the odometer should be active for the duration of a change, and only then")
+	}
+}
+
+func TestOdometerDirection(t *testing.T) {
+	white := color.NRGBA{255, 255, 255, 255}
+	start := time.Now()
+	rest := odometerdigits(NewOdometer(10, 50, 5, 9, white, color.NRGBA{}), start)[0][0].Points[1]
+	tests := []struct {
+		name     string
+		from, to int64
+		above    bool // the new digit comes from above the old
+	}{
+		{"rising", 9, 10, false},
+		{"falling", 10, 9, true},
+	}
+	for _, tc := range tests {
+		o := NewOdometer(10, 50, 5, tc.from, white, color.NRGBA{})
+		o.Set(tc.to, start)
+		cols := odometerdigits(o, start.Add(o.Duration/100))
+		last := cols[len(cols)-1]
+		// 9 rolls on to 0, and 0 back to 9, by one place
+		if last[0].Text != "9" || last[1].Text != "0" {
+			t.Errorf("%s: last column shows %q and %q, want 9 and 0", tc.name, last[0].Text, last[1].Text)
+			continue
+		}
+		old, new := last[0], last[1]
+		if tc.from > tc.to {
+			old, new = new, old
+		}
+		if d := old.Points[1] - rest; d < -0.5 || d > 0.5 {
+			t.Errorf("%s: the old digit has moved to %v, from %v, at the start", tc.name, old.Points[1], rest)
+		}
+		if above := new.Points[1] > old.Points[1]; above != tc.above {
+			t.Errorf("%s: the new digit at %v, the old at %v; want it above: %v", tc.name, new.Points[1], old.Points[1], tc.above)
+		}
+	}
+}
+
+func TestOdometerWrap(t *testing.T) {
+	start := time.Now()
+	o := NewOdometer(10, 50, 5, 19, color.NRGBA{255, 255, 255, 255}, color.NRGBA{})
+	o.Set(20, start)
+	cols := odometerdigits(o, start.Add(o.Duration/2))
+	// halfway, the units wheel is between 9 and 0, not rolling back through 8 to 1
+	if u := cols[1]; u[0].Text != "9" || u[1].Text != "0" {
+		t.Errorf("units halfway from 19 to 20 show %q and %q, want 9 and 0", u[0].Text, u[1].Text)
+	}
+	if tens := cols[0]; tens[0].Text != "1" || tens[1].Text != "2" {
+		t.Errorf("tens halfway from 19 to 20 show %q and %q, want 1 and 2", tens[0].Text, tens[1].Text)
+	}
+}
@@ -0,0 +1,124 @@
+package widgets
+
+import (
+	"image/color"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Selection is a rubber-band rectangle, dragged out with the pointer, for choosing
+// a region of the canvas (for example, to export with capture.WritePNGRegion)
+type Selection struct {
+	Color, Fill color.NRGBA
+	x0, y0      float32
+	x1, y1      float32
+	selecting   bool
+	done        bool
+}
+
+// NewSelection makes a selection outlined in the color, and filled with a translucent version of it
+func NewSelection(outline color.NRGBA) *Selection {
+	fill := outline
+	fill.A = 48
+	return &Selection{Color: outline, Fill: fill}
+}
+
+// Input follows a press, drag and release, reporting whether the selection changed
+func (s *Selection) Input(p gc.PointerEvent) bool {
+	switch p.Type {
+	case pointer.Press:
+		s.x0, s.y0, s.x1, s.y1 = p.X, p.Y, p.X, p.Y
+		s.selecting = true
+		s.done = false
+	case pointer.Drag:
+		if !s.selecting {
+			return false
+		}
+		s.x1, s.y1 = p.X, p.Y
+	case pointer.Release:
+		if !s.selecting {
+			return false
+		}
+		s.x1, s.y1 = p.X, p.Y
+		s.selecting = false
+		b := s.Box()
+		s.done = b.W > 0 && b.H > 0
+	case pointer.Cancel:
+		s.Clear()
+	default:
+		return false
+	}
+	return true
+}
+
+// Selecting reports whether a selection is being dragged out
+func (s *Selection) Selecting() bool {
+	return s.selecting
+}
+
+// Box returns the selected rectangle, clipped to the canvas
+func (s *Selection) Box() gc.Box {
+	x0, x1 := clamp100(min32(s.x0, s.x1)), clamp100(max32(s.x0, s.x1))
+	y0, y1 := clamp100(min32(s.y0, s.y1)), clamp100(max32(s.y0, s.y1))
+	return gc.Box{X: x0, Y: y0, W: x1 - x0, H: y1 - y0}
+}
+
+// Done returns the selected rectangle once, after the pointer is released;
+// ok is false while selecting, or if nothing was selected
+func (s *Selection) Done() (b gc.Box, ok bool) {
+	if !s.done {
+		return gc.Box{}, false
+	}
+	s.done = false
+	return s.Box(), true
+}
+
+// Clear removes the selection
+func (s *Selection) Clear() {
+	*s = Selection{Color: s.Color, Fill: s.Fill}
+}
+
+// Draw draws the selection while it is being dragged out
+func (s *Selection) Draw(c *gc.Canvas) {
+	if !s.selecting {
+		return
+	}
+	b := s.Box()
+	c.BoxRect(b, s.Fill)
+	frame(c, b, 0.15, s.Color)
+}
+
+// frame outlines a box with lines lw wide (a percentage of the canvas width)
+func frame(c *gc.Canvas, b gc.Box, lw float32, col color.NRGBA) {
+	lh := lw * c.Width / c.Height
+	c.BoxRect(gc.Box{X: b.X, Y: b.Y, W: b.W, H: lh}, col)
+	c.BoxRect(gc.Box{X: b.X, Y: b.Y + b.H - lh, W: b.W, H: lh}, col)
+	c.BoxRect(gc.Box{X: b.X, Y: b.Y, W: lw, H: b.H}, col)
+	c.BoxRect(gc.Box{X: b.X + b.W - lw, Y: b.Y, W: lw, H: b.H}, col)
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// clamp100 limits v to the range 0-100
+func clamp100(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
@@ -0,0 +1,55 @@
+package widgets
+
+import (
+	"image/color"
+	"testing"
+
+	"gioui.org/io/pointer"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestSelection(t *testing.T) {
+	s := NewSelection(color.NRGBA{0, 120, 255, 255})
+	s.Input(gc.PointerEvent{Type: pointer.Press, X: 60, Y: 70})
+	s.Input(gc.PointerEvent{Type: pointer.Drag, X: 20, Y: 110}) // dragged up and left, off the canvas
+	if !s.Selecting() {
+		t.Fatal("not selecting while dragging")
+	}
+	if _, ok := s.Done(); ok {
+		t.Error("done while dragging")
+	}
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	s.Draw(c)
+	want := gc.Box{X: 20, Y: 70, W: 40, H: 30}
+	if rects := log.Find("rect"); len(rects) != 5 || rects[0].Points[0] != want.X || rects[0].Points[1] != want.Y+want.H || rects[0].W != want.W {
+		t.Errorf("drawn %v, want the box %v filled and framed", rects, want)
+	}
+
+	s.Input(gc.PointerEvent{Type: pointer.Release, X: 20, Y: 110})
+	if b, ok := s.Done(); !ok || b != want {
+		t.Errorf("done: %v, %v; want %v", b, ok, want)
+	}
+	if _, ok := s.Done(); ok {
+		t.Error("the selection is done twice")
+	}
+	c, log = gc.NewRecordingCanvas(1000, 500)
+	s.Draw(c)
+	if len(log.Calls) != 0 {
+		t.Error("the selection is drawn after it is done")
+	}
+
+	// a click selects nothing, and a drag without a press is ignored
+	s.Input(gc.PointerEvent{Type: pointer.Press, X: 30, Y: 30})
+	s.Input(gc.PointerEvent{Type: pointer.Release, X: 30, Y: 30})
+	if _, ok := s.Done(); ok {
+		t.Error("a click made a selection")
+	}
+	if s.Input(gc.PointerEvent{Type: pointer.Drag, X: 50, Y: 50}) {
+		t.Error("a drag without a press changed the selection")
+	}
+	s.Input(gc.PointerEvent{Type: pointer.Press, X: 30, Y: 30})
+	s.Input(gc.PointerEvent{Type: pointer.Cancel})
+	if s.Selecting() || s.Box() != (gc.Box{}) {
+		t.Error("cancelling did not clear the selection")
+	}
+}
@@ -0,0 +1,136 @@
+package widgets
+
+import (
+	"image/color"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Status is the state shown by an indicator
+type Status int
+
+// The states: off (nothing lit), then good, warning and error
+const (
+	StatusOff Status = iota
+	StatusOK
+	StatusWarning
+	StatusError
+)
+
+// The colors of the states, of unlit lamps, and of the housings of indicators
+var (
+	statuscolors = [...]color.NRGBA{{96, 96, 96, 255}, {40, 200, 80, 255}, {255, 180, 0, 255}, {230, 40, 40, 255}}
+	unlit        = color.NRGBA{70, 70, 70, 255}
+	housing      = color.NRGBA{40, 40, 40, 255}
+)
+
+// StatusColor returns the color of a state
+func StatusColor(s Status) color.NRGBA {
+	if s < 0 || int(s) >= len(statuscolors) {
+		s = StatusOff
+	}
+	return statuscolors[s]
+}
+
+// scale returns the color with its red, green and blue scaled by f (0-1)
+func scale(col color.NRGBA, f float32) color.NRGBA {
+	col.R, col.G, col.B = uint8(float32(col.R)*f), uint8(float32(col.G)*f), uint8(float32(col.B)*f)
+	return col
+}
+
+// lamp draws a lamp centered at (x, y), radius r, in absolute coordinates: glowing if lit,
+// dimmed if not
+func lamp(c *gc.Canvas, x, y, r float32, col color.NRGBA, lit bool) {
+	if !lit {
+		c.AbsCircle(x, y, r, scale(col, 0.35))
+		return
+	}
+	glow := func(a uint8) color.NRGBA {
+		g := col
+		g.A = uint8(uint32(a) * uint32(col.A) / 255)
+		return g
+	}
+	c.AbsCircleGradient(x, y, r*2.2, gc.RadialGradient{Stops: []gc.GradientStop{
+		{Offset: 0.4, Color: glow(110)}, {Offset: 1, Color: glow(0)},
+	}})
+	c.AbsCircle(x, y, r, col)
+	highlight := color.NRGBA{255, 255, 255, uint8(uint32(col.A) * 110 / 255)}
+	c.AbsCircle(x-r*0.3, y-r*0.3, r*0.35, highlight)
+}
+
+// LED draws an indicator light centered at (x, y), size across (a percentage of the canvas
+// width), glowing in col when on, and dim when off
+func LED(c *gc.Canvas, x, y, size float32, col color.NRGBA, on bool) {
+	ax, ay := c.AbsCoord(x, y)
+	lamp(c, ax, ay, size*c.Width/200, col, on)
+}
+
+// StatusLED draws an LED showing a state: lit in its color, or unlit if off
+func StatusLED(c *gc.Canvas, x, y, size float32, s Status) {
+	LED(c, x, y, size, StatusColor(s), s != StatusOff)
+}
+
+// TrafficLight draws a traffic light centered at (x, y), its lamps size across: red lit
+// for an error, amber for a warning and green for good, all unlit if off
+func TrafficLight(c *gc.Canvas, x, y, size float32, s Status) {
+	cx, cy := c.AbsCoord(x, y)
+	r := size * c.Width / 200
+	w, h := r*3, r*8
+	c.AbsRoundedRect(cx-w/2, cy-h/2, w, h, r*0.8, housing)
+	for i, state := range []Status{StatusError, StatusWarning, StatusOK} {
+		lamp(c, cx, cy+float32(i-1)*r*2.5, r, StatusColor(state), s == state)
+	}
+}
+
+// Battery draws a battery gauge centered at (x, y), size wide, filled to the level (0-1):
+// green, then amber below a half and red below a fifth. A charging battery shows a bolt.
+func Battery(c *gc.Canvas, x, y, size, level float32, charging bool, outline color.NRGBA) {
+	level = clamp01(level)
+	cx, cy := c.AbsCoord(x, y)
+	w := size * c.Width / 100
+	h := w * 0.5
+	lw := w * 0.06
+	left, top := cx-w/2, cy-h/2
+	c.AbsStrokedRect(left, top, w-lw*1.5, h, lw, outline)
+	c.AbsRect(left+w-lw*1.5, cy-h/5, lw*1.5, h*0.4, outline)
+	state := StatusOK
+	switch {
+	case level < 0.2:
+		state = StatusError
+	case level < 0.5:
+		state = StatusWarning
+	}
+	pad := lw * 1.5
+	if full := w - lw*1.5 - 2*pad; level > 0 {
+		c.AbsRect(left+pad, top+pad, full*level, h-2*pad, StatusColor(state))
+	}
+	if charging {
+		bx, by := cx-lw*0.75, cy
+		c.AbsPolygon(
+			[]float32{bx + h*0.1, bx - h*0.25, bx - h*0.02, bx - h*0.1, bx + h*0.25, bx + h*0.02},
+			[]float32{by - h*0.42, by + h*0.06, by + h*0.06, by + h*0.42, by - h*0.06, by - h*0.06},
+			outline)
+	}
+}
+
+// Signal draws a signal strength gauge of bars rising to the right, its lower left at
+// (x, y), size wide; strength (0-1) is the fraction of the bars lit in col
+func Signal(c *gc.Canvas, x, y, size, strength float32, bars int, col color.NRGBA) {
+	if bars < 1 {
+		return
+	}
+	lit := int(clamp01(strength)*float32(bars) + 0.5)
+	ax, ay := c.AbsCoord(x, y)
+	w := size * c.Width / 100
+	step := w / float32(bars)
+	bw := step * 0.7
+	for i := 0; i < bars; i++ {
+		bh := w * 0.8 * float32(i+1) / float32(bars)
+		fill := col
+		if i >= lit {
+			fill = unlit
+			fill.A = col.A
+		}
+		c.AbsRect(ax+float32(i)*step, ay-bh, bw, bh, fill)
+	}
+}
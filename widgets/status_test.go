@@ -0,0 +1,125 @@
+package widgets
+
+import (
+	"image/color"
+	"testing"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestStatusLED(t *testing.T) {
+	if StatusColor(Status(9)) != StatusColor(StatusOff) || StatusColor(-1) != StatusColor(StatusOff) {
+		t.Error("unknown states should have the color of off")
+	}
+	for _, s := range []Status{StatusOff, StatusOK, StatusWarning, StatusError} {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		StatusLED(c, 50, 50, 4, s)
+		glows, lamps := log.Find("ellipse"), log.Find("circle")
+		if s == StatusOff {
+			if len(glows) != 0 || len(lamps) != 1 || lamps[0].Color != scale(StatusColor(s), 0.35) {
+				t.Errorf("off: glows %v, lamps %v, want one dim lamp", glows, lamps)
+			}
+			continue
+		}
+		// a lit lamp glows, and has a highlight
+		if len(glows) != 1 || len(lamps) != 2 || lamps[0].Color != StatusColor(s) || lamps[0].Size != 2 {
+			t.Errorf("state %d: glows %v, lamps %v, want a glowing lamp in %v, radius 2", s, glows, lamps, StatusColor(s))
+		}
+	}
+}
+
+func TestTrafficLight(t *testing.T) {
+	tests := []struct {
+		s   Status
+		lit float32 // the height of the lit lamp, relative to the middle
+	}{
+		{StatusError, 1},
+		{StatusWarning, 0},
+		{StatusOK, -1},
+	}
+	for _, tc := range tests {
+		c, log := gc.NewRecordingCanvas(1000, 1000)
+		TrafficLight(c, 50, 50, 4, tc.s)
+		if n := len(log.Find("ellipse")); n != 1 {
+			t.Errorf("state %d: %d lamps lit, want 1", tc.s, n)
+		}
+		for _, lamp := range log.Find("circle") {
+			if lamp.Color == StatusColor(tc.s) {
+				if want := 50 + tc.lit*5; lamp.Points[1] != want {
+					t.Errorf("state %d: lit at %v, want %v", tc.s, lamp.Points[1], want)
+				}
+			}
+		}
+	}
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	TrafficLight(c, 50, 50, 4, StatusOff)
+	if n := len(log.Find("ellipse")); n != 0 {
+		t.Errorf("off: %d lamps lit, want none", n)
+	}
+}
+
+func TestBattery(t *testing.T) {
+	black := color.NRGBA{0, 0, 0, 255}
+	tests := []struct {
+		level    float32
+		charging bool
+		fill     Status
+	}{
+		{0.9, false, StatusOK},
+		{0.3, false, StatusWarning},
+		{0.1, true, StatusError},
+		{0, false, StatusOff},
+	}
+	var full float32
+	for _, tc := range tests {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		Battery(c, 50, 50, 10, tc.level, tc.charging, black)
+		var fills []gc.DrawCall
+		for _, r := range log.Find("rect") {
+			if r.Color != black {
+				fills = append(fills, r)
+			}
+		}
+		if tc.fill == StatusOff {
+			if len(fills) != 0 {
+				t.Errorf("empty: filled %v", fills)
+			}
+			continue
+		}
+		if len(fills) != 1 || fills[0].Color != StatusColor(tc.fill) {
+			t.Errorf("level %v: filled %v, want in %v", tc.level, fills, StatusColor(tc.fill))
+			continue
+		}
+		if full == 0 {
+			full = fills[0].W / tc.level
+		} else if w := full * tc.level; fills[0].W < w-0.01 || fills[0].W > w+0.01 {
+			t.Errorf("level %v: filled %v wide, want %v", tc.level, fills[0].W, w)
+		}
+		if bolt := len(log.Find("polygon")) == 1; bolt != tc.charging {
+			t.Errorf("level %v: bolt drawn: %v, want %v", tc.level, bolt, tc.charging)
+		}
+	}
+}
+
+func TestSignal(t *testing.T) {
+	green := color.NRGBA{0, 200, 0, 255}
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	Signal(c, 10, 10, 10, 0.5, 4, green)
+	bars := log.Find("rect")
+	if len(bars) != 4 {
+		t.Fatalf("%d bars, want 4", len(bars))
+	}
+	for i, b := range bars {
+		if lit := b.Color == green; lit != (i < 2) {
+			t.Errorf("bar %d lit: %v, want %v", i, lit, i < 2)
+		}
+		if i > 0 && b.H <= bars[i-1].H {
+			t.Errorf("bar %d is %v high, no higher than the one before", i, b.H)
+		}
+	}
+	c, log = gc.NewRecordingCanvas(1000, 500)
+	Signal(c, 10, 10, 10, 1, 0, green)
+	if n := len(log.Calls); n != 0 {
+		t.Errorf("%d calls for a signal of no bars, want none", n)
+	}
+}
@@ -0,0 +1,144 @@
+package sysmon
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Proc reads the CPU, memory and network use of a Linux system from /proc
+type Proc struct {
+	idle, total uint64 // CPU time at the last reading
+}
+
+// readproc parses a file of /proc
+func readproc(name string, parse func(io.Reader) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// CPUPercent returns the percentage of CPU time in use since the last reading
+// (since the system started, for the first)
+func (p *Proc) CPUPercent() (float64, error) {
+	var idle, total uint64
+	err := readproc("/proc/stat", func(r io.Reader) (err error) {
+		idle, total, err = parsestat(r)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	di, dt := idle-p.idle, total-p.total
+	p.idle, p.total = idle, total
+	if dt == 0 {
+		return 0, nil
+	}
+	return 100 * float64(dt-di) / float64(dt), nil
+}
+
+// Memory returns the bytes of memory in use (not available for use), and in all
+func (p *Proc) Memory() (used, total uint64, err error) {
+	err = readproc("/proc/meminfo", func(r io.Reader) (err error) {
+		used, total, err = parsememinfo(r)
+		return err
+	})
+	return used, total, err
+}
+
+// NetworkBytes returns the bytes sent and received by the network interfaces,
+// other than loopback
+func (p *Proc) NetworkBytes() (sent, received uint64, err error) {
+	err = readproc("/proc/net/dev", func(r io.Reader) (err error) {
+		sent, received, err = parsenetdev(r)
+		return err
+	})
+	return sent, received, err
+}
+
+// parsestat returns the idle (including waiting for I/O) and total CPU time
+// of all CPUs, from /proc/stat
+func parsestat(r io.Reader) (idle, total uint64, err error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		if len(f) < 5 || f[0] != "cpu" {
+			continue
+		}
+		for i, v := range f[1:] {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return 0, 0, err
+			}
+			if i < 8 { // guest time is counted in user time already
+				total += n
+			}
+			if i == 3 || i == 4 {
+				idle += n
+			}
+		}
+		return idle, total, nil
+	}
+	return 0, 0, errors.New("no cpu line")
+}
+
+// parsememinfo returns the memory in use and in all, from /proc/meminfo
+func parsememinfo(r io.Reader) (used, total uint64, err error) {
+	var available uint64
+	found := 0
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		if len(f) < 2 || (f[0] != "MemTotal:" && f[0] != "MemAvailable:") {
+			continue
+		}
+		n, err := strconv.ParseUint(f[1], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if f[0] == "MemTotal:" {
+			total = n * 1024
+		} else {
+			available = n * 1024
+		}
+		found++
+	}
+	if found < 2 {
+		return 0, 0, errors.New("no MemTotal or MemAvailable")
+	}
+	return total - available, total, nil
+}
+
+// parsenetdev returns the bytes sent and received by the interfaces other than
+// loopback, from /proc/net/dev
+func parsenetdev(r io.Reader) (sent, received uint64, err error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		i := strings.IndexByte(line, ':')
+		if i < 0 || strings.TrimSpace(line[:i]) == "lo" {
+			continue
+		}
+		f := strings.Fields(line[i+1:])
+		if len(f) < 9 {
+			continue
+		}
+		rx, err := strconv.ParseUint(f[0], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		tx, err := strconv.ParseUint(f[8], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		sent += tx
+		received += rx
+	}
+	return sent, received, s.Err()
+}
@@ -0,0 +1,35 @@
+package sysmon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProcParsers(t *testing.T) {
+	idle, total, err := parsestat(strings.NewReader("cpu  100 0 50 800 50 0 0 0 20 0\ncpu0 100 0 50 800 50 0 0 0 20 0\n"))
+	if err != nil || idle != 850 || total != 1000 {
+		t.Errorf("stat: idle %d, total %d, %v", idle, total, err)
+	}
+	used, all, err := parsememinfo(strings.NewReader("MemTotal:  1000 kB\nMemFree:  100 kB\nMemAvailable:  250 kB\n"))
+	if err != nil || used != 750*1024 || all != 1000*1024 {
+		t.Errorf("meminfo: used %d of %d, %v", used, all, err)
+	}
+	if _, _, err := parsememinfo(strings.NewReader("MemTotal:  1000 kB\n")); err == nil {
+		t.Error("meminfo without MemAvailable parsed")
+	}
+	dev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:  5000  10 0 0 0 0 0 0  5000  10 0 0 0 0 0 0
+  eth0:  3000  10 0 0 0 0 0 0  1000  10 0 0 0 0 0 0
+ wlan0:   500   1 0 0 0 0 0 0   200   1 0 0 0 0 0 0
+`
+	sent, received, err := parsenetdev(strings.NewReader(dev))
+	if err != nil || sent != 1200 || received != 3500 {
+		t.Errorf("net/dev: sent %d, received %d, %v", sent, received, err)
+	}
+	for n, want := range map[float64]string{512: "512 B", 1536: "1.5 KB", 200 * 1024 * 1024: "200 MB"} {
+		if got := bytesize(n); got != want {
+			t.Errorf("bytesize(%v) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package sysmon has meters of a computer's use -- CPU, memory and network --
+// for desk monitor dashboards drawn on a giocanvas. Readings come from providers,
+// such as Proc on Linux, or adapters to a library like gopsutil:
+//
+//	p := new(sysmon.Proc)
+//	cpu := sysmon.NewCPUMeter(p, 10, 80, 80, 2)
+//	mem := sysmon.NewMemoryMeter(p, 10, 65, 80, 2)
+//	net := sysmon.NewThroughput(p, 10, 20, 80, 35, 2)
+//	...
+//	cpu.Draw(c, now)
+//	mem.Draw(c, now)
+//	net.Draw(c, now)
+package sysmon
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+	"github.com/ajstarks/giocanvas/widgets"
+)
+
+// CPU reports the percentage of CPU time in use since it was last asked
+type CPU interface {
+	CPUPercent() (float64, error)
+}
+
+// Memory reports the bytes of memory in use, and in all
+type Memory interface {
+	Memory() (used, total uint64, err error)
+}
+
+// Network reports the bytes sent and received by the network interfaces since the
+// system started
+type Network interface {
+	NetworkBytes() (sent, received uint64, err error)
+}
+
+// bytesize formats a number of bytes in binary units
+func bytesize(n float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	i := 0
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 || n >= 100 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+// Meter is a labelled bar, its lower left at (X, Y), W wide, with text Size high (percentages
+// of the canvas width). It is filled to a percentage in Color, turning amber at Warn and red
+// at Alarm (if they are above zero), on a Track.
+type Meter struct {
+	X, Y, W, Size float32
+	Label         string
+	Color, Track  color.NRGBA
+	TextColor     color.NRGBA
+	Warn, Alarm   float64
+}
+
+// newmeter makes a meter with the default colors, warning at 75% and alarming at 90%
+func newmeter(label string, x, y, w, size float32) Meter {
+	return Meter{
+		X: x, Y: y, W: w, Size: size, Label: label,
+		Color: widgets.StatusColor(widgets.StatusOK), Track: color.NRGBA{60, 60, 60, 255},
+		TextColor: color.NRGBA{230, 230, 230, 255}, Warn: 75, Alarm: 90,
+	}
+}
+
+// Draw draws the meter filled to percent, with value shown at its right
+func (m Meter) Draw(c *gc.Canvas, percent float64, value string) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	ty := m.Y + m.Size*1.2*c.Width/c.Height
+	c.Text(m.X, ty, m.Size, m.Label, m.TextColor)
+	c.TextEnd(m.X+m.W, ty, m.Size, value, m.TextColor)
+
+	x, y := m.X*c.Width/100, (100-m.Y)*c.Height/100
+	w, h := m.W*c.Width/100, m.Size*c.Width/100*0.8
+	col := m.Color
+	switch {
+	case m.Alarm > 0 && percent >= m.Alarm:
+		col = widgets.StatusColor(widgets.StatusError)
+	case m.Warn > 0 && percent >= m.Warn:
+		col = widgets.StatusColor(widgets.StatusWarning)
+	}
+	c.AbsRoundedRect(x, y-h, w, h, h/2, m.Track)
+	if fw := w * float32(percent) / 100; fw > 0 {
+		c.AbsRoundedRect(x, y-h, fw, h, h/2, col)
+	}
+}
+
+// CPUMeter shows the CPU in use, read from Source every Interval
+type CPUMeter struct {
+	Meter
+	Source   CPU
+	Interval time.Duration
+	percent  float64
+	err      error
+	last     time.Time
+}
+
+// NewCPUMeter makes a CPU meter, its lower left at (x, y), w wide, reading every second
+func NewCPUMeter(src CPU, x, y, w, size float32) *CPUMeter {
+	return &CPUMeter{Meter: newmeter("CPU", x, y, w, size), Source: src, Interval: time.Second}
+}
+
+// Draw draws the meter at the time now, reading the CPU in use if it is due
+func (m *CPUMeter) Draw(c *gc.Canvas, now time.Time) {
+	if now.Sub(m.last) >= m.Interval {
+		m.percent, m.err = m.Source.CPUPercent()
+		m.last = now
+	}
+	value := fmt.Sprintf("%.0f%%", m.percent)
+	if m.err != nil {
+		value = "n/a"
+	}
+	m.Meter.Draw(c, m.percent, value)
+}
+
+// MemoryMeter shows the memory in use, read from Source every Interval
+type MemoryMeter struct {
+	Meter
+	Source      Memory
+	Interval    time.Duration
+	used, total uint64
+	err         error
+	last        time.Time
+}
+
+// NewMemoryMeter makes a memory meter, its lower left at (x, y), w wide, reading every second
+func NewMemoryMeter(src Memory, x, y, w, size float32) *MemoryMeter {
+	return &MemoryMeter{Meter: newmeter("Memory", x, y, w, size), Source: src, Interval: time.Second}
+}
+
+// Draw draws the meter at the time now, reading the memory in use if it is due
+func (m *MemoryMeter) Draw(c *gc.Canvas, now time.Time) {
+	if now.Sub(m.last) >= m.Interval {
+		m.used, m.total, m.err = m.Source.Memory()
+		m.last = now
+	}
+	if m.err != nil || m.total == 0 {
+		m.Meter.Draw(c, 0, "n/a")
+		return
+	}
+	m.Meter.Draw(c, 100*float64(m.used)/float64(m.total),
+		bytesize(float64(m.used))+" / "+bytesize(float64(m.total)))
+}
+
+// Throughput is a sparkline of the rates of sending and receiving over the network, in the
+// box with lower left (X, Y), W by H, with the latest rates above in text Size high.
+// Source is read every Interval, and the last Samples rates (at least 2) are kept.
+type Throughput struct {
+	X, Y, W, H, Size   float32
+	Source             Network
+	Interval           time.Duration
+	Samples            int
+	Sent, Received     color.NRGBA
+	TextColor          color.NRGBA
+	sent, received     []float64 // rates, in bytes per second
+	lastsent, lastrecv uint64    // the bytes at the last good reading
+	last, lastgood     time.Time // the times of the last reading, and the last good one
+	good               bool      // there has been a good reading
+	err                error
+}
+
+// NewThroughput makes a network sparkline, reading every second and keeping a minute of rates
+func NewThroughput(src Network, x, y, w, h, size float32) *Throughput {
+	return &Throughput{
+		X: x, Y: y, W: w, H: h, Size: size, Source: src, Interval: time.Second, Samples: 60,
+		Sent: color.NRGBA{255, 150, 50, 255}, Received: color.NRGBA{60, 160, 255, 255},
+		TextColor: color.NRGBA{230, 230, 230, 255},
+	}
+}
+
+// samples returns the number of rates kept, at least 2
+func (t *Throughput) samples() int {
+	if t.Samples < 2 {
+		return 2
+	}
+	return t.Samples
+}
+
+// sample reads the bytes sent and received, adding the rates since the last reading.
+// A failed reading is not retried until the next is due; the rate after it is
+// measured from the last good one.
+func (t *Throughput) sample(now time.Time) {
+	sent, recv, err := t.Source.NetworkBytes()
+	t.err = err
+	if err != nil {
+		t.last = now
+		return
+	}
+	if t.good && sent >= t.lastsent && recv >= t.lastrecv {
+		if secs := now.Sub(t.lastgood).Seconds(); secs > 0 {
+			t.sent = append(t.sent, float64(sent-t.lastsent)/secs)
+			t.received = append(t.received, float64(recv-t.lastrecv)/secs)
+			if n := len(t.sent) - t.samples(); n > 0 {
+				t.sent, t.received = t.sent[n:], t.received[n:]
+			}
+		}
+	}
+	t.lastsent, t.lastrecv, t.last, t.lastgood, t.good = sent, recv, now, now, true
+}
+
+// Draw draws the sparkline at the time now, reading the bytes sent and received if it is due
+func (t *Throughput) Draw(c *gc.Canvas, now time.Time) {
+	if t.last.IsZero() || now.Sub(t.last) >= t.Interval {
+		t.sample(now)
+	}
+	ty := t.Y + t.H + t.Size*0.6*c.Width/c.Height
+	c.Text(t.X, ty, t.Size, "Network", t.TextColor)
+	n := len(t.sent)
+	switch {
+	case t.err != nil:
+		c.TextEnd(t.X+t.W, ty, t.Size, "n/a", t.TextColor)
+		return
+	case n == 0:
+		return
+	}
+	c.TextEnd(t.X+t.W, ty, t.Size, "↓ "+bytesize(t.received[n-1])+"/s", t.Received)
+	c.TextEnd(t.X+t.W*0.7, ty, t.Size, "↑ "+bytesize(t.sent[n-1])+"/s", t.Sent)
+
+	peak := 1.0
+	for i := range t.sent {
+		if t.sent[i] > peak {
+			peak = t.sent[i]
+		}
+		if t.received[i] > peak {
+			peak = t.received[i]
+		}
+	}
+	samples := t.samples()
+	if samples < n {
+		samples = n
+	}
+	line := func(rates []float64, col color.NRGBA) {
+		if len(rates) < 2 {
+			return
+		}
+		x, y := make([]float32, len(rates)), make([]float32, len(rates))
+		for i, r := range rates {
+			x[i] = t.X + t.W*float32(samples-len(rates)+i)/float32(samples-1)
+			y[i] = t.Y + t.H*float32(r/peak)
+		}
+		c.Polyline(x, y, t.Size*0.1, col)
+	}
+	line(t.received, t.Received)
+	line(t.sent, t.Sent)
+}
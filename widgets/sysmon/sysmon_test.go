@@ -0,0 +1,58 @@
+package sysmon
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// network counts its readings, failing while err is set
+type network struct {
+	bytes, reads int
+	err          error
+}
+
+func (n *network) NetworkBytes() (uint64, uint64, error) {
+	n.reads++
+	n.bytes += 1000
+	return uint64(n.bytes), uint64(2 * n.bytes), n.err
+}
+
+func TestThroughput(t *testing.T) {
+	src := new(network)
+	tp := NewThroughput(src, 10, 20, 80, 30, 2)
+	tp.Samples = 1
+	c, log := gc.NewRecordingCanvas(1000, 1000)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		tp.Draw(c, start.Add(time.Duration(i)*time.Second))
+	}
+	if len(tp.sent) != 2 || tp.sent[1] != 1000 || tp.received[1] != 2000 {
+		t.Errorf("rates: sent %v, received %v", tp.sent, tp.received)
+	}
+	lines := log.Find("polyline")
+	if len(lines) == 0 {
+		t.Error("no sparklines")
+	}
+	for _, call := range lines {
+		for _, v := range call.Points {
+			if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+				t.Fatalf("polyline points %v", call.Points)
+			}
+		}
+	}
+
+	// a failing source is read once an interval, not every frame
+	src.err = errors.New("no network")
+	now := start.Add(10 * time.Second)
+	reads := src.reads
+	for i := 0; i < 10; i++ {
+		tp.Draw(c, now.Add(time.Duration(i)*time.Millisecond))
+	}
+	if src.reads != reads+1 {
+		t.Errorf("%d readings in an interval", src.reads-reads)
+	}
+}
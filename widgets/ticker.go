@@ -0,0 +1,59 @@
+// Package widgets has ready-made components drawn on a giocanvas,
+// placed using percentage-based coordinates
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"gioui.org/op/clip"
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Ticker is a line of text scrolling through a band starting at X, Width wide,
+// with its baseline at Y. Speed is in percent of the canvas width per second:
+// positive speeds move the text left, negative speeds right. Gap separates the
+// repeats of the text. The band is filled with Background if it is not transparent.
+type Ticker struct {
+	Text        string
+	X, Y, Width float32
+	Size        float32
+	Color       color.NRGBA
+	Background  color.NRGBA
+	Speed       float32
+	Gap         float32
+	Start       time.Time
+}
+
+// NewTicker makes a ticker that starts scrolling now
+func NewTicker(s string, x, y, width, size float32, textcolor color.NRGBA) *Ticker {
+	return &Ticker{Text: s, X: x, Y: y, Width: width, Size: size, Color: textcolor, Speed: 10, Gap: size * 4, Start: time.Now()}
+}
+
+// Draw draws the ticker at the time now. The text is shaped once, and
+// then only moved, so drawing every frame is cheap.
+func (t *Ticker) Draw(c *gc.Canvas, now time.Time) {
+	span := c.TextWidth(t.Text, t.Size) + t.Gap
+	if span <= 0 {
+		return
+	}
+	shift := float32(math.Mod(float64(t.Speed)*now.Sub(t.Start).Seconds(), float64(span)))
+	if shift < 0 {
+		shift += span
+	}
+	size := t.Size * c.Width / 100
+	left, base := c.AbsCoord(t.X, t.Y)
+	right, _ := c.AbsCoord(t.X+t.Width, t.Y)
+	band := image.Rect(int(left), int(base-size*1.3), int(right), int(base+size*0.5))
+	if t.Background.A > 0 {
+		c.AbsRect(float32(band.Min.X), float32(band.Min.Y), float32(band.Dx()), float32(band.Dy()), t.Background)
+	}
+	stack := clip.Rect(band).Push(c.Context.Ops)
+	for x := t.X - shift; x < t.X+t.Width; x += span {
+		ax, _ := c.AbsCoord(x, t.Y)
+		c.AbsText(ax, base, size, t.Text, t.Color)
+	}
+	stack.Pop()
+}
@@ -0,0 +1,64 @@
+package widgets
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+func TestTicker(t *testing.T) {
+	start := time.Now()
+	tk := NewTicker("Markets are up", 10, 5, 80, 2, color.NRGBA{255, 255, 255, 255})
+	tk.Start = start
+	span := func(c *gc.Canvas) float32 { return c.TextWidth(tk.Text, tk.Size) + tk.Gap }
+	tests := []struct {
+		name  string
+		speed float32
+		at    time.Duration
+		first func(span float32) float32 // where the first repeat begins
+	}{
+		{"at the start", 10, 0, func(float32) float32 { return 10 }},
+		{"moving left", 10, time.Second, func(float32) float32 { return 0 }},
+		{"moving right", -10, time.Second, func(span float32) float32 { return 20 - span }},
+	}
+	for _, tc := range tests {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		tk.Speed = tc.speed
+		tk.Draw(c, start.Add(tc.at))
+		text := log.Find("text")
+		if len(text) == 0 {
+			t.Errorf("%s: no text drawn", tc.name)
+			continue
+		}
+		s := span(c)
+		if x, want := text[0].Points[0], tc.first(s); math.Abs(float64(x-want)) > 0.01 {
+			t.Errorf("%s: the text begins at %v, want %v", tc.name, x, want)
+		}
+		// repeats, span apart, across the band, and no further
+		for i, tx := range text {
+			if tx.Text != tk.Text || math.Abs(float64(tx.Points[0]-text[0].Points[0]-float32(i)*s)) > 0.01 {
+				t.Errorf("%s: repeat %d at %v, want %v", tc.name, i, tx.Points[0], text[0].Points[0]+float32(i)*s)
+			}
+		}
+		if last := text[len(text)-1].Points[0]; last >= 90 || last+s < 90 {
+			t.Errorf("%s: the last repeat begins at %v, not reaching the end of the band at 90", tc.name, last)
+		}
+	}
+}
+
+func TestTickerBackground(t *testing.T) {
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	tk := NewTicker("News", 10, 5, 80, 2, color.NRGBA{255, 255, 255, 255})
+	tk.Draw(c, tk.Start)
+	if n := len(log.Find("rect")); n != 0 {
+		t.Errorf("%d backgrounds drawn, want none when transparent", n)
+	}
+	tk.Background = color.NRGBA{0, 0, 80, 255}
+	tk.Draw(c, tk.Start)
+	if r := log.Find("rect"); len(r) != 1 || math.Abs(float64(r[0].Points[0]-10)) > 0.1 || math.Abs(float64(r[0].W-80)) > 0.1 {
+		t.Errorf("background %v, want the band from 10, 80 wide", r)
+	}
+}
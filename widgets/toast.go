@@ -0,0 +1,83 @@
+package widgets
+
+import (
+	"image/color"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Toasts shows queued messages one at a time, each sliding in at the Corner
+// of the canvas (Margin from its edges), staying for Duration, then sliding out.
+// Size is the text size; Color and Background style the message.
+type Toasts struct {
+	Corner            gc.Anchor
+	Margin, Size      float32
+	Color, Background color.NRGBA
+	Duration, Slide   time.Duration
+	queue             []string
+	start             time.Time
+}
+
+// NewToasts makes a message queue showing at the corner, each message for three seconds
+func NewToasts(corner gc.Anchor, size float32) *Toasts {
+	return &Toasts{
+		Corner: corner, Margin: 2, Size: size,
+		Color: color.NRGBA{255, 255, 255, 255}, Background: color.NRGBA{40, 40, 40, 220},
+		Duration: 3 * time.Second, Slide: 300 * time.Millisecond,
+	}
+}
+
+// Show adds a message to the queue
+func (t *Toasts) Show(msg string) {
+	t.queue = append(t.queue, msg)
+}
+
+// Active reports whether there are messages to show; while there are, keep drawing frames
+func (t *Toasts) Active() bool {
+	return len(t.queue) > 0
+}
+
+// Draw draws the current message at the time now, moving on to the next when it is done,
+// and reports whether any messages remain
+func (t *Toasts) Draw(c *gc.Canvas, now time.Time) bool {
+	if len(t.queue) == 0 {
+		return false
+	}
+	if t.start.IsZero() {
+		t.start = now
+	}
+	elapsed := now.Sub(t.start)
+	if elapsed >= t.Duration+2*t.Slide {
+		t.queue = t.queue[1:]
+		t.start = time.Time{}
+		return t.Draw(c, now)
+	}
+	// p is how far the message is shown: 0 off the canvas, 1 in place
+	p := float32(1)
+	switch {
+	case elapsed < t.Slide:
+		p = float32(gc.EaseOut(float64(elapsed) / float64(t.Slide)))
+	case elapsed > t.Duration+t.Slide:
+		p = 1 - float32(gc.EaseIn(float64(elapsed-t.Duration-t.Slide)/float64(t.Slide)))
+	}
+	msg := t.queue[0]
+	pad := t.Size * 0.8
+	w := c.TextWidth(msg, t.Size) + 2*pad
+	h := (t.Size + 2*pad) * c.Width / c.Height
+	b := gc.Page.Inset(t.Margin).Align(t.Corner, gc.Size{W: w, H: h})
+	// slide in from the nearest side: left or right, or top or bottom for centered corners
+	switch {
+	case t.Corner.X < 0.5:
+		b.X -= (1 - p) * (b.X + w)
+	case t.Corner.X > 0.5:
+		b.X += (1 - p) * (100 - b.X)
+	case t.Corner.Y < 0.5:
+		b.Y -= (1 - p) * (b.Y + h)
+	default:
+		b.Y += (1 - p) * (100 - b.Y)
+	}
+	c.BoxRect(b, t.Background)
+	c.Text(b.X+pad, b.Y+pad*c.Width/c.Height, t.Size, msg, t.Color)
+	return true
+}
@@ -0,0 +1,62 @@
+package widgets
+
+import (
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// drawtoast draws the toasts at now, returning the message shown and its box, if any
+func drawtoast(ts *Toasts, now time.Time) (string, gc.DrawCall, bool) {
+	c, log := gc.NewRecordingCanvas(1000, 500)
+	more := ts.Draw(c, now)
+	text, rect := log.Find("text"), log.Find("rect")
+	if !more || len(text) != 1 || len(rect) != 1 {
+		return "", gc.DrawCall{}, more
+	}
+	return text[0].Text, rect[0], more
+}
+
+func TestToastQueue(t *testing.T) {
+	ts := NewToasts(gc.AnchorBottomRight, 2)
+	ts.Show("first")
+	ts.Show("second")
+	start := time.Now()
+	each := ts.Duration + 2*ts.Slide
+	tests := []struct {
+		at   time.Duration
+		want string
+	}{
+		{0, "first"},
+		{each - time.Millisecond, "first"},
+		{each, "second"},
+		{2*each - time.Millisecond, "second"},
+	}
+	for _, tc := range tests {
+		if msg, _, _ := drawtoast(ts, start.Add(tc.at)); msg != tc.want {
+			t.Errorf("at %v: showing %q, want %q", tc.at, msg, tc.want)
+		}
+	}
+	if _, _, more := drawtoast(ts, start.Add(2*each)); more || ts.Active() {
+		t.Error("the toasts are still active after showing every message")
+	}
+}
+
+func TestToastSlide(t *testing.T) {
+	for _, corner := range []gc.Anchor{gc.AnchorBottomRight, gc.AnchorTopLeft, gc.AnchorBottom} {
+		ts := NewToasts(corner, 2)
+		ts.Show("hello")
+		start := time.Now()
+		_, off, _ := drawtoast(ts, start)
+		_, in, _ := drawtoast(ts, start.Add(ts.Slide))
+		_, out, _ := drawtoast(ts, start.Add(ts.Duration+2*ts.Slide-time.Millisecond))
+		onpage := func(r gc.DrawCall) bool {
+			x, top := r.Points[0], r.Points[1]
+			return x >= 0 && x+r.W <= 100 && top <= 100 && top-r.H >= 0
+		}
+		if onpage(off) || !onpage(in) || onpage(out) {
+			t.Errorf("corner %v: the toast should slide in, at %v, to %v, and out, to %v", corner, off.Points, in.Points, out.Points)
+		}
+	}
+}
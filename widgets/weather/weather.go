@@ -0,0 +1,207 @@
+// Package weather has a panel of the current weather for desk monitor dashboards
+// drawn on a giocanvas, beside the meters of sysmon. Conditions come from a Provider,
+// such as an adapter to a weather service:
+//
+//	w := weather.NewPanel(provider, 10, 90, 8)
+//	w.Invalidate = win.Invalidate
+//	...
+//	w.Draw(c, now)
+package weather
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// Sky is the kind of weather, shown by the panel's icon
+type Sky int
+
+// The kinds of weather
+const (
+	Clear Sky = iota
+	PartlyCloudy
+	Cloudy
+	Rain
+	Snow
+	Storm
+	Fog
+)
+
+// Conditions is the weather at a place: the temperature (degrees Celsius), the relative
+// humidity (percent), the wind speed (km/h), the sky, and a summary such as "Light rain"
+type Conditions struct {
+	Place       string
+	Temperature float64
+	Humidity    float64
+	Wind        float64
+	Sky         Sky
+	Summary     string
+}
+
+// Provider reports the current weather
+type Provider interface {
+	Weather() (Conditions, error)
+}
+
+// Panel shows the weather: an icon of the sky, the temperature Size high (a percentage of
+// the canvas width), and the place, summary, humidity and wind beneath, its upper left at
+// (X, Y). Source is read every Interval, in the background, as reading the weather is slow;
+// Invalidate, if set, is called when a reading is done, to redraw. Fahrenheit shows degrees
+// Fahrenheit and miles per hour.
+type Panel struct {
+	X, Y, Size float32
+	Source     Provider
+	Interval   time.Duration
+	Fahrenheit bool
+	Color      color.NRGBA
+	Invalidate func()
+
+	mu      sync.Mutex
+	now     Conditions
+	ok      bool // there has been a good reading
+	err     error
+	last    time.Time
+	reading bool
+}
+
+// NewPanel makes a weather panel, reading every ten minutes
+func NewPanel(src Provider, x, y, size float32) *Panel {
+	return &Panel{X: x, Y: y, Size: size, Source: src, Interval: 10 * time.Minute,
+		Color: color.NRGBA{230, 230, 230, 255}}
+}
+
+// read reads the weather, keeping the last good reading if it fails
+func (p *Panel) read() {
+	w, err := p.Source.Weather()
+	p.mu.Lock()
+	p.err, p.reading = err, false
+	if err == nil {
+		p.now, p.ok = w, true
+	}
+	p.mu.Unlock()
+	if p.Invalidate != nil {
+		p.Invalidate()
+	}
+}
+
+// Conditions returns the last weather read, and whether there has been one
+func (p *Panel) Conditions() (Conditions, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.now, p.ok
+}
+
+// temperature formats degrees Celsius, or Fahrenheit
+func temperature(celsius float64, fahrenheit bool) string {
+	if fahrenheit {
+		return fmt.Sprintf("%.0f°F", celsius*9/5+32)
+	}
+	return fmt.Sprintf("%.0f°C", celsius)
+}
+
+// wind formats a speed in km/h, or miles per hour
+func wind(kmh float64, mph bool) string {
+	if mph {
+		return fmt.Sprintf("%.0f mph", kmh/1.609344)
+	}
+	return fmt.Sprintf("%.0f km/h", kmh)
+}
+
+// Draw draws the panel at the time now, starting a reading of the weather if it is due
+func (p *Panel) Draw(c *gc.Canvas, now time.Time) {
+	p.mu.Lock()
+	if !p.reading && (p.last.IsZero() || now.Sub(p.last) >= p.Interval) {
+		p.reading, p.last = true, now
+		go p.read()
+	}
+	w, ok := p.now, p.ok
+	p.mu.Unlock()
+
+	ax, ay := c.AbsCoord(p.X, p.Y)
+	s := p.Size * c.Width / 100
+	tx := ax + s*1.9
+	if !ok {
+		c.AbsText(tx, ay+s*1.1, s, "n/a", p.Color)
+		return
+	}
+	icon(c, ax+s*0.8, ay+s*0.7, s*1.4, w.Sky)
+	c.AbsText(tx, ay+s*1.1, s, temperature(w.Temperature, p.Fahrenheit), p.Color)
+	muted := p.Color
+	muted.A = uint8(uint32(p.Color.A) * 180 / 255)
+	line := w.Summary
+	if w.Place != "" {
+		line = w.Place + " · " + w.Summary
+	}
+	c.AbsText(tx, ay+s*1.65, s*0.35, line, p.Color)
+	c.AbsText(tx, ay+s*2.1, s*0.3, fmt.Sprintf("Humidity %.0f%%  Wind %s", w.Humidity, wind(w.Wind, p.Fahrenheit)), muted)
+}
+
+// The colors of the icons
+var (
+	sun      = color.NRGBA{255, 200, 40, 255}
+	cloud    = color.NRGBA{200, 205, 215, 255}
+	darkness = color.NRGBA{120, 125, 140, 255}
+	raindrop = color.NRGBA{80, 150, 255, 255}
+	snowfall = color.NRGBA{245, 245, 255, 255}
+	bolt     = color.NRGBA{255, 180, 0, 255}
+)
+
+// icon draws the sky centered at (x, y), about s across, in absolute coordinates
+func icon(c *gc.Canvas, x, y, s float32, sky Sky) {
+	switch sky {
+	case Clear:
+		drawsun(c, x, y, s*0.22)
+	case PartlyCloudy:
+		drawsun(c, x+s*0.15, y-s*0.15, s*0.17)
+		drawcloud(c, x-s*0.05, y+s*0.1, s*0.8, cloud)
+	case Cloudy, Fog:
+		drawcloud(c, x, y, s, cloud)
+	default:
+		drawcloud(c, x, y-s*0.12, s, darkness)
+	}
+	lw := s * 0.05
+	switch sky {
+	case Rain:
+		for i := -1; i <= 1; i++ {
+			dx := float32(i) * s * 0.22
+			c.AbsLine(x+dx, y+s*0.2, x+dx-s*0.06, y+s*0.4, lw, raindrop)
+		}
+	case Snow:
+		for i := -1; i <= 1; i++ {
+			c.AbsCircle(x+float32(i)*s*0.22, y+s*0.3+float32(i*i)*s*0.08, s*0.05, snowfall)
+		}
+	case Storm:
+		c.AbsPolygon(
+			[]float32{x + s*0.05, x - s*0.12, x, x - s*0.06, x + s*0.14, x + s*0.02},
+			[]float32{y + s*0.08, y + s*0.3, y + s*0.3, y + s*0.48, y + s*0.24, y + s*0.24},
+			bolt)
+	case Fog:
+		for i := 0; i < 3; i++ {
+			fy := y + s*0.22 + float32(i)*s*0.1
+			c.AbsLine(x-s*0.4, fy, x+s*0.4, fy, lw, darkness)
+		}
+	}
+}
+
+// drawsun draws a sun of radius r centered at (x, y), with rays
+func drawsun(c *gc.Canvas, x, y, r float32) {
+	c.AbsCircle(x, y, r, sun)
+	for i := 0; i < 8; i++ {
+		sin, cos := math.Sincos(float64(i) * math.Pi / 4)
+		dx, dy := float32(cos), float32(sin)
+		c.AbsLine(x+dx*r*1.3, y+dy*r*1.3, x+dx*r*1.7, y+dy*r*1.7, r*0.2, sun)
+	}
+}
+
+// drawcloud draws a cloud about s across, centered at (x, y)
+func drawcloud(c *gc.Canvas, x, y, s float32, col color.NRGBA) {
+	c.AbsCircle(x-s*0.2, y, s*0.17, col)
+	c.AbsCircle(x+s*0.02, y-s*0.1, s*0.22, col)
+	c.AbsCircle(x+s*0.24, y+s*0.01, s*0.15, col)
+	c.AbsRect(x-s*0.2, y, s*0.44, s*0.16, col)
+}
@@ -0,0 +1,62 @@
+package weather
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gc "github.com/ajstarks/giocanvas"
+)
+
+// provider reports fixed conditions, or an error
+type provider struct {
+	w   Conditions
+	err error
+}
+
+func (p *provider) Weather() (Conditions, error) {
+	return p.w, p.err
+}
+
+func TestPanel(t *testing.T) {
+	src := &provider{w: Conditions{Place: "Oslo", Temperature: 21, Humidity: 40, Wind: 16.09344, Sky: Rain, Summary: "Light rain"}}
+	p := NewPanel(src, 10, 90, 8)
+	done := make(chan bool, 4)
+	p.Invalidate = func() { done <- true }
+	texts := func(now time.Time) string {
+		c, log := gc.NewRecordingCanvas(1000, 500)
+		p.Draw(c, now)
+		var s []string
+		for _, call := range log.Find("text") {
+			s = append(s, call.Text)
+		}
+		return strings.Join(s, "|")
+	}
+	start := time.Now()
+	if got := texts(start); got != "n/a" {
+		t.Errorf("before reading: %q", got)
+	}
+	<-done
+	if got, want := texts(start.Add(time.Second)), "21°C|Oslo · Light rain|Humidity 40%  Wind 16 km/h"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	p.Fahrenheit = true
+	if got := texts(start.Add(time.Second)); !strings.HasPrefix(got, "70°F|") || !strings.HasSuffix(got, "Wind 10 mph") {
+		t.Errorf("fahrenheit: %q", got)
+	}
+
+	// a failed reading keeps the last good one, and is not retried until the next is due
+	src.err = errors.New("offline")
+	texts(start.Add(p.Interval))
+	<-done
+	texts(start.Add(p.Interval + time.Second))
+	select {
+	case <-done:
+		t.Error("read again before the interval")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if w, ok := p.Conditions(); !ok || w.Temperature != 21 {
+		t.Errorf("conditions after a failure: %v, %v", w, ok)
+	}
+}